@@ -4,20 +4,48 @@ import (
 	"fmt"
 
 	component "github.com/motain/of-catalog/internal/modules/component/cmd"
+	history "github.com/motain/of-catalog/internal/modules/history/cmd"
 	metric "github.com/motain/of-catalog/internal/modules/metric/cmd"
 	scorecard "github.com/motain/of-catalog/internal/modules/scorecard/cmd"
+	state "github.com/motain/of-catalog/internal/modules/state/cmd"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/yaml"
 	"github.com/spf13/cobra"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "ofc",
 	Short: "⚽ onefootball catalog CLI",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		config := configservice.NewConfigService()
+		if err := catalogservice.ValidateBackend(config.GetCatalogBackend()); err != nil {
+			return err
+		}
+
+		backend, err := statebackend.NewStateBackend(config)
+		if err != nil {
+			return err
+		}
+		yaml.SetStateBackend(backend)
+
+		historyLimit, err := statebackend.HistoryLimit(config)
+		if err != nil {
+			return err
+		}
+		yaml.SetStateHistoryLimit(historyLimit)
+
+		return nil
+	},
 }
 
 func Execute() {
 	rootCmd.AddCommand(component.Init())
 	rootCmd.AddCommand(metric.Init())
 	rootCmd.AddCommand(scorecard.Init())
+	rootCmd.AddCommand(state.Init())
+	rootCmd.AddCommand(history.Init())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
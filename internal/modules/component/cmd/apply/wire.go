@@ -6,6 +6,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/component/handler"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/documentservice"
@@ -30,6 +31,10 @@ var ProviderSet = wire.NewSet(
 	compassservice.NewCompassService,
 	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
 
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
 	// Githubservice
 	githubservice.NewGitHubClient,
 	githubservice.NewGitHubService,
@@ -50,8 +55,7 @@ var ProviderSet = wire.NewSet(
 
 	// --- component module ---
 	// Repository
-	repository.NewRepository,
-	wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)),
+	repository.NewCatalogRepository,
 
 	// ApplyHandler
 	handler.NewApplyHandler,
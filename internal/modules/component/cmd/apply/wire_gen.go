@@ -10,6 +10,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/component/handler"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/documentservice"
@@ -29,13 +30,14 @@ func initializeHandler() *handler.ApplyHandler {
 	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
 	httpClientInterface := compassservice.NewHTTPClient(configService)
 	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
-	repositoryRepository := repository.NewRepository(compassService)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
 	ownerService := ownerservice.NewOwnerService()
 	documentService := documentservice.NewDocumentService(gitHubService)
-	applyHandler := handler.NewApplyHandler(gitHubService, repositoryRepository, ownerService, documentService)
+	applyHandler := handler.NewApplyHandler(gitHubService, repositoryInterface, ownerService, documentService)
 	return applyHandler
 }
 
 // wire.go:
 
-var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), prometheusservice.NewPrometheusService, prometheusservice.NewPrometheusClient, wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)), ownerservice.NewOwnerService, wire.Bind(new(ownerservice.OwnerServiceInterface), new(*ownerservice.OwnerService)), documentservice.NewDocumentService, wire.Bind(new(documentservice.DocumentServiceInterface), new(*documentservice.DocumentService)), repository.NewRepository, wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)), handler.NewApplyHandler)
+var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), prometheusservice.NewPrometheusService, prometheusservice.NewPrometheusClient, wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)), ownerservice.NewOwnerService, wire.Bind(new(ownerservice.OwnerServiceInterface), new(*ownerservice.OwnerService)), documentservice.NewDocumentService, wire.Bind(new(documentservice.DocumentServiceInterface), new(*documentservice.DocumentService)), repository.NewCatalogRepository, handler.NewApplyHandler)
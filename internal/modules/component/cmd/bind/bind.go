@@ -1,19 +1,33 @@
 package bind
 
 import (
+	"log"
+	"time"
+
 	"github.com/motain/of-catalog/internal/utils/commandcontext"
 	"github.com/motain/of-catalog/internal/utils/yaml"
 	"github.com/spf13/cobra"
 )
 
 func Init() *cobra.Command {
-	return &cobra.Command{
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
 		Use:   "bind",
 		Short: "Bind components to metrics",
 		Run: func(cmd *cobra.Command, args []string) {
 			handler := initializeHandler()
 			ctx := commandcontext.Init()
-			handler.Bind(ctx, yaml.StateLocation)
+			err := yaml.WithStateLock("component bind", lockTimeout, func() error {
+				return handler.Bind(ctx, yaml.StateLocation)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
 		},
 	}
+
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/component/handler"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/githubservice"
@@ -28,6 +29,10 @@ var ProviderSet = wire.NewSet(
 	compassservice.NewCompassService,
 	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
 
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
 	// Githubservice
 	githubservice.NewGitHubClient,
 	githubservice.NewGitHubService,
@@ -40,8 +45,7 @@ var ProviderSet = wire.NewSet(
 
 	// --- metric module ---
 	// Repository
-	repository.NewRepository,
-	wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)),
+	repository.NewCatalogRepository,
 
 	// BindHandler
 	handler.NewBindHandler,
@@ -4,6 +4,7 @@ import (
 	"github.com/motain/of-catalog/internal/modules/component/cmd/apply"
 	"github.com/motain/of-catalog/internal/modules/component/cmd/bind"
 	"github.com/motain/of-catalog/internal/modules/component/cmd/compute"
+	"github.com/motain/of-catalog/internal/modules/component/cmd/pushpending"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +17,7 @@ func Init() *cobra.Command {
 	componentCmd.AddCommand(apply.Init())
 	componentCmd.AddCommand(bind.Init())
 	componentCmd.AddCommand(compute.Init())
+	componentCmd.AddCommand(pushpending.Init())
 
 	return componentCmd
 }
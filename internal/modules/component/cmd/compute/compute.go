@@ -2,15 +2,21 @@ package compute
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/modules/component/utils"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 	"github.com/motain/of-catalog/internal/utils/commandcontext"
 	"github.com/motain/of-catalog/internal/utils/yaml"
 	"github.com/spf13/cobra"
 )
 
 func Init() *cobra.Command {
-	var componentName, metricName string
-	var all bool
+	var componentName, metricName, recordDir, replayDir, from, to string
+	var all, noPush bool
+	var pushConcurrency int
+	var dedupWindow, step time.Duration
 
 	cmd := &cobra.Command{
 		Use:   "compute",
@@ -27,15 +33,96 @@ func Init() *cobra.Command {
 				return
 			}
 
+			if recordDir != "" && replayDir != "" {
+				fmt.Println("Error: --record and --replay cannot be used together")
+				cmd.Help()
+				return
+			}
+			if cassetteErr := useCassette(recordDir, replayDir); cassetteErr != nil {
+				fmt.Printf("Error: %v\n", cassetteErr)
+				return
+			}
+			if replayDir != "" {
+				// Replayed values reproduce a past computation, they are not pushed
+				noPush = true
+			}
+
+			backfillTimes, backfillErr := parseBackfillTimes(from, to, step)
+			if backfillErr != nil {
+				fmt.Printf("Error: %v\n", backfillErr)
+				cmd.Help()
+				return
+			}
+
 			handler := initializeHandler()
 			ctx := commandcontext.Init()
-			handler.Compute(ctx, componentName, all, metricName, yaml.StateLocation)
+			handler.Compute(ctx, componentName, all, metricName, yaml.StateLocation, noPush, newPushOptions(pushConcurrency, dedupWindow, backfillTimes), backfillTimes)
 		},
 	}
 
 	cmd.Flags().StringVarP(&componentName, "component", "c", "", "Name of the component")
 	cmd.Flags().StringVarP(&metricName, "metric", "m", "", "Name of the metric")
 	cmd.Flags().BoolVarP(&all, "all", "a", false, "Compute all metrics for the component")
+	cmd.Flags().BoolVar(&noPush, "no-push", false, "Print computed values instead of pushing them to Compass")
+	cmd.Flags().IntVar(&pushConcurrency, "push-concurrency", repository.DefaultPushConcurrency, "Number of values pushed to Compass at the same time")
+	cmd.Flags().DurationVar(&dedupWindow, "dedup-window", repository.DefaultDedupWindow, "Skip values identical to the last one pushed for the same metric source within this window, 0 disables it, ignored with --from")
+	cmd.Flags().StringVar(&recordDir, "record", "", "Record the HTTP interactions of the facts in a cassette directory")
+	cmd.Flags().StringVar(&from, "from", "", "Backfill the metrics from this time (RFC 3339), only metrics with prometheus facts")
+	cmd.Flags().StringVar(&to, "to", "", "Backfill the metrics until this time (RFC 3339) (default now)")
+	cmd.Flags().DurationVar(&step, "step", time.Hour, "Time between two backfilled values")
+	cmd.Flags().StringVar(&replayDir, "replay", "", "Answer the HTTP requests of the facts from a cassette directory, computed values are printed")
 
 	return cmd
 }
+
+// useCassette sets the cassette the HTTP clients of the facts are built with
+func useCassette(recordDir, replayDir string) error {
+	if recordDir != "" {
+		return cassette.Use(cassette.Record, recordDir)
+	}
+	if replayDir != "" {
+		return cassette.Use(cassette.Replay, replayDir)
+	}
+
+	return nil
+}
+
+// newPushOptions returns the options of the pushes. Backfilled values are all pushed: values recorded one
+// step apart are distinct points of the history, even when identical.
+func newPushOptions(concurrency int, dedupWindow time.Duration, backfillTimes []time.Time) repository.PushOptions {
+	if backfillTimes != nil {
+		dedupWindow = 0
+	}
+
+	return repository.PushOptions{Concurrency: concurrency, DedupWindow: dedupWindow}
+}
+
+// parseBackfillTimes returns the times the metrics are backfilled at, nil to compute them now
+func parseBackfillTimes(from, to string, step time.Duration) ([]time.Time, error) {
+	if from == "" {
+		if to != "" {
+			return nil, fmt.Errorf("--to requires --from")
+		}
+		return nil, nil
+	}
+
+	fromTime, fromErr := time.Parse(time.RFC3339, from)
+	if fromErr != nil {
+		return nil, fmt.Errorf("invalid --from: %v", fromErr)
+	}
+	toTime := time.Now()
+	if to != "" {
+		var toErr error
+		if toTime, toErr = time.Parse(time.RFC3339, to); toErr != nil {
+			return nil, fmt.Errorf("invalid --to: %v", toErr)
+		}
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("--step must be positive")
+	}
+	if toTime.Before(fromTime) {
+		return nil, fmt.Errorf("--from must be before --to")
+	}
+
+	return utils.BackfillTimes(fromTime, toTime, step), nil
+}
@@ -0,0 +1,18 @@
+package compute
+
+import (
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPushOptions(t *testing.T) {
+	assert.Equal(t, repository.PushOptions{Concurrency: 4, DedupWindow: time.Minute}, newPushOptions(4, time.Minute, nil))
+
+	backfillTimes, err := parseBackfillTimes("2026-10-01T00:00:00Z", "2026-10-01T02:00:00Z", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, repository.PushOptions{Concurrency: 4}, newPushOptions(4, time.Minute, backfillTimes), "backfilled values are not deduplicated")
+}
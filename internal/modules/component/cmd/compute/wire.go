@@ -6,13 +6,17 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/component/handler"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/factsystem/aggregators"
 	"github.com/motain/of-catalog/internal/services/factsystem/extractors"
 	"github.com/motain/of-catalog/internal/services/factsystem/processor"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
 	"github.com/motain/of-catalog/internal/services/factsystem/validators"
 	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
 	"github.com/motain/of-catalog/internal/services/jsonservice"
 	"github.com/motain/of-catalog/internal/services/keyringservice"
 	"github.com/motain/of-catalog/internal/services/prometheusservice"
@@ -33,6 +37,10 @@ var ProviderSet = wire.NewSet(
 	compassservice.NewCompassService,
 	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
 
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
 	// Githubservice
 	githubservice.NewGitHubClient,
 	githubservice.NewGitHubService,
@@ -43,17 +51,28 @@ var ProviderSet = wire.NewSet(
 	prometheusservice.NewPrometheusClient,
 	wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)),
 
+	// HistoryService
+	historyservice.NewHistoryService,
+	wire.Bind(new(historyservice.HistoryServiceInterface), new(*historyservice.HistoryService)),
+
 	// JSONService
 	jsonservice.NewJSONService,
 
 	// --- metric module ---
 	// Repository
-	repository.NewRepository,
-	wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)),
+	repository.NewCatalogRepository,
+	repository.NewPushQueue,
+	wire.Bind(new(repository.PushQueueInterface), new(*repository.PushQueue)),
+	repository.NewPushedValues,
+	wire.Bind(new(repository.PushedValuesInterface), new(*repository.PushedValues)),
 	// Fact System
 	aggregators.NewAggregator,
 	wire.Bind(new(aggregators.AggregatorInterface), new(*aggregators.Aggregator)),
 
+	builtin.NewBuiltinSources,
+	sources.NewRegistry,
+	wire.Bind(new(sources.RegistryInterface), new(*sources.Registry)),
+
 	extractors.NewExtractor,
 	wire.Bind(new(extractors.ExtractorInterface), new(*extractors.Extractor)),
 
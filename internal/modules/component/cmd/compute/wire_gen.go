@@ -10,13 +10,17 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/component/handler"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/factsystem/aggregators"
 	"github.com/motain/of-catalog/internal/services/factsystem/extractors"
 	"github.com/motain/of-catalog/internal/services/factsystem/processor"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
 	"github.com/motain/of-catalog/internal/services/factsystem/validators"
 	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
 	"github.com/motain/of-catalog/internal/services/jsonservice"
 	"github.com/motain/of-catalog/internal/services/keyringservice"
 	"github.com/motain/of-catalog/internal/services/prometheusservice"
@@ -29,21 +33,27 @@ func initializeHandler() *handler.ComputeHandler {
 	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
 	httpClientInterface := compassservice.NewHTTPClient(configService)
 	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
-	repositoryRepository := repository.NewRepository(compassService)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
 	aggregator := aggregators.NewAggregator()
 	validator := validators.NewValidator()
-	jsonServiceInterface := jsonservice.NewJSONService(configService)
 	keyringService := keyringservice.NewKeyringService()
 	gitHubClientInterface := githubservice.NewGitHubClient(configService, keyringService)
 	gitHubService := githubservice.NewGitHubService(gitHubClientInterface)
+	jsonServiceInterface := jsonservice.NewJSONService(configService)
 	prometheusClientInterface := prometheusservice.NewPrometheusClient(configService)
 	prometheusService := prometheusservice.NewPrometheusService(prometheusClientInterface)
-	extractor := extractors.NewExtractor(configService, jsonServiceInterface, gitHubService, prometheusService)
+	v := builtin.NewBuiltinSources(configService, gitHubService, jsonServiceInterface, prometheusService, compassService)
+	registry := sources.NewRegistry(v)
+	extractor := extractors.NewExtractor(registry)
 	processorProcessor := processor.NewProcessor(aggregator, validator, extractor)
-	computeHandler := handler.NewComputeHandler(repositoryRepository, processorProcessor, gitHubService)
+	pushQueue := repository.NewPushQueue(configService)
+	pushedValues := repository.NewPushedValues(configService)
+	historyService := historyservice.NewHistoryService(configService)
+	computeHandler := handler.NewComputeHandler(repositoryInterface, processorProcessor, gitHubService, pushQueue, pushedValues, historyService)
 	return computeHandler
 }
 
 // wire.go:
 
-var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), prometheusservice.NewPrometheusService, prometheusservice.NewPrometheusClient, wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)), jsonservice.NewJSONService, repository.NewRepository, wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)), aggregators.NewAggregator, wire.Bind(new(aggregators.AggregatorInterface), new(*aggregators.Aggregator)), extractors.NewExtractor, wire.Bind(new(extractors.ExtractorInterface), new(*extractors.Extractor)), validators.NewValidator, wire.Bind(new(validators.ValidatorInterface), new(*validators.Validator)), processor.NewProcessor, wire.Bind(new(processor.ProcessorInterface), new(*processor.Processor)), handler.NewComputeHandler)
+var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), prometheusservice.NewPrometheusService, prometheusservice.NewPrometheusClient, wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)), historyservice.NewHistoryService, wire.Bind(new(historyservice.HistoryServiceInterface), new(*historyservice.HistoryService)), jsonservice.NewJSONService, repository.NewCatalogRepository, repository.NewPushQueue, wire.Bind(new(repository.PushQueueInterface), new(*repository.PushQueue)), repository.NewPushedValues, wire.Bind(new(repository.PushedValuesInterface), new(*repository.PushedValues)), aggregators.NewAggregator, wire.Bind(new(aggregators.AggregatorInterface), new(*aggregators.Aggregator)), builtin.NewBuiltinSources, sources.NewRegistry, wire.Bind(new(sources.RegistryInterface), new(*sources.Registry)), extractors.NewExtractor, wire.Bind(new(extractors.ExtractorInterface), new(*extractors.Extractor)), validators.NewValidator, wire.Bind(new(validators.ValidatorInterface), new(*validators.Validator)), processor.NewProcessor, wire.Bind(new(processor.ProcessorInterface), new(*processor.Processor)), handler.NewComputeHandler)
@@ -0,0 +1,24 @@
+package pushpending
+
+import (
+	"os"
+
+	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "push-pending",
+		Short: "Push the metric values spooled by compute while Compass was unavailable",
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			ctx := commandcontext.Init()
+			if left, rejected := handler.PushPending(ctx); left > 0 || rejected > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+//go:build wireinject
+
+package pushpending
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/component/handler"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+var ProviderSet = wire.NewSet(
+	// Configservice
+	configservice.NewConfigService,
+	wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)),
+
+	// Compassservice
+	compassservice.NewGraphQLClient,
+	compassservice.NewHTTPClient,
+	compassservice.NewCompassService,
+	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
+
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
+	// Repository
+	repository.NewCatalogRepository,
+	repository.NewPushQueue,
+	wire.Bind(new(repository.PushQueueInterface), new(*repository.PushQueue)),
+
+	// PushPendingHandler
+	handler.NewPushPendingHandler,
+)
+
+func initializeHandler() *handler.PushPendingHandler {
+	panic(wire.Build(ProviderSet))
+}
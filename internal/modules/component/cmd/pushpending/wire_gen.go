@@ -0,0 +1,34 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package pushpending
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/component/handler"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.PushPendingHandler {
+	configService := configservice.NewConfigService()
+	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
+	httpClientInterface := compassservice.NewHTTPClient(configService)
+	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
+	pushQueue := repository.NewPushQueue(configService)
+	pushPendingHandler := handler.NewPushPendingHandler(repositoryInterface, pushQueue)
+	return pushPendingHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), repository.NewCatalogRepository, repository.NewPushQueue, wire.Bind(new(repository.PushQueueInterface), new(*repository.PushQueue)), handler.NewPushPendingHandler)
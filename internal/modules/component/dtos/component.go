@@ -1,6 +1,10 @@
 package dtos
 
-import "sort"
+import (
+	"sort"
+
+	"github.com/motain/of-catalog/internal/utils/drift"
+)
 
 type ComponentDTO struct {
 	APIVersion string   `yaml:"apiVersion" json:"apiVersion"`
@@ -13,6 +17,29 @@ func GetComponentUniqueKey(c *ComponentDTO) string {
 	return c.Spec.Name
 }
 
+// GetComponentPreviousNames returns the names the component was known by before being renamed.
+func GetComponentPreviousNames(c *ComponentDTO) []string {
+	return c.Metadata.PreviousNames
+}
+
+// GetComponentCompassName returns the name of the component in Compass: the name it was moved from by state mv,
+// until the next apply renames it.
+func GetComponentCompassName(c *ComponentDTO) string {
+	if c.Metadata.MovedFrom != "" {
+		return c.Metadata.MovedFrom
+	}
+
+	return c.Spec.Name
+}
+
+func GetComponentID(c *ComponentDTO) string {
+	return c.Spec.ID
+}
+
+func GetComponentLifecycle(c *ComponentDTO) drift.Lifecycle {
+	return c.Metadata.Lifecycle
+}
+
 func FromStateToConfig(state *ComponentDTO, conf *ComponentDTO) {
 	conf.Spec.ID = state.Spec.ID
 	conf.Spec.MetricSources = state.Spec.MetricSources
@@ -79,15 +106,23 @@ func IsEqualFields(f1, f2 map[string]interface{}) bool {
 }
 
 func IsEqualComponent(c1, c2 *ComponentDTO) bool {
-	return c1.Spec.Name == c2.Spec.Name &&
-		c1.Spec.Description == c2.Spec.Description &&
-		c1.Spec.ConfigVersion == c2.Spec.ConfigVersion &&
-		c1.Spec.TypeID == c2.Spec.TypeID &&
-		c1.Spec.OwnerID == c2.Spec.OwnerID &&
-		IsEqualLinks(c1.Spec.Links, c2.Spec.Links) &&
-		IsEqualLabels(c1.Spec.Labels, c2.Spec.Labels) &&
-		IsEqualDependsOn(c1.Spec.DependsOn, c2.Spec.DependsOn) &&
-		IsEqualFields(c1.Spec.Fields, c2.Spec.Fields)
+	return len(DiffComponent(c1, c2)) == 0
+}
+
+// DiffComponent returns the changes between the state c1 and the configuration c2 of a component.
+func DiffComponent(c1, c2 *ComponentDTO) []drift.Change {
+	var changes []drift.Change
+	changes = append(changes, drift.Diff("spec.name", GetComponentCompassName(c1), c2.Spec.Name)...)
+	changes = append(changes, drift.Diff("spec.description", c1.Spec.Description, c2.Spec.Description)...)
+	changes = append(changes, drift.Diff("spec.configVersion", c1.Spec.ConfigVersion, c2.Spec.ConfigVersion)...)
+	changes = append(changes, drift.Diff("spec.typeId", c1.Spec.TypeID, c2.Spec.TypeID)...)
+	changes = append(changes, drift.Diff("spec.ownerId", c1.Spec.OwnerID, c2.Spec.OwnerID)...)
+	changes = append(changes, drift.DiffUnlessEqual("spec.links", c1.Spec.Links, c2.Spec.Links, IsEqualLinks(c1.Spec.Links, c2.Spec.Links))...)
+	changes = append(changes, drift.DiffUnlessEqual("spec.labels", c1.Spec.Labels, c2.Spec.Labels, IsEqualLabels(c1.Spec.Labels, c2.Spec.Labels))...)
+	changes = append(changes, drift.DiffUnlessEqual("spec.dependsOn", c1.Spec.DependsOn, c2.Spec.DependsOn, IsEqualDependsOn(c1.Spec.DependsOn, c2.Spec.DependsOn))...)
+	changes = append(changes, drift.DiffUnlessEqual("spec.fields", c1.Spec.Fields, c2.Spec.Fields, IsEqualFields(c1.Spec.Fields, c2.Spec.Fields))...)
+
+	return changes
 }
 
 func SortAndRemoveDuplicateDocuments(documents []*Document) []*Document {
@@ -188,8 +223,11 @@ func UniqueAndSortLinks(links []Link) []Link {
 }
 
 type Metadata struct {
-	Name          string `yaml:"name" jsonyaml:"name"`
-	ComponentType string `yaml:"componentType" jsonyaml:"componentType"`
+	Name          string          `yaml:"name" jsonyaml:"name"`
+	ComponentType string          `yaml:"componentType" jsonyaml:"componentType"`
+	PreviousNames []string        `yaml:"previousNames,omitempty" jsonyaml:"previousNames"`
+	MovedFrom     string          `yaml:"movedFrom,omitempty" jsonyaml:"movedFrom"`
+	Lifecycle     drift.Lifecycle `yaml:"lifecycle,omitempty" jsonyaml:"lifecycle"`
 }
 
 type Spec struct {
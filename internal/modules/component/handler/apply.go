@@ -42,36 +42,57 @@ func NewApplyHandler(
 	}
 }
 
-func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, stateRootLocation string, recursive bool, componentName string) {
+// Apply brings Compass in line with the component definitions found under configRootLocation, or with
+// the one named componentName when set. It returns an error, before making any change, when the
+// deletions are not allowed by deletePolicy.
+func (h *ApplyHandler) Apply(
+	ctx context.Context,
+	configRootLocation string,
+	stateRootLocation string,
+	recursive bool,
+	componentName string,
+	deletePolicy drift.DeletePolicy,
+) error {
 	parseInput := yaml.ParseInput{
 		RootLocation: configRootLocation,
 		Recursive:    recursive,
 	}
 	configComponents, errConfig := yaml.Parse(parseInput, dtos.GetComponentUniqueKey)
 	if errConfig != nil {
-		log.Fatalf("error: %v", errConfig)
+		return errConfig
 	}
 
 	stateComponents, errState := yaml.Parse(yaml.GetComponentStateInput(), dtos.GetComponentUniqueKey)
 	if errState != nil {
-		log.Fatalf("error: %v", errState)
+		return errState
+	}
+
+	renamed, errRename := drift.Rename(stateComponents, configComponents, dtos.GetComponentPreviousNames, dtos.GetComponentID)
+	if errRename != nil {
+		return errRename
+	}
+	for previousName, name := range renamed {
+		fmt.Printf("Renaming component %s to %s\n", previousName, name)
 	}
 
 	if componentName == "" {
-		h.handleAll(ctx, stateComponents, configComponents)
-		return
+		return h.handleAll(ctx, stateComponents, configComponents, deletePolicy)
 	}
 
 	_, existsInState := stateComponents[componentName]
 	_, existsInConfig := configComponents[componentName]
 	if !existsInConfig && !existsInState {
-		log.Fatalf("component %s not found", componentName)
+		return fmt.Errorf("component %s not found", componentName)
 	}
 
-	h.handleOne(ctx, stateComponents, configComponents, componentName)
+	return h.handleOne(ctx, stateComponents, configComponents, componentName, deletePolicy)
 }
 
-func (h *ApplyHandler) handleAll(ctx context.Context, stateComponents, configComponents map[string]*dtos.ComponentDTO) {
+func (h *ApplyHandler) handleAll(
+	ctx context.Context,
+	stateComponents, configComponents map[string]*dtos.ComponentDTO,
+	deletePolicy drift.DeletePolicy,
+) error {
 	correctedConfigComponents := make(map[string]*dtos.ComponentDTO)
 	for name, component := range configComponents {
 		correctedConfigComponents[name] = h.handleOwner(component)
@@ -82,25 +103,36 @@ func (h *ApplyHandler) handleAll(ctx context.Context, stateComponents, configCom
 		correctedStateComponents[name] = h.handleOwner(component)
 	}
 
-	created, updated, deleted, unchanged := drift.Detect(
+	created, updated, deleted, unchanged, changes := drift.DetectChanges(
 		correctedStateComponents,
 		correctedConfigComponents,
 		dtos.FromStateToConfig,
-		dtos.IsEqualComponent,
+		dtos.DiffComponent,
 	)
 
+	if errDelete := drift.CheckDeletions("component", deleted, deletePolicy, dtos.GetComponentLifecycle); errDelete != nil {
+		return errDelete
+	}
+
 	var result []*dtos.ComponentDTO
 	h.handleDeleted(ctx, deleted)
 	result = h.handleUnchanged(ctx, result, unchanged, stateComponents)
 	result = h.handleCreated(ctx, result, created, stateComponents)
-	result = h.handleUpdated(ctx, result, updated, stateComponents)
+	result = h.handleUpdated(ctx, result, updated, stateComponents, changes)
 	err := yaml.WriteComponentStates(yaml.SortResults(result, dtos.GetComponentUniqueKey), dtos.GetComponentUniqueKey)
 	if err != nil {
-		log.Fatalf("error writing components to file: %v", err)
+		return fmt.Errorf("error writing components to file: %w", err)
 	}
+
+	return nil
 }
 
-func (h *ApplyHandler) handleOne(ctx context.Context, stateComponents, configComponents map[string]*dtos.ComponentDTO, componentName string) {
+func (h *ApplyHandler) handleOne(
+	ctx context.Context,
+	stateComponents, configComponents map[string]*dtos.ComponentDTO,
+	componentName string,
+	deletePolicy drift.DeletePolicy,
+) error {
 	configComponent := configComponents[componentName]
 
 	result := make([]*dtos.ComponentDTO, 0)
@@ -124,23 +156,29 @@ func (h *ApplyHandler) handleOne(ctx context.Context, stateComponents, configCom
 		stateMap[componentName] = stateComponentWithCorrectOwner
 	}
 
-	created, updated, deleted, unchanged := drift.Detect(
+	created, updated, deleted, unchanged, changes := drift.DetectChanges(
 		stateMap,
 		map[string]*dtos.ComponentDTO{componentName: configComponentWithCorrectOwner},
 		dtos.FromStateToConfig,
-		dtos.IsEqualComponent,
+		dtos.DiffComponent,
 	)
 	fmt.Printf("DEBUG: created: %d, updated: %d, deleted: %d, unchanged: %d\n", len(created), len(updated), len(deleted), len(unchanged))
 
+	if errDelete := drift.CheckDeletions("component", deleted, deletePolicy, dtos.GetComponentLifecycle); errDelete != nil {
+		return errDelete
+	}
+
 	h.handleDeleted(ctx, deleted)
 	result = h.handleUnchanged(ctx, result, unchanged, stateComponents)
 	result = h.handleCreated(ctx, result, created, stateComponents)
-	result = h.handleUpdated(ctx, result, updated, stateComponents)
+	result = h.handleUpdated(ctx, result, updated, stateComponents, changes)
 
 	err := yaml.WriteComponentStates(yaml.SortResults(result, dtos.GetComponentUniqueKey), dtos.GetComponentUniqueKey)
 	if err != nil {
-		log.Fatalf("error writing components to file: %v", err)
+		return fmt.Errorf("error writing components to file: %w", err)
 	}
+
+	return nil
 }
 
 func (h *ApplyHandler) handleDeleted(ctx context.Context, components map[string]*dtos.ComponentDTO) {
@@ -287,17 +325,27 @@ func (h *ApplyHandler) handleUpdated(
 	result []*dtos.ComponentDTO,
 	components map[string]*dtos.ComponentDTO,
 	stateComponents map[string]*dtos.ComponentDTO,
+	changes map[string][]drift.Change,
 ) []*dtos.ComponentDTO {
-	for _, componentDTO := range components {
+	for name, componentDTO := range components {
+		fmt.Printf("Updating component %s:\n", name)
+		for _, change := range changes[name] {
+			fmt.Printf("    %s\n", change)
+		}
+
 		componentDTO = h.handleOwner(componentDTO)
 		componentDTO = h.handleDescription(componentDTO)
 		componentDTO = h.handleLinks(ctx, componentDTO, stateComponents)
 		componentDTO = h.handleDocumenation(ctx, componentDTO, stateComponents)
 
+		// Links and dependencies have their own mutations, the component itself is only updated when other fields changed
 		component := h.converter.ToResource(componentDTO)
-		component, errComponent := h.repository.Update(ctx, component)
-		if errComponent != nil {
-			panic(errComponent)
+		if len(drift.Without(changes[name], "spec.links", "spec.dependsOn")) > 0 {
+			var errComponent error
+			component, errComponent = h.repository.Update(ctx, component)
+			if errComponent != nil {
+				panic(errComponent)
+			}
 		}
 
 		componentDTO.Spec.ID = component.ID
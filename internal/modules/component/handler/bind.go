@@ -3,14 +3,12 @@ package handler
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"github.com/motain/of-catalog/internal/modules/component/dtos"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
 	_ "github.com/motain/of-catalog/internal/modules/component/resources"
 	"github.com/motain/of-catalog/internal/modules/component/utils"
 	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
-	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
 	"github.com/motain/of-catalog/internal/services/githubservice"
 	"github.com/motain/of-catalog/internal/utils/yaml"
 )
@@ -32,17 +30,27 @@ func NewBindHandler(
 	}
 }
 
-func (h *BindHandler) Bind(ctx context.Context, stateRootLocation string) {
+func (h *BindHandler) Bind(ctx context.Context, stateRootLocation string) error {
 	components, errCState := yaml.Parse(yaml.GetComponentStateInput(), dtos.GetComponentUniqueKey)
 	if errCState != nil {
-		log.Fatalf("error: %v", errCState)
+		return errCState
 	}
 
-	metricsMap := h.getMetricsGroupedByCompoentType()
+	metricsMap, errMState := h.getMetricsGroupedByCompoentType()
+	if errMState != nil {
+		return errMState
+	}
 
 	for _, component := range components {
 		for metricName, metricSource := range component.Spec.MetricSources {
 			if _, exists := metricsMap[component.Metadata.ComponentType][metricName]; !exists {
+				if renamedMetric := findMetricByID(metricsMap[component.Metadata.ComponentType], metricSource.Metric); renamedMetric != nil {
+					// The metric was renamed: keep its metric source and the values recorded for it
+					delete(component.Spec.MetricSources, metricName)
+					component.Spec.MetricSources[renamedMetric.Metadata.Name] = metricSource
+					continue
+				}
+
 				errDelete := h.repository.UnbindMetric(ctx, MetricSourceDTOToResource(metricSource))
 				if errDelete != nil {
 					fmt.Printf("Failed to delete metric source %s: %v\n", metricSource.Name, errDelete)
@@ -67,14 +75,16 @@ func (h *BindHandler) Bind(ctx context.Context, stateRootLocation string) {
 
 	err := yaml.WriteComponentStates(state, dtos.GetComponentUniqueKey)
 	if err != nil {
-		log.Fatalf("error writing metrics to file: %v", err)
+		return fmt.Errorf("error writing metrics to file: %w", err)
 	}
+
+	return nil
 }
 
-func (*BindHandler) getMetricsGroupedByCompoentType() map[string]map[string]*metricdtos.MetricDTO {
+func (*BindHandler) getMetricsGroupedByCompoentType() (map[string]map[string]*metricdtos.MetricDTO, error) {
 	metrics, errMState := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
 	if errMState != nil {
-		log.Fatalf("error: %v", errMState)
+		return nil, errMState
 	}
 
 	metricsMap := make(map[string]map[string]*metricdtos.MetricDTO)
@@ -87,7 +97,21 @@ func (*BindHandler) getMetricsGroupedByCompoentType() map[string]map[string]*met
 		}
 	}
 
-	return metricsMap
+	return metricsMap, nil
+}
+
+func findMetricByID(metrics map[string]*metricdtos.MetricDTO, id string) *metricdtos.MetricDTO {
+	if id == "" {
+		return nil
+	}
+
+	for _, metric := range metrics {
+		if metric.Spec.ID == id {
+			return metric
+		}
+	}
+
+	return nil
 }
 
 func (h *BindHandler) handleBind(ctx context.Context, component *dtos.ComponentDTO, metric *metricdtos.MetricDTO) error {
@@ -96,7 +120,7 @@ func (h *BindHandler) handleBind(ctx context.Context, component *dtos.ComponentD
 	metricName := metric.Metadata.Name
 	componentName := component.Metadata.Name
 	identifier := utils.GetMetricSourceIdentifier(metricName, componentName, component.Metadata.ComponentType)
-	tasks := h.prepareSourceMetricFacts(metric.Metadata.Facts, *component)
+	tasks := utils.PrepareMetricFacts(metric.Metadata.Facts, *component)
 
 	if _, exists := component.Spec.MetricSources[metricName]; exists {
 		component.Spec.MetricSources[metricName].Facts = tasks
@@ -121,53 +145,3 @@ func (h *BindHandler) handleBind(ctx context.Context, component *dtos.ComponentD
 
 	return nil
 }
-
-func (h *BindHandler) prepareSourceMetricFacts(tasks []*fsdtos.Task, component dtos.ComponentDTO) []*fsdtos.Task {
-	processedFacts := make([]*fsdtos.Task, len(tasks))
-	for i, task := range tasks {
-		processedFacts[i] = h.prepareSourceMetricFact(task, component)
-	}
-	return processedFacts
-}
-
-func (h *BindHandler) prepareSourceMetricFact(task *fsdtos.Task, component dtos.ComponentDTO) *fsdtos.Task {
-	if task == nil {
-		return nil
-	}
-
-	// if fact.URI != "" {
-	// 	fmt.Printf("Processing fact %s for component %s\n", fact.Name, component.Metadata.Name)
-	// 	fmt.Printf("Fact URI: %s\n", fact.URI)
-	// 	parsed := utils.ReplaceMetricFactPlaceholders(fact.URI, component)
-	// 	fmt.Printf("Fact URI: %s\n", parsed)
-	// }
-
-	processedFact := fsdtos.Task{
-		ID:     task.ID,
-		Name:   task.Name,
-		Source: task.Source,
-		URI:    utils.ReplaceMetricFactPlaceholders(task.URI, component),
-		Auth:   task.Auth,
-		// ComponentName: utils.ReplaceMetricFactPlaceholders(task.ComponentName, component),
-		Repo:            utils.ReplaceMetricFactPlaceholders(task.Repo, component),
-		Type:            task.Type,
-		FilePath:        task.FilePath,
-		JSONPath:        task.JSONPath,
-		Rule:            task.Rule,
-		Pattern:         utils.ReplaceMetricFactPlaceholders(task.Pattern, component),
-		DependsOn:       task.DependsOn,
-		Method:          task.Method,
-		SearchString:    task.SearchString,
-		PrometheusQuery: utils.ReplaceMetricFactPlaceholders(task.PrometheusQuery, component),
-
-		// Are these still worth it?
-		// RegexPattern:     task.RegexPattern,
-		// RepoProperty:     task.RepoProperty,
-		// ReposSearchQuery: task.ReposSearchQuery,
-
-		// I need to reintegrate this !!
-		// ExpectedFormula:  task.ExpectedFormula,
-	}
-
-	return &processedFact
-}
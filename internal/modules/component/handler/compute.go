@@ -8,8 +8,12 @@ import (
 
 	"github.com/motain/of-catalog/internal/modules/component/dtos"
 	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/modules/component/utils"
 	"github.com/motain/of-catalog/internal/services/factsystem/processor"
+	fsutils "github.com/motain/of-catalog/internal/services/factsystem/utils"
 	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 	"github.com/motain/of-catalog/internal/utils/yaml"
 )
 
@@ -17,21 +21,39 @@ type ComputeHandler struct {
 	repository    repository.RepositoryInterface
 	factProcessor processor.ProcessorInterface
 	converter     *ComponentConverter
+	pushQueue     repository.PushQueueInterface
+	pushedValues  repository.PushedValuesInterface
+	history       historyservice.HistoryServiceInterface
 }
 
 func NewComputeHandler(
 	repository repository.RepositoryInterface,
 	factProcessor processor.ProcessorInterface,
 	github githubservice.GitHubServiceInterface, // Add GitHub service for consistency
+	pushQueue repository.PushQueueInterface,
+	pushedValues repository.PushedValuesInterface,
+	history historyservice.HistoryServiceInterface,
 ) *ComputeHandler {
 	return &ComputeHandler{
 		repository:    repository,
 		factProcessor: factProcessor,
 		converter:     NewComponentConverter(github), // Initialize converter
+		pushQueue:     pushQueue,
+		pushedValues:  pushedValues,
+		history:       history,
 	}
 }
 
-func (h *ComputeHandler) Compute(ctx context.Context, componentName string, all bool, metricName string, stateRootLocation string) {
+func (h *ComputeHandler) Compute(
+	ctx context.Context,
+	componentName string,
+	all bool,
+	metricName string,
+	stateRootLocation string,
+	noPush bool,
+	pushOptions repository.PushOptions,
+	backfillTimes []time.Time,
+) {
 	components, errCState := yaml.Parse(yaml.GetComponentStateInput(), dtos.GetComponentUniqueKey)
 	if errCState != nil {
 		log.Fatalf("error: %v", errCState)
@@ -42,39 +64,120 @@ func (h *ComputeHandler) Compute(ctx context.Context, componentName string, all
 		log.Fatalf("compute: error: component not found for name %s", componentName)
 	}
 
+	var pipeline *repository.PushPipeline
+	if !noPush {
+		// Values which cannot be pushed now are pushed later with push-pending
+		pushOptions.Queue = h.pushQueue
+		pushOptions.Pushed = h.pushedValues
+		pipeline = repository.NewPushPipeline(ctx, h.repository, pushOptions)
+	}
+
 	if !all {
 		fmt.Printf("Tracking metric '%s' component '%s'\n", metricName, componentName)
-		computeErr := h.computeMetric(ctx, component, metricName)
+		computeErr := h.computeMetric(ctx, component, metricName, pipeline, backfillTimes)
 		if computeErr != nil {
 			log.Fatalf("compute: %v", computeErr)
 		}
+		h.closePipeline(pipeline)
 		return
 	}
 
 	for metricName := range component.Spec.MetricSources {
 		fmt.Printf("Tracking metric '%s' for component '%s'\n", metricName, componentName)
-		computeErr := h.computeMetric(ctx, component, metricName)
+		computeErr := h.computeMetric(ctx, component, metricName, pipeline, backfillTimes)
 		if computeErr != nil {
 			log.Printf("compute metric %s: %v", metricName, computeErr)
 		}
 	}
+	h.closePipeline(pipeline)
 }
 
-func (h *ComputeHandler) computeMetric(ctx context.Context, component *dtos.ComponentDTO, metricName string) error {
+// closePipeline waits for the computed values to be pushed and prints the push summary. It exits with an
+// error when values could neither be pushed nor spooled for push-pending, values spooled being pushed later.
+func (h *ComputeHandler) closePipeline(pipeline *repository.PushPipeline) {
+	if pipeline == nil {
+		return
+	}
+
+	summary := pipeline.Close()
+	for _, pushErr := range summary.Errors {
+		log.Printf("compute: error: %v", pushErr)
+	}
+	fmt.Printf("Push summary: %s\n", summary)
+
+	if unspooled := summary.Unspooled(); unspooled > 0 {
+		log.Fatalf("compute: error: %d value(s) could not be pushed nor spooled for push-pending", unspooled)
+	}
+}
+
+// computeMetric computes the value of the metric for the component and queues it in the pipeline,
+// or prints it when there is no pipeline. With backfill times, the value is computed as of each of
+// them instead of now.
+func (h *ComputeHandler) computeMetric(
+	ctx context.Context,
+	component *dtos.ComponentDTO,
+	metricName string,
+	pipeline *repository.PushPipeline,
+	backfillTimes []time.Time,
+) error {
 	metricSource, msExists := component.Spec.MetricSources[metricName]
 	if !msExists {
 		return fmt.Errorf("error: metric source not found for metric %s", metricName)
 	}
 
-	metricValue, processErr := h.factProcessor.Process(ctx, metricSource.Facts)
-	if processErr != nil {
-		return fmt.Errorf("%v", processErr)
+	if backfillTimes == nil {
+		metricValue, processErr := h.factProcessor.Process(ctx, metricSource.Facts)
+		if processErr != nil {
+			return fmt.Errorf("%v", processErr)
+		}
+
+		if pipeline == nil {
+			fmt.Printf("Computed metric '%s' for component '%s': %v\n", metricName, component.Metadata.Name, metricValue)
+		}
+		h.track(pipeline, component, metricName, metricSource, metricValue, time.Now())
+
+		return nil
+	}
+
+	if !utils.IsBackfillable(metricSource.Facts) {
+		return fmt.Errorf("error: metric %s cannot be backfilled, only facts from the prometheus source can be evaluated in the past", metricName)
 	}
 
-	pushErr := h.repository.Push(ctx, MetricSourceDTOToResource(metricSource), metricValue, time.Now())
-	if pushErr != nil {
-		return fmt.Errorf("error: %v", pushErr)
+	for _, at := range backfillTimes {
+		// The facts are processed again at each time, from copies without the results of the previous run
+		metricValue, processErr := h.factProcessor.Process(fsutils.WithEvaluationTime(ctx, at), utils.CopyFacts(metricSource.Facts))
+		if processErr != nil {
+			return fmt.Errorf("at %s: %v", at.Format(time.RFC3339), processErr)
+		}
+
+		if pipeline == nil {
+			fmt.Printf("Computed metric '%s' for component '%s' at %s: %v\n", metricName, component.Metadata.Name, at.Format(time.RFC3339), metricValue)
+		}
+		h.track(pipeline, component, metricName, metricSource, metricValue, at)
 	}
 
 	return nil
 }
+
+// track queues the value of the metric in the pipeline, if any, and records it in the metric history,
+// whether or not it is pushed. Replayed values reproduce a past computation and are not recorded.
+func (h *ComputeHandler) track(
+	pipeline *repository.PushPipeline,
+	component *dtos.ComponentDTO,
+	metricName string,
+	metricSource *dtos.MetricSourceDTO,
+	value float64,
+	recordedAt time.Time,
+) {
+	if pipeline != nil {
+		pipeline.Enqueue(MetricSourceDTOToResource(metricSource), value, recordedAt)
+	}
+	if cassette.Replaying() {
+		return
+	}
+
+	record := historyservice.Record{Component: component.Metadata.Name, Metric: metricName, Value: value, RecordedAt: recordedAt}
+	if err := h.history.Append(record); err != nil {
+		log.Printf("compute: error: failed to record metric %s in the history: %v", metricName, err)
+	}
+}
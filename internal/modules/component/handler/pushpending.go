@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+)
+
+type PushPendingHandler struct {
+	repository repository.RepositoryInterface
+	pushQueue  repository.PushQueueInterface
+}
+
+func NewPushPendingHandler(
+	repository repository.RepositoryInterface,
+	pushQueue repository.PushQueueInterface,
+) *PushPendingHandler {
+	return &PushPendingHandler{repository: repository, pushQueue: pushQueue}
+}
+
+// PushPending pushes the values spooled by compute, oldest first, with the time they were recorded at,
+// and returns the number of values left in the queue and the number of values rejected by Compass.
+// It stops at the first transient error, Compass being still unavailable. Rejected values would be
+// rejected again, so they are moved out of the queue to its dead-letter directory.
+func (h *PushPendingHandler) PushPending(ctx context.Context) (int, int) {
+	pending, pendingErr := h.pushQueue.Pending()
+	if pendingErr != nil {
+		log.Fatalf("push-pending: error: %v", pendingErr)
+	}
+
+	pushed, rejected := 0, 0
+	for _, value := range pending {
+		pushErr := h.repository.Push(ctx, value.MetricSource(), value.Value, value.RecordedAt)
+		if pushErr != nil {
+			log.Printf("push-pending: metric source %s at %s: %v", value.MetricSourceID, value.RecordedAt, pushErr)
+			if repository.IsTransientPushError(pushErr) {
+				break
+			}
+
+			path, rejectErr := h.pushQueue.Reject(value, pushErr)
+			if rejectErr != nil {
+				log.Fatalf("push-pending: error: %v", rejectErr)
+			}
+			fmt.Printf("Value %v of metric source %s at %s rejected by Compass, moved to %s\n", value.Value, value.MetricSourceID, value.RecordedAt.Format(time.RFC3339), path)
+			rejected++
+			continue
+		}
+
+		if removeErr := h.pushQueue.Remove(value); removeErr != nil {
+			log.Fatalf("push-pending: error: %v", removeErr)
+		}
+		pushed++
+	}
+
+	left := len(pending) - pushed - rejected
+	fmt.Printf("%d pending value(s) pushed, %d rejected, %d left in the queue\n", pushed, rejected, left)
+
+	return left, rejected
+}
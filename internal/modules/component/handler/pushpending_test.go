@@ -0,0 +1,84 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/motain/of-catalog/internal/modules/component/handler"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushPendingHandler_PushPending(t *testing.T) {
+	tests := []struct {
+		name             string
+		compass          http.HandlerFunc
+		expectedLeft     int
+		expectedRejected int
+	}{
+		{
+			name:         "keeps the value on a server error",
+			compass:      func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) },
+			expectedLeft: 1,
+		},
+		{
+			name: "keeps the value when the connection drops after sending it",
+			compass: func(w http.ResponseWriter, r *http.Request) {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+			},
+			expectedLeft: 1,
+		},
+		{
+			name:             "rejects the value when Compass rejects it",
+			compass:          func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) },
+			expectedRejected: 1,
+		},
+		{
+			name:    "removes the pushed value",
+			compass: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := httptest.NewServer(tt.compass)
+			defer server.Close()
+
+			queuePath := t.TempDir()
+			mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().GetCompassHost().Return(server.URL).AnyTimes()
+			mockConfig.EXPECT().GetCompassToken().Return("mock-token").AnyTimes()
+			mockConfig.EXPECT().GetCompassCloudId().Return("mock-cloud-id").AnyTimes()
+			mockConfig.EXPECT().GetCompassMaxRetries().Return(0).AnyTimes()
+			mockConfig.EXPECT().GetCompassRetryBaseDelay().Return(time.Millisecond).AnyTimes()
+			mockConfig.EXPECT().GetCompassRateLimit().Return(0.0).AnyTimes()
+			mockConfig.EXPECT().GetPushQueuePath().Return(queuePath).AnyTimes()
+
+			compass := compassservice.NewCompassService(mockConfig, nil, compassservice.NewHTTPClient(mockConfig))
+			queue := repository.NewPushQueue(mockConfig)
+			require.NoError(t, queue.Spool(resources.MetricSource{ID: "metric-source"}, 1, time.Now(), errors.New("service unavailable")))
+
+			left, rejected := handler.NewPushPendingHandler(repository.NewRepository(compass), queue).PushPending(context.Background())
+
+			assert.Equal(t, tt.expectedLeft, left)
+			assert.Equal(t, tt.expectedRejected, rejected)
+			rejectedFiles, _ := os.ReadDir(filepath.Join(queuePath, "rejected-push"))
+			assert.Len(t, rejectedFiles, tt.expectedRejected)
+		})
+	}
+}
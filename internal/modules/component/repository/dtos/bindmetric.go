@@ -26,6 +26,10 @@ func (dto *BindMetricInput) GetQuery() string {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -67,3 +71,7 @@ func (dto *BindMetricOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *BindMetricOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateMetricSource.Errors
+}
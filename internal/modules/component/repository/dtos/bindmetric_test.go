@@ -27,6 +27,10 @@ func TestBindMetricInput_GetQuery(t *testing.T) {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
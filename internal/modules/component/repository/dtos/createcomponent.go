@@ -34,6 +34,10 @@ func (dto *CreateComponentInput) GetQuery() string {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -101,10 +105,19 @@ type MetricSources struct {
 
 type Component struct {
 	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	TypeID        string        `json:"typeId"`
+	OwnerID       string        `json:"ownerId"`
+	Labels        []Label       `json:"labels"`
 	Links         []Link        `json:"links"`
 	MetricSources MetricSources `json:"metricSources"`
 }
 
+type Label struct {
+	Name string `json:"name"`
+}
+
 type Link struct {
 	ID   string `json:"id"`
 	Type string `json:"type"`
@@ -146,3 +159,7 @@ func (dto *CreateComponentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateComponentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateComponent.Errors
+}
@@ -27,6 +27,10 @@ func TestCreateComponentInput_GetQuery(t *testing.T) {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -25,6 +25,10 @@ func (dto *CreateDependencyInput) GetQuery() string {
 				}) {
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -63,3 +67,7 @@ func (dto *CreateDependencyOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateDependencyOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateDependency.Errors
+}
@@ -27,6 +27,10 @@ func TestCreateDependencyInput_GetQuery(t *testing.T) {
 				}) {
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -24,6 +24,10 @@ func (dto *CreateDocumentInput) GetQuery() string {
    				success
  					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					documentDetails {
 						id
@@ -80,3 +84,7 @@ func (dto *CreateDocumentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateDocumentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.AddDocument.Errors
+}
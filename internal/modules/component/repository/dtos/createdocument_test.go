@@ -26,6 +26,10 @@ func TestCreateDocumentInput_GetQuery(t *testing.T) {
    				success
  					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					documentDetails {
 						id
@@ -23,6 +23,10 @@ func (dto *CreateLinkInput) GetQuery() string {
    				success
 				errors {
 					message
+					extensions {
+						statusCode
+						errorType
+					}
 				}
 				createdComponentLink {
 					id
@@ -73,3 +77,7 @@ func (dto *CreateLinkOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateLinkOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateComponentLink.Errors
+}
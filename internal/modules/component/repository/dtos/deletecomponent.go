@@ -21,6 +21,10 @@ func (dto *DeleteComponentInput) GetQuery() string {
 					deletedComponentId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -63,3 +67,7 @@ func (dto *DeleteComponentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *DeleteComponentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteComponent.Errors
+}
@@ -17,6 +17,10 @@ func TestDeleteComponentInput_GetQuery(t *testing.T) {
 					deletedComponentId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
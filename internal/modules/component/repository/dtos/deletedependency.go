@@ -25,6 +25,10 @@ func (dto *DeleteDependencyInput) GetQuery() string {
 				}) {
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -63,3 +67,7 @@ func (dto *DeleteDependencyOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *DeleteDependencyOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteDependency.Errors
+}
@@ -26,6 +26,10 @@ func TestDeleteDependencyInput_GetQuery(t *testing.T) {
 				}) {
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
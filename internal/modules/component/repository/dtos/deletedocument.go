@@ -21,6 +21,10 @@ func (dto *DeleteDocumentInput) GetQuery() string {
    				success
  					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -61,3 +65,7 @@ func (dto *DeleteDocumentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *DeleteDocumentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteDocument.Errors
+}
@@ -24,6 +24,10 @@ func TestDeleteDocumentInput_GetQuery(t *testing.T) {
    				success
  					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
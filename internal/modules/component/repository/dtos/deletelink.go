@@ -22,6 +22,10 @@ func (dto *RemoveLinkInput) GetQuery() string {
    				success
 				errors {
 					message
+					extensions {
+						statusCode
+						errorType
+					}
 				}
 			}
 		}
@@ -57,3 +61,7 @@ func (dto *RemoveLinkOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *RemoveLinkOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteComponentLink.Errors
+}
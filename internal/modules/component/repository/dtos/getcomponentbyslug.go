@@ -1,6 +1,9 @@
 package dtos
 
-import compassdtos "github.com/motain/of-catalog/internal/services/compassservice/dtos"
+import (
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	compassdtos "github.com/motain/of-catalog/internal/services/compassservice/dtos"
+)
 
 /*************
  * INPUT DTO *
@@ -18,11 +21,19 @@ func (dto *ComponentByReferenceInput) GetQuery() string {
 				componentByReference(reference: {slug: {slug: $slug, cloudId: $cloudId}}) {
 					... on CompassComponent {
 						id
+						name
+						description
+						typeId
+						ownerId
+						labels {
+							name
+						}
 						metricSources {
 							... on CompassComponentMetricSourcesConnection {
 								nodes {
 									id,
 									metricDefinition {
+										id
 										name
 									}
 								}
@@ -64,3 +75,13 @@ func (dto *ComponentByReferenceOutput) IsSuccessful() bool {
 func (dto *ComponentByReferenceOutput) GetErrors() []string {
 	return nil
 }
+
+// GetCompassErrors reports a missing component as a NOT_FOUND error, Compass answering the query
+// with a null component rather than with an error.
+func (dto *ComponentByReferenceOutput) GetCompassErrors() []compassservice.CompassError {
+	if dto.IsSuccessful() {
+		return nil
+	}
+
+	return []compassservice.CompassError{{Message: "component not found", Extensions: compassservice.CompassErrorExtensions{ErrorType: "NOT_FOUND"}}}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/motain/of-catalog/internal/modules/component/repository/dtos"
+	"github.com/motain/of-catalog/internal/services/compassservice"
 )
 
 func TestComponentByReferenceInput_GetQuery(t *testing.T) {
@@ -15,11 +16,19 @@ func TestComponentByReferenceInput_GetQuery(t *testing.T) {
 				componentByReference(reference: {slug: {slug: $slug, cloudId: $cloudId}}) {
 					... on CompassComponent {
 						id
+						name
+						description
+						typeId
+						ownerId
+						labels {
+							name
+						}
 						metricSources {
 							... on CompassComponentMetricSourcesConnection {
 								nodes {
 									id,
 									metricDefinition {
+										id
 										name
 									}
 								}
@@ -156,3 +165,17 @@ func TestComponentByReferenceOutput_GetErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestComponentByReferenceOutput_GetCompassErrors(t *testing.T) {
+	found := &dtos.ComponentByReferenceOutput{}
+	found.Compass.Component.ID = "component123"
+	if errs := found.GetCompassErrors(); errs != nil {
+		t.Errorf("GetCompassErrors() = %v, want nil", errs)
+	}
+
+	missing := &dtos.ComponentByReferenceOutput{}
+	errs := missing.GetCompassErrors()
+	if !compassservice.HasNotFoundError(errs) {
+		t.Errorf("GetCompassErrors() = %v, want a NOT_FOUND error", errs)
+	}
+}
@@ -21,6 +21,10 @@ func (dto *UnbindMetricInput) GetQuery() string {
 					deletedMetricSourceId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -58,3 +62,7 @@ func (dto *UnbindMetricOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *UnbindMetricOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteMetricSource.Errors
+}
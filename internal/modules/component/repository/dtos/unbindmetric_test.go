@@ -24,6 +24,10 @@ func TestUnbindMetricInput_GetQuery(t *testing.T) {
 					deletedMetricSourceId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
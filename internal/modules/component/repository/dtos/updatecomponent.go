@@ -22,6 +22,10 @@ func (dto *UpdateComponentInput) GetQuery() string {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -69,3 +73,7 @@ func (dto *UpdateComponentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *UpdateComponentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.UpdateComponent.Errors
+}
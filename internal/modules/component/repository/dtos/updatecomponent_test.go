@@ -76,6 +76,10 @@ func TestGetQuery(t *testing.T) {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -23,6 +23,10 @@ func (dto *UpdateDocumentInput) GetQuery() string {
 				success
 				errors {
 					message
+					extensions {
+						statusCode
+						errorType
+					}
 				}
 				documentDetails {
 					id
@@ -72,3 +76,7 @@ func (dto *UpdateDocumentOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *UpdateDocumentOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.UpdateDocument.Errors
+}
@@ -25,6 +25,10 @@ func TestUpdateDocumentInput_GetQuery(t *testing.T) {
 				success
 				errors {
 					message
+					extensions {
+						statusCode
+						errorType
+					}
 				}
 				documentDetails {
 					id
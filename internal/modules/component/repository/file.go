@@ -0,0 +1,432 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+)
+
+// fileComponent is a component as stored in the file catalog, with the data Compass keeps alongside it.
+// Documents and metric sources are stored as their own entities.
+type fileComponent struct {
+	Component             resources.Component
+	DependsOn             []string
+	APISpecifications     string
+	APISpecificationsFile string
+}
+
+type fileDocument struct {
+	ComponentID string
+	Document    resources.Document
+}
+
+type fileMetricSource struct {
+	ComponentID string
+	MetricID    string
+	Identifier  string
+}
+
+type fileMetricValue struct {
+	Value      float64
+	RecordedAt time.Time
+}
+
+// FileRepository keeps components in a local file catalog.
+type FileRepository struct {
+	catalog catalogservice.FileCatalogInterface
+	// pushMutex serializes the pushes, which rewrite the values of their metric source
+	pushMutex sync.Mutex
+}
+
+func NewFileRepository(catalog catalogservice.FileCatalogInterface) *FileRepository {
+	return &FileRepository{catalog: catalog}
+}
+
+func (r *FileRepository) Create(ctx context.Context, component resources.Component) (resources.Component, error) {
+	// As in Compass, creating an existing component updates it
+	if existing, getErr := r.GetBySlug(ctx, component); getErr == nil {
+		component.ID = existing.ID
+		component.MetricSources = existing.MetricSources
+		component.Links = nil
+		return r.Update(ctx, component)
+	}
+
+	id, idErr := r.catalog.NewID(catalogservice.ComponentKind)
+	if idErr != nil {
+		return resources.Component{}, fmt.Errorf("Create component error for %s: %w", component.Name, idErr)
+	}
+
+	component.ID = id
+	links := make([]resources.Link, len(component.Links))
+	for i, link := range component.Links {
+		linkID, linkIDErr := r.catalog.NewID(catalogservice.LinkKind)
+		if linkIDErr != nil {
+			return resources.Component{}, fmt.Errorf("Create component error for %s: %w", component.Name, linkIDErr)
+		}
+		link.ID = linkID
+		links[i] = link
+	}
+	component.Links = links
+	component.Documents = nil
+	component.MetricSources = make(map[string]*resources.MetricSource)
+
+	if putErr := r.putComponent(fileComponent{Component: component}); putErr != nil {
+		return resources.Component{}, fmt.Errorf("Create component error for %s: %w", component.Name, putErr)
+	}
+
+	return component, nil
+}
+
+func (r *FileRepository) Update(ctx context.Context, component resources.Component) (resources.Component, error) {
+	stored, getErr := r.getComponent(component.ID)
+	if errors.Is(getErr, catalogservice.ErrNotFound) {
+		// As in Compass, a component missing by ID is looked up by slug
+		stored, getErr = r.findComponent(component.Slug)
+		if getErr == nil {
+			component.ID = stored.Component.ID
+		}
+	}
+	if getErr != nil {
+		return resources.Component{}, fmt.Errorf("Update component error for %s: %w", component.Name, getErr)
+	}
+
+	// Only the details Compass updates are changed, links, documents and metric sources have their own operations
+	stored.Component.Name = component.Name
+	stored.Component.Slug = component.Slug
+	stored.Component.Description = component.Description
+	if component.OwnerID != "" {
+		stored.Component.OwnerID = component.OwnerID
+	}
+
+	if putErr := r.putComponent(stored); putErr != nil {
+		return resources.Component{}, fmt.Errorf("Update component error for %s: %w", component.Name, putErr)
+	}
+
+	return component, nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, component resources.Component) error {
+	metricSources, listErr := r.listMetricSources(component.ID)
+	if listErr != nil {
+		return fmt.Errorf("Delete component error for %s: %w", component.ID, listErr)
+	}
+	for id := range metricSources {
+		if deleteErr := r.deleteMetricSource(id); deleteErr != nil {
+			return fmt.Errorf("Delete component error for %s: %w", component.ID, deleteErr)
+		}
+	}
+
+	documents, documentsErr := r.listDocuments(component.ID)
+	if documentsErr != nil {
+		return fmt.Errorf("Delete component error for %s: %w", component.ID, documentsErr)
+	}
+	for _, document := range documents {
+		if deleteErr := r.catalog.Delete(catalogservice.DocumentKind, document.ID); deleteErr != nil {
+			return fmt.Errorf("Delete component error for %s: %w", component.ID, deleteErr)
+		}
+	}
+
+	if deleteErr := r.catalog.Delete(catalogservice.ComponentKind, component.ID); deleteErr != nil {
+		return fmt.Errorf("Delete component error for %s: %w", component.ID, deleteErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) GetBySlug(ctx context.Context, component resources.Component) (*resources.Component, error) {
+	stored, findErr := r.findComponent(component.Slug)
+	if findErr != nil {
+		return nil, fmt.Errorf("GetBySlug error for %s: %w", component.Slug, findErr)
+	}
+
+	metricSources, listErr := r.listMetricSources(stored.Component.ID)
+	if listErr != nil {
+		return nil, fmt.Errorf("GetBySlug error for %s: %w", component.Slug, listErr)
+	}
+
+	found := stored.Component
+	found.MetricSources = make(map[string]*resources.MetricSource, len(metricSources))
+	for id, metricSource := range metricSources {
+		found.MetricSources[r.getMetricName(metricSource.MetricID)] = &resources.MetricSource{
+			ID:     id,
+			Name:   metricSource.Identifier,
+			Metric: metricSource.MetricID,
+		}
+	}
+
+	return &found, nil
+}
+
+func (r *FileRepository) SetDependency(ctx context.Context, dependent, provider resources.Component) error {
+	stored, getErr := r.getComponent(dependent.ID)
+	if getErr != nil {
+		return fmt.Errorf("SetDependency error for %s: %w", dependent.ID, getErr)
+	}
+	if _, providerErr := r.getComponent(provider.ID); providerErr != nil {
+		return fmt.Errorf("SetDependency error for %s: %w", dependent.ID, providerErr)
+	}
+
+	if !slices.Contains(stored.DependsOn, provider.ID) {
+		stored.DependsOn = append(stored.DependsOn, provider.ID)
+	}
+
+	if putErr := r.putComponent(stored); putErr != nil {
+		return fmt.Errorf("SetDependency error for %s: %w", dependent.ID, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) UnsetDependency(ctx context.Context, dependent, provider resources.Component) error {
+	stored, getErr := r.getComponent(dependent.ID)
+	if getErr != nil {
+		return fmt.Errorf("UnsetDependency dependency error for %s: %w", dependent.ID, getErr)
+	}
+
+	stored.DependsOn = slices.DeleteFunc(stored.DependsOn, func(id string) bool { return id == provider.ID })
+
+	if putErr := r.putComponent(stored); putErr != nil {
+		return fmt.Errorf("UnsetDependency dependency error for %s: %w", dependent.ID, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) AddLink(ctx context.Context, component resources.Component, link resources.Link) (*resources.Link, error) {
+	stored, getErr := r.getComponent(component.ID)
+	if getErr != nil {
+		return nil, fmt.Errorf("AddLink error for %s/%s: %w", component.ID, link.Name, getErr)
+	}
+
+	id, idErr := r.catalog.NewID(catalogservice.LinkKind)
+	if idErr != nil {
+		return nil, fmt.Errorf("AddLink error for %s/%s: %w", component.ID, link.Name, idErr)
+	}
+
+	link.ID = id
+	stored.Component.Links = append(stored.Component.Links, link)
+
+	if putErr := r.putComponent(stored); putErr != nil {
+		return nil, fmt.Errorf("AddLink error for %s/%s: %w", component.ID, link.Name, putErr)
+	}
+
+	return &resources.Link{ID: link.ID}, nil
+}
+
+func (r *FileRepository) RemoveLink(ctx context.Context, component resources.Component, id string) error {
+	stored, getErr := r.getComponent(component.ID)
+	if getErr != nil {
+		return fmt.Errorf("RemoveLink error for %s/%s: %w", component.ID, id, getErr)
+	}
+
+	stored.Component.Links = slices.DeleteFunc(stored.Component.Links, func(link resources.Link) bool { return link.ID == id })
+
+	if putErr := r.putComponent(stored); putErr != nil {
+		return fmt.Errorf("RemoveLink error for %s/%s: %w", component.ID, id, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) GetDocuments(ctx context.Context, component resources.Component) ([]resources.Document, error) {
+	documents, listErr := r.listDocuments(component.ID)
+	if listErr != nil {
+		return nil, fmt.Errorf("GetDocuments error for %s: %w", component.ID, listErr)
+	}
+
+	return documents, nil
+}
+
+func (r *FileRepository) AddDocument(ctx context.Context, component resources.Component, document resources.Document) (resources.Document, error) {
+	if _, getErr := r.getComponent(component.ID); getErr != nil {
+		return resources.Document{}, fmt.Errorf("AddDocument error for %s/%s: %w", component.ID, document.Title, getErr)
+	}
+
+	id, idErr := r.catalog.NewID(catalogservice.DocumentKind)
+	if idErr != nil {
+		return resources.Document{}, fmt.Errorf("AddDocument error for %s/%s: %w", component.ID, document.Title, idErr)
+	}
+
+	document.ID = id
+	document.DocumentationCategoryId = document.Type
+
+	stored := fileDocument{ComponentID: component.ID, Document: document}
+	if putErr := r.catalog.Put(catalogservice.DocumentKind, document.ID, stored); putErr != nil {
+		return resources.Document{}, fmt.Errorf("AddDocument error for %s/%s: %w", component.ID, document.Title, putErr)
+	}
+
+	return document, nil
+}
+
+func (r *FileRepository) UpdateDocument(ctx context.Context, component resources.Component, document resources.Document) error {
+	var stored fileDocument
+	if getErr := r.catalog.Get(catalogservice.DocumentKind, document.ID, &stored); getErr != nil {
+		return fmt.Errorf("UpdateDocument error for %s/%s: %w", component.ID, document.Title, getErr)
+	}
+
+	document.DocumentationCategoryId = document.Type
+	stored.Document = document
+	if putErr := r.catalog.Put(catalogservice.DocumentKind, document.ID, stored); putErr != nil {
+		return fmt.Errorf("UpdateDocument error for %s/%s: %w", component.ID, document.Title, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) RemoveDocument(ctx context.Context, component resources.Component, document resources.Document) error {
+	if deleteErr := r.catalog.Delete(catalogservice.DocumentKind, document.ID); deleteErr != nil {
+		return fmt.Errorf("RemoveDocument error for %s/%s: %w", component.ID, document.Title, deleteErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) BindMetric(ctx context.Context, component resources.Component, metricID string, identifier string) (string, error) {
+	if _, getErr := r.getComponent(component.ID); getErr != nil {
+		return "", fmt.Errorf("BindMetric error for %s/%s: %w", component.ID, metricID, getErr)
+	}
+
+	id, idErr := r.catalog.NewID(catalogservice.MetricSourceKind)
+	if idErr != nil {
+		return "", fmt.Errorf("BindMetric error for %s/%s: %w", component.ID, metricID, idErr)
+	}
+
+	metricSource := fileMetricSource{ComponentID: component.ID, MetricID: metricID, Identifier: identifier}
+	if putErr := r.catalog.Put(catalogservice.MetricSourceKind, id, metricSource); putErr != nil {
+		return "", fmt.Errorf("BindMetric error for %s/%s: %w", component.ID, metricID, putErr)
+	}
+
+	return id, nil
+}
+
+func (r *FileRepository) UnbindMetric(ctx context.Context, metricSource resources.MetricSource) error {
+	if deleteErr := r.deleteMetricSource(metricSource.ID); deleteErr != nil {
+		return fmt.Errorf("UnbindMetric error for %s: %w", metricSource.ID, deleteErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) SetAPISpecifications(ctx context.Context, component resources.Component, apiSpecs, apiSpecsFile string) error {
+	stored, getErr := r.getComponent(component.ID)
+	if getErr != nil {
+		return fmt.Errorf("SetAPISpecifications error for %s: %w", component.ID, getErr)
+	}
+
+	stored.APISpecifications = apiSpecs
+	stored.APISpecificationsFile = apiSpecsFile
+	return r.putComponent(stored)
+}
+
+// Push appends the value to the values recorded for the metric source.
+func (r *FileRepository) Push(ctx context.Context, metricSource resources.MetricSource, value float64, recordedAt time.Time) error {
+	var source fileMetricSource
+	if getErr := r.catalog.Get(catalogservice.MetricSourceKind, metricSource.ID, &source); getErr != nil {
+		return getErr
+	}
+
+	r.pushMutex.Lock()
+	defer r.pushMutex.Unlock()
+
+	var values []fileMetricValue
+	if getErr := r.catalog.Get(catalogservice.MetricValueKind, metricSource.ID, &values); getErr != nil && !errors.Is(getErr, catalogservice.ErrNotFound) {
+		return getErr
+	}
+
+	values = append(values, fileMetricValue{Value: value, RecordedAt: recordedAt.UTC()})
+	return r.catalog.Put(catalogservice.MetricValueKind, metricSource.ID, values)
+}
+
+func (r *FileRepository) getComponent(id string) (fileComponent, error) {
+	var stored fileComponent
+	if id == "" {
+		return stored, fmt.Errorf("component without id %w", catalogservice.ErrNotFound)
+	}
+
+	getErr := r.catalog.Get(catalogservice.ComponentKind, id, &stored)
+	return stored, getErr
+}
+
+func (r *FileRepository) putComponent(stored fileComponent) error {
+	stored.Component.Documents = nil
+	stored.Component.MetricSources = nil
+
+	return r.catalog.Put(catalogservice.ComponentKind, stored.Component.ID, stored)
+}
+
+func (r *FileRepository) findComponent(slug string) (fileComponent, error) {
+	ids, listErr := r.catalog.List(catalogservice.ComponentKind)
+	if listErr != nil {
+		return fileComponent{}, listErr
+	}
+
+	for _, id := range ids {
+		stored, getErr := r.getComponent(id)
+		if getErr != nil {
+			return fileComponent{}, getErr
+		}
+		if stored.Component.Slug == slug {
+			return stored, nil
+		}
+	}
+
+	return fileComponent{}, fmt.Errorf("component %s %w", slug, catalogservice.ErrNotFound)
+}
+
+func (r *FileRepository) listDocuments(componentID string) ([]resources.Document, error) {
+	ids, listErr := r.catalog.List(catalogservice.DocumentKind)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	var documents []resources.Document
+	for _, id := range ids {
+		var stored fileDocument
+		if getErr := r.catalog.Get(catalogservice.DocumentKind, id, &stored); getErr != nil {
+			return nil, getErr
+		}
+		if stored.ComponentID == componentID {
+			documents = append(documents, stored.Document)
+		}
+	}
+
+	return documents, nil
+}
+
+func (r *FileRepository) listMetricSources(componentID string) (map[string]fileMetricSource, error) {
+	ids, listErr := r.catalog.List(catalogservice.MetricSourceKind)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	metricSources := make(map[string]fileMetricSource)
+	for _, id := range ids {
+		var stored fileMetricSource
+		if getErr := r.catalog.Get(catalogservice.MetricSourceKind, id, &stored); getErr != nil {
+			return nil, getErr
+		}
+		if stored.ComponentID == componentID {
+			metricSources[id] = stored
+		}
+	}
+
+	return metricSources, nil
+}
+
+func (r *FileRepository) deleteMetricSource(id string) error {
+	if deleteErr := r.catalog.Delete(catalogservice.MetricValueKind, id); deleteErr != nil {
+		return deleteErr
+	}
+
+	return r.catalog.Delete(catalogservice.MetricSourceKind, id)
+}
+
+// getMetricName returns the name of the metric definition stored in the catalog, metric sources being keyed
+// by metric name. It falls back to the ID for metrics missing from the catalog.
+func (r *FileRepository) getMetricName(metricID string) string {
+	var metric struct{ Name string }
+	if getErr := r.catalog.Get(catalogservice.MetricKind, metricID, &metric); getErr != nil || metric.Name == "" {
+		return metricID
+	}
+
+	return metric.Name
+}
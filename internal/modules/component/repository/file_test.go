@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRepository(t *testing.T) {
+	catalog := catalogservice.NewFileCatalogAt(t.TempDir())
+	repo := repository.NewFileRepository(catalog)
+	ctx := context.Background()
+
+	require.NoError(t, catalog.Put(catalogservice.MetricKind, "metric-1", map[string]string{"ID": "metric-1", "Name": "coverage"}))
+
+	created, createErr := repo.Create(ctx, resources.Component{
+		Name:  "api",
+		Slug:  "svc-api",
+		Links: []resources.Link{{Name: "Repository", Type: "REPOSITORY", URL: "https://github.com/motain/api"}},
+	})
+	require.NoError(t, createErr)
+	require.NotEmpty(t, created.ID)
+	require.Len(t, created.Links, 1)
+	assert.NotEmpty(t, created.Links[0].ID)
+
+	again, againErr := repo.Create(ctx, resources.Component{Name: "api", Slug: "svc-api", Description: "The API"})
+	require.NoError(t, againErr)
+	assert.Equal(t, created.ID, again.ID)
+
+	provider, providerErr := repo.Create(ctx, resources.Component{Name: "db", Slug: "svc-db"})
+	require.NoError(t, providerErr)
+	require.NoError(t, repo.SetDependency(ctx, created, provider))
+	require.NoError(t, repo.UnsetDependency(ctx, created, provider))
+
+	link, linkErr := repo.AddLink(ctx, created, resources.Link{Name: "Docs", Type: "DOCUMENT", URL: "https://docs"})
+	require.NoError(t, linkErr)
+	require.NoError(t, repo.RemoveLink(ctx, created, created.Links[0].ID))
+
+	document, documentErr := repo.AddDocument(ctx, created, resources.Document{Title: "Runbook", Type: "Runbooks", URL: "https://runbook"})
+	require.NoError(t, documentErr)
+	document.Title = "On-call runbook"
+	require.NoError(t, repo.UpdateDocument(ctx, created, document))
+	documents, documentsErr := repo.GetDocuments(ctx, created)
+	require.NoError(t, documentsErr)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "On-call runbook", documents[0].Title)
+
+	metricSourceID, bindErr := repo.BindMetric(ctx, created, "metric-1", "svc-api-coverage")
+	require.NoError(t, bindErr)
+	require.NoError(t, repo.Push(ctx, resources.MetricSource{ID: metricSourceID}, 0.8, time.Now()))
+	assert.Error(t, repo.Push(ctx, resources.MetricSource{ID: "metric-source-missing"}, 1, time.Now()))
+
+	found, getErr := repo.GetBySlug(ctx, resources.Component{Slug: "svc-api"})
+	require.NoError(t, getErr)
+	assert.Equal(t, "The API", found.Description)
+	assert.Equal(t, []resources.Link{{ID: link.ID, Name: "Docs", Type: "DOCUMENT", URL: "https://docs"}}, found.Links)
+	require.Contains(t, found.MetricSources, "coverage")
+	assert.Equal(t, metricSourceID, found.MetricSources["coverage"].ID)
+
+	require.NoError(t, repo.UnbindMetric(ctx, *found.MetricSources["coverage"]))
+	require.NoError(t, repo.Delete(ctx, created))
+	_, getErr = repo.GetBySlug(ctx, resources.Component{Slug: "svc-api"})
+	assert.ErrorIs(t, getErr, catalogservice.ErrNotFound)
+	_, getErr = repo.AddLink(ctx, created, resources.Link{Name: "Docs"})
+	assert.ErrorIs(t, getErr, catalogservice.ErrNotFound)
+}
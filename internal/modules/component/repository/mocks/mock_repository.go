@@ -51,6 +51,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) AddDocument(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDocument", reflect.TypeOf((*MockRepositoryInterface)(nil).AddDocument), arg0, arg1, arg2)
 }
 
+// AddLink mocks base method.
+func (m *MockRepositoryInterface) AddLink(arg0 context.Context, arg1 resources.Component, arg2 resources.Link) (*resources.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*resources.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLink indicates an expected call of AddLink.
+func (mr *MockRepositoryInterfaceMockRecorder) AddLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLink", reflect.TypeOf((*MockRepositoryInterface)(nil).AddLink), arg0, arg1, arg2)
+}
+
 // BindMetric mocks base method.
 func (m *MockRepositoryInterface) BindMetric(arg0 context.Context, arg1 resources.Component, arg2, arg3 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +125,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetBySlug(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySlug", reflect.TypeOf((*MockRepositoryInterface)(nil).GetBySlug), arg0, arg1)
 }
 
+// GetDocuments mocks base method.
+func (m *MockRepositoryInterface) GetDocuments(arg0 context.Context, arg1 resources.Component) ([]resources.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocuments", arg0, arg1)
+	ret0, _ := ret[0].([]resources.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocuments indicates an expected call of GetDocuments.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDocuments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocuments", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDocuments), arg0, arg1)
+}
+
 // Push mocks base method.
 func (m *MockRepositoryInterface) Push(arg0 context.Context, arg1 resources.MetricSource, arg2 float64, arg3 time.Time) error {
 	m.ctrl.T.Helper()
@@ -138,6 +168,20 @@ func (mr *MockRepositoryInterfaceMockRecorder) RemoveDocument(arg0, arg1, arg2 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDocument", reflect.TypeOf((*MockRepositoryInterface)(nil).RemoveDocument), arg0, arg1, arg2)
 }
 
+// RemoveLink mocks base method.
+func (m *MockRepositoryInterface) RemoveLink(arg0 context.Context, arg1 resources.Component, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveLink", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveLink indicates an expected call of RemoveLink.
+func (mr *MockRepositoryInterfaceMockRecorder) RemoveLink(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLink", reflect.TypeOf((*MockRepositoryInterface)(nil).RemoveLink), arg0, arg1, arg2)
+}
+
 // SetAPISpecifications mocks base method.
 func (m *MockRepositoryInterface) SetAPISpecifications(arg0 context.Context, arg1 resources.Component, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
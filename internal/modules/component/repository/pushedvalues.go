@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+const pushedValueKind = "pushed-value"
+
+// PushedValue is the last value pushed to a metric source.
+type PushedValue struct {
+	MetricSourceID string    `json:"metricSourceId"`
+	Value          float64   `json:"value"`
+	RecordedAt     time.Time `json:"recordedAt"`
+}
+
+// PushedValuesInterface keeps the last value pushed to each metric source, so that identical values
+// are deduplicated across runs.
+type PushedValuesInterface interface {
+	// Last returns the last value pushed to the metric source, nil when none was pushed.
+	Last(metricSourceID string) (*PushedValue, error)
+	Save(pushed PushedValue) error
+}
+
+// PushedValues keeps the last pushed values as files in the directory given by PUSH_QUEUE_PATH,
+// one file per metric source.
+type PushedValues struct {
+	catalog catalogservice.FileCatalogInterface
+}
+
+func NewPushedValues(config configservice.ConfigServiceInterface) *PushedValues {
+	return &PushedValues{catalog: catalogservice.NewFileCatalogAt(config.GetPushQueuePath())}
+}
+
+func (v *PushedValues) Last(metricSourceID string) (*PushedValue, error) {
+	var pushed PushedValue
+	if err := v.catalog.Get(pushedValueKind, pushedValueID(metricSourceID), &pushed); err != nil {
+		if errors.Is(err, catalogservice.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read the last value pushed to metric source %s: %w", metricSourceID, err)
+	}
+
+	return &pushed, nil
+}
+
+func (v *PushedValues) Save(pushed PushedValue) error {
+	pushed.RecordedAt = pushed.RecordedAt.UTC()
+	if err := v.catalog.Put(pushedValueKind, pushedValueID(pushed.MetricSourceID), pushed); err != nil {
+		return fmt.Errorf("failed to save the value pushed to metric source %s: %w", pushed.MetricSourceID, err)
+	}
+
+	return nil
+}
+
+// pushedValueID returns the file name of the metric source, whose ID is an ARI holding slashes.
+func pushedValueID(metricSourceID string) string {
+	sum := sha256.Sum256([]byte(metricSourceID))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+)
+
+const (
+	DefaultPushConcurrency = 4
+	DefaultDedupWindow     = time.Minute
+)
+
+type PushOptions struct {
+	// Concurrency is the number of values pushed at the same time
+	Concurrency int
+	// DedupWindow is the time within which a value identical to the last one queued for, or pushed to,
+	// the same metric source is not pushed again, zero disables deduplication
+	DedupWindow time.Duration
+	// Queue spools the values whose push failed with a transient error, nil to drop them
+	Queue PushQueueInterface
+	// Pushed keeps the last value pushed to each metric source, to deduplicate values pushed by
+	// previous runs too, nil to only deduplicate the values queued in the pipeline
+	Pushed PushedValuesInterface
+}
+
+// PushSummary counts the values queued in a PushPipeline by outcome.
+type PushSummary struct {
+	Queued       int
+	Pushed       int
+	Deduplicated int
+	Failed       int
+	// Spooled counts the failed values saved in the push queue
+	Spooled int
+	// Errors are the errors of the failed pushes
+	Errors []error
+}
+
+// Unspooled returns the number of values whose push failed and which were not saved in the push queue,
+// that is the values lost.
+func (s PushSummary) Unspooled() int {
+	return s.Failed - s.Spooled
+}
+
+func (s PushSummary) String() string {
+	return fmt.Sprintf(
+		"%d value(s) queued: %d pushed, %d deduplicated, %d failed (%d spooled for push-pending)",
+		s.Queued, s.Pushed, s.Deduplicated, s.Failed, s.Spooled,
+	)
+}
+
+type pushRequest struct {
+	metricSource resources.MetricSource
+	value        float64
+	recordedAt   time.Time
+}
+
+// PushPipeline pushes metric values through the repository in the background, with a bounded number
+// of concurrent pushes. The rate of the pushes is limited by the Compass service, whose rate limit is
+// shared by all the calls of a run.
+type PushPipeline struct {
+	repository  RepositoryInterface
+	dedupWindow time.Duration
+	spool       PushQueueInterface
+	pushed      PushedValuesInterface
+	queue       chan pushRequest
+	workers     sync.WaitGroup
+
+	mutex   sync.Mutex
+	queued  map[string]pushRequest
+	summary PushSummary
+}
+
+// NewPushPipeline starts a pipeline pushing the values enqueued until it is closed.
+func NewPushPipeline(ctx context.Context, repository RepositoryInterface, options PushOptions) *PushPipeline {
+	concurrency := max(options.Concurrency, 1)
+	pipeline := &PushPipeline{
+		repository:  repository,
+		dedupWindow: options.DedupWindow,
+		spool:       options.Queue,
+		pushed:      options.Pushed,
+		queue:       make(chan pushRequest, concurrency),
+		queued:      make(map[string]pushRequest),
+	}
+
+	pipeline.workers.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go pipeline.work(ctx)
+	}
+
+	return pipeline
+}
+
+// Enqueue queues value for metricSource, unless an identical value was queued for it, or pushed to it
+// by a previous run, within the deduplication window. It blocks while all workers are busy.
+func (p *PushPipeline) Enqueue(metricSource resources.MetricSource, value float64, recordedAt time.Time) {
+	request := pushRequest{metricSource: metricSource, value: value, recordedAt: recordedAt}
+
+	p.mutex.Lock()
+	p.summary.Queued++
+	if p.isDuplicate(request) {
+		p.summary.Deduplicated++
+		p.mutex.Unlock()
+		return
+	}
+	p.queued[metricSource.ID] = request
+	p.mutex.Unlock()
+
+	p.queue <- request
+}
+
+// Close waits for the queued values to be pushed and returns the summary of the pipeline.
+func (p *PushPipeline) Close() PushSummary {
+	close(p.queue)
+	p.workers.Wait()
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.summary
+}
+
+func (p *PushPipeline) isDuplicate(request pushRequest) bool {
+	if p.dedupWindow <= 0 {
+		return false
+	}
+
+	last, exists := p.queued[request.metricSource.ID]
+	if !exists {
+		last, exists = p.lastPushed(request.metricSource)
+	}
+	if !exists || last.value != request.value {
+		return false
+	}
+
+	elapsed := request.recordedAt.Sub(last.recordedAt)
+	return elapsed > -p.dedupWindow && elapsed < p.dedupWindow
+}
+
+// lastPushed returns the last value pushed to metricSource by a previous run. A value which cannot be
+// read is not deduplicated.
+func (p *PushPipeline) lastPushed(metricSource resources.MetricSource) (pushRequest, bool) {
+	if p.pushed == nil {
+		return pushRequest{}, false
+	}
+
+	last, err := p.pushed.Last(metricSource.ID)
+	if err != nil {
+		p.summary.Errors = append(p.summary.Errors, err)
+		return pushRequest{}, false
+	}
+	if last == nil {
+		return pushRequest{}, false
+	}
+
+	return pushRequest{metricSource: metricSource, value: last.Value, recordedAt: last.RecordedAt}, true
+}
+
+func (p *PushPipeline) work(ctx context.Context) {
+	defer p.workers.Done()
+
+	for request := range p.queue {
+		err := p.repository.Push(ctx, request.metricSource, request.value, request.recordedAt)
+		spooled, spoolErr := p.spoolFailed(request, err)
+		saveErr := p.savePushed(request, err)
+
+		p.mutex.Lock()
+		if err != nil {
+			p.summary.Failed++
+			p.summary.Errors = append(p.summary.Errors, fmt.Errorf("push to metric source %s: %w", request.metricSource.ID, err))
+		} else {
+			p.summary.Pushed++
+		}
+		if spooled {
+			p.summary.Spooled++
+		}
+		if spoolErr != nil {
+			p.summary.Errors = append(p.summary.Errors, spoolErr)
+		}
+		if saveErr != nil {
+			p.summary.Errors = append(p.summary.Errors, saveErr)
+		}
+		p.mutex.Unlock()
+	}
+}
+
+// savePushed records the value of request as the last one pushed to its metric source, once pushed.
+func (p *PushPipeline) savePushed(request pushRequest, pushErr error) error {
+	if pushErr != nil || p.pushed == nil {
+		return nil
+	}
+
+	return p.pushed.Save(PushedValue{MetricSourceID: request.metricSource.ID, Value: request.value, RecordedAt: request.recordedAt})
+}
+
+// spoolFailed saves the value of request in the push queue when its push failed with a transient error.
+func (p *PushPipeline) spoolFailed(request pushRequest, pushErr error) (bool, error) {
+	if pushErr == nil || p.spool == nil || !IsTransientPushError(pushErr) {
+		return false, nil
+	}
+
+	if err := p.spool.Spool(request.metricSource, request.value, request.recordedAt, pushErr); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,255 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	repositorymocks "github.com/motain/of-catalog/internal/modules/component/repository/mocks"
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushPipeline(t *testing.T) {
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	sourceA := resources.MetricSource{ID: "metric-source-a"}
+	sourceB := resources.MetricSource{ID: "metric-source-b"}
+
+	type push struct {
+		metricSource resources.MetricSource
+		value        float64
+		recordedAt   time.Time
+	}
+	tests := []struct {
+		name            string
+		dedupWindow     time.Duration
+		pushes          []push
+		failingSource   string
+		expectedPushes  int
+		expectedSummary repository.PushSummary
+	}{
+		{
+			name:        "pushes every value",
+			dedupWindow: time.Minute,
+			pushes: []push{
+				{sourceA, 1, recordedAt},
+				{sourceB, 1, recordedAt},
+				{sourceA, 0, recordedAt.Add(time.Second)},
+			},
+			expectedPushes:  3,
+			expectedSummary: repository.PushSummary{Queued: 3, Pushed: 3},
+		},
+		{
+			name:        "deduplicates identical values of a metric source within the window",
+			dedupWindow: time.Minute,
+			pushes: []push{
+				{sourceA, 1, recordedAt},
+				{sourceA, 1, recordedAt.Add(30 * time.Second)},
+				{sourceA, 1, recordedAt.Add(2 * time.Minute)},
+			},
+			expectedPushes:  2,
+			expectedSummary: repository.PushSummary{Queued: 3, Pushed: 2, Deduplicated: 1},
+		},
+		{
+			name: "no deduplication without window",
+			pushes: []push{
+				{sourceA, 1, recordedAt},
+				{sourceA, 1, recordedAt},
+			},
+			expectedPushes:  2,
+			expectedSummary: repository.PushSummary{Queued: 2, Pushed: 2},
+		},
+		{
+			name:        "counts failed pushes",
+			dedupWindow: time.Minute,
+			pushes: []push{
+				{sourceA, 1, recordedAt},
+				{sourceB, 1, recordedAt},
+			},
+			failingSource:   sourceB.ID,
+			expectedPushes:  2,
+			expectedSummary: repository.PushSummary{Queued: 2, Pushed: 1, Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepository := repositorymocks.NewMockRepositoryInterface(ctrl)
+			mockRepository.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(tt.expectedPushes).DoAndReturn(
+				func(ctx context.Context, metricSource resources.MetricSource, value float64, recordedAt time.Time) error {
+					if metricSource.ID == tt.failingSource {
+						return errors.New("compass unavailable")
+					}
+					return nil
+				},
+			)
+
+			pipeline := repository.NewPushPipeline(context.Background(), mockRepository, repository.PushOptions{Concurrency: 2, DedupWindow: tt.dedupWindow})
+			for _, push := range tt.pushes {
+				pipeline.Enqueue(push.metricSource, push.value, push.recordedAt)
+			}
+			summary := pipeline.Close()
+
+			if tt.failingSource != "" {
+				require.Len(t, summary.Errors, 1)
+				assert.EqualError(t, summary.Errors[0], "push to metric source metric-source-b: compass unavailable")
+				summary.Errors = nil
+			}
+			assert.Equal(t, tt.expectedSummary, summary)
+		})
+	}
+}
+
+func TestPushPipeline_Concurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var running, maxRunning atomic.Int32
+	mockRepository := repositorymocks.NewMockRepositoryInterface(ctrl)
+	mockRepository.EXPECT().Push(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(12).DoAndReturn(
+		func(context.Context, resources.MetricSource, float64, time.Time) error {
+			current := running.Add(1)
+			for {
+				observed := maxRunning.Load()
+				if current <= observed || maxRunning.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		},
+	)
+
+	pipeline := repository.NewPushPipeline(context.Background(), mockRepository, repository.PushOptions{Concurrency: 3})
+	for i := 0; i < 12; i++ {
+		pipeline.Enqueue(resources.MetricSource{ID: "metric-source"}, float64(i), time.Now())
+	}
+	summary := pipeline.Close()
+
+	assert.Equal(t, 12, summary.Pushed)
+	assert.Equal(t, int32(3), maxRunning.Load())
+}
+
+func TestPushPipeline_SpoolsTransientFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	unavailable := &compassservice.Error{Class: compassservice.ErrorClassServer, Retryable: true, Err: errors.New("unavailable")}
+	rejected := &compassservice.Error{Class: compassservice.ErrorClassNotFound, Err: errors.New("metric source not found")}
+
+	mockRepository := repositorymocks.NewMockRepositoryInterface(ctrl)
+	mockRepository.EXPECT().Push(gomock.Any(), resources.MetricSource{ID: "unavailable"}, 1.0, recordedAt).Return(unavailable)
+	mockRepository.EXPECT().Push(gomock.Any(), resources.MetricSource{ID: "rejected"}, 1.0, recordedAt).Return(rejected)
+	mockQueue := repositorymocks.NewMockPushQueueInterface(ctrl)
+	mockQueue.EXPECT().Spool(resources.MetricSource{ID: "unavailable"}, 1.0, recordedAt, unavailable).Return(nil)
+
+	pipeline := repository.NewPushPipeline(context.Background(), mockRepository, repository.PushOptions{Concurrency: 1, Queue: mockQueue})
+	pipeline.Enqueue(resources.MetricSource{ID: "unavailable"}, 1, recordedAt)
+	pipeline.Enqueue(resources.MetricSource{ID: "rejected"}, 1, recordedAt)
+	summary := pipeline.Close()
+
+	assert.Equal(t, 2, summary.Failed)
+	assert.Equal(t, 1, summary.Spooled)
+	assert.Len(t, summary.Errors, 2)
+}
+
+func TestPushPipeline_DeduplicatesValuesPushedByPreviousRuns(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetPushQueuePath().Return(t.TempDir())
+	pushed := repository.NewPushedValues(mockConfig)
+	options := repository.PushOptions{Concurrency: 1, DedupWindow: time.Minute, Pushed: pushed}
+
+	mockRepository := repositorymocks.NewMockRepositoryInterface(ctrl)
+	mockRepository.EXPECT().Push(gomock.Any(), resources.MetricSource{ID: "metric-source"}, 1.0, recordedAt).Return(nil)
+	mockRepository.EXPECT().Push(gomock.Any(), resources.MetricSource{ID: "metric-source"}, 2.0, recordedAt.Add(time.Second)).Return(nil)
+
+	first := repository.NewPushPipeline(context.Background(), mockRepository, options)
+	first.Enqueue(resources.MetricSource{ID: "metric-source"}, 1, recordedAt)
+	assert.Equal(t, repository.PushSummary{Queued: 1, Pushed: 1}, first.Close())
+
+	second := repository.NewPushPipeline(context.Background(), mockRepository, options)
+	second.Enqueue(resources.MetricSource{ID: "metric-source"}, 1, recordedAt.Add(30*time.Second))
+	second.Enqueue(resources.MetricSource{ID: "metric-source"}, 2, recordedAt.Add(time.Second))
+	assert.Equal(t, repository.PushSummary{Queued: 2, Pushed: 1, Deduplicated: 1}, second.Close())
+
+	last, err := pushed.Last("metric-source")
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, 2.0, last.Value)
+}
+
+func TestPushSummary_Unspooled(t *testing.T) {
+	assert.Equal(t, 0, repository.PushSummary{Queued: 2, Failed: 2, Spooled: 2}.Unspooled())
+	assert.Equal(t, 1, repository.PushSummary{Queued: 2, Failed: 2, Spooled: 1}.Unspooled())
+}
+
+// newCompassRepository returns a repository pushing the values to a Compass served by handler.
+func newCompassRepository(t *testing.T, ctrl *gomock.Controller, handler http.HandlerFunc) *repository.Repository {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetCompassHost().Return(server.URL).AnyTimes()
+	mockConfig.EXPECT().GetCompassToken().Return("mock-token").AnyTimes()
+	mockConfig.EXPECT().GetCompassCloudId().Return("mock-cloud-id").AnyTimes()
+	mockConfig.EXPECT().GetCompassMaxRetries().Return(3).AnyTimes()
+	mockConfig.EXPECT().GetCompassRetryBaseDelay().Return(time.Millisecond).AnyTimes()
+	mockConfig.EXPECT().GetCompassRateLimit().Return(0.0).AnyTimes()
+
+	return repository.NewRepository(compassservice.NewCompassService(mockConfig, nil, compassservice.NewHTTPClient(mockConfig)))
+}
+
+func TestPushPipeline_SpoolsValuesCompassFailedToProcess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var calls atomic.Int32
+	compassRepository := newCompassRepository(t, ctrl, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "dropped") {
+			// The value was received, but the connection drops before the response
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetPushQueuePath().Return(t.TempDir())
+	queue := repository.NewPushQueue(mockConfig)
+
+	pipeline := repository.NewPushPipeline(context.Background(), compassRepository, repository.PushOptions{Concurrency: 1, Queue: queue})
+	pipeline.Enqueue(resources.MetricSource{ID: "server-error"}, 1, time.Now())
+	pipeline.Enqueue(resources.MetricSource{ID: "dropped"}, 1, time.Now())
+	summary := pipeline.Close()
+
+	assert.Equal(t, int32(2), calls.Load(), "values Compass may have processed are not retried")
+	assert.Equal(t, 2, summary.Failed)
+	assert.Equal(t, 2, summary.Spooled, "values Compass may have processed are pushed again by push-pending")
+	assert.Zero(t, summary.Unspooled())
+
+	pending, err := queue.Pending()
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+}
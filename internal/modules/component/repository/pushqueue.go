@@ -0,0 +1,140 @@
+package repository
+
+//go:generate mockgen -destination=./mocks/mock_push_queue.go -package=repository github.com/motain/of-catalog/internal/modules/component/repository PushQueueInterface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+const (
+	pendingPushKind = "pending-push"
+	// rejectedPushKind is the dead-letter directory of the push queue
+	rejectedPushKind = "rejected-push"
+)
+
+// PendingPush is a metric value whose push failed, kept with the time it was recorded at.
+type PendingPush struct {
+	ID               string    `json:"-"`
+	MetricSourceID   string    `json:"metricSourceId"`
+	MetricSourceName string    `json:"metricSourceName"`
+	Value            float64   `json:"value"`
+	RecordedAt       time.Time `json:"recordedAt"`
+	SpooledAt        time.Time `json:"spooledAt"`
+	// Error is the error of the failed push
+	Error string `json:"error"`
+}
+
+func (p PendingPush) MetricSource() resources.MetricSource {
+	return resources.MetricSource{ID: p.MetricSourceID, Name: p.MetricSourceName}
+}
+
+// PushQueueInterface spools metric values whose push failed, to push them later.
+type PushQueueInterface interface {
+	Spool(metricSource resources.MetricSource, value float64, recordedAt time.Time, pushErr error) error
+	// Pending returns the spooled values, oldest recorded first.
+	Pending() ([]PendingPush, error)
+	Remove(pending PendingPush) error
+	// Reject moves a value rejected by Compass out of the queue, to the dead-letter directory, and returns its path.
+	Reject(pending PendingPush, pushErr error) (string, error)
+}
+
+// PushQueue keeps the spooled values as files in the directory given by PUSH_QUEUE_PATH,
+// one file per value, so that they outlive the run. The values rejected by Compass are moved to
+// its rejected-push directory.
+type PushQueue struct {
+	root    string
+	catalog catalogservice.FileCatalogInterface
+}
+
+func NewPushQueue(config configservice.ConfigServiceInterface) *PushQueue {
+	root := config.GetPushQueuePath()
+	return &PushQueue{root: root, catalog: catalogservice.NewFileCatalogAt(root)}
+}
+
+func (q *PushQueue) Spool(metricSource resources.MetricSource, value float64, recordedAt time.Time, pushErr error) error {
+	pending := PendingPush{
+		MetricSourceID:   metricSource.ID,
+		MetricSourceName: metricSource.Name,
+		Value:            value,
+		RecordedAt:       recordedAt.UTC(),
+		SpooledAt:        time.Now().UTC(),
+		Error:            pushErr.Error(),
+	}
+
+	id, idErr := q.catalog.NewID(pendingPushKind)
+	if idErr != nil {
+		return fmt.Errorf("failed to spool value of metric source %s: %w", metricSource.ID, idErr)
+	}
+
+	if err := q.catalog.Put(pendingPushKind, id, pending); err != nil {
+		return fmt.Errorf("failed to spool value of metric source %s: %w", metricSource.ID, err)
+	}
+
+	return nil
+}
+
+func (q *PushQueue) Pending() ([]PendingPush, error) {
+	ids, listErr := q.catalog.List(pendingPushKind)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	pending := make([]PendingPush, len(ids))
+	for i, id := range ids {
+		if err := q.catalog.Get(pendingPushKind, id, &pending[i]); err != nil {
+			return nil, err
+		}
+		pending[i].ID = id
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].RecordedAt.Before(pending[j].RecordedAt) })
+
+	return pending, nil
+}
+
+func (q *PushQueue) Remove(pending PendingPush) error {
+	return q.catalog.Delete(pendingPushKind, pending.ID)
+}
+
+func (q *PushQueue) Reject(pending PendingPush, pushErr error) (string, error) {
+	rejected := pending
+	rejected.Error = pushErr.Error()
+	if err := q.catalog.Put(rejectedPushKind, pending.ID, rejected); err != nil {
+		return "", fmt.Errorf("failed to reject value of metric source %s: %w", pending.MetricSourceID, err)
+	}
+
+	if err := q.catalog.Delete(pendingPushKind, pending.ID); err != nil {
+		return "", fmt.Errorf("failed to reject value of metric source %s: %w", pending.MetricSourceID, err)
+	}
+
+	return filepath.Join(q.root, rejectedPushKind, pending.ID+".json"), nil
+}
+
+// IsTransientPushError tells whether a push failed with an error that may not happen later, e.g. when Compass
+// is unavailable or rate limiting, or when the connection failed, as opposed to a push rejected by Compass.
+// It is told by the class of the error: a push Compass may have processed is not retried, but may be pushed
+// again later. A canceled push is not transient, the run being stopped.
+func IsTransientPushError(err error) bool {
+	var compassErr *compassservice.Error
+	if !errors.As(err, &compassErr) {
+		return false
+	}
+
+	switch compassErr.Class {
+	case compassservice.ErrorClassServer, compassservice.ErrorClassRateLimited:
+		return true
+	case compassservice.ErrorClassTransport:
+		return !errors.Is(err, context.Canceled)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,126 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	"github.com/motain/of-catalog/internal/modules/component/resources"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetPushQueuePath().Return(t.TempDir())
+	queue := repository.NewPushQueue(mockConfig)
+
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	sourceA := resources.MetricSource{ID: "metric-source-a", Name: "metric-a", Facts: nil}
+	sourceB := resources.MetricSource{ID: "metric-source-b", Name: "metric-b"}
+	pushErr := errors.New("service unavailable")
+
+	require.NoError(t, queue.Spool(sourceA, 1, recordedAt.Add(time.Hour), pushErr))
+	require.NoError(t, queue.Spool(sourceB, 0.5, recordedAt, pushErr))
+
+	pending, err := queue.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, sourceB, pending[0].MetricSource(), "oldest value first")
+	assert.Equal(t, 0.5, pending[0].Value)
+	assert.True(t, recordedAt.Equal(pending[0].RecordedAt))
+	assert.Equal(t, "service unavailable", pending[0].Error)
+	assert.Equal(t, sourceA, pending[1].MetricSource())
+
+	require.NoError(t, queue.Remove(pending[0]))
+
+	pending, err = queue.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "metric-source-a", pending[0].MetricSourceID)
+}
+
+func TestIsTransientPushError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "compass unavailable",
+			err:      &compassservice.Error{Class: compassservice.ErrorClassServer, StatusCode: http.StatusServiceUnavailable, Retryable: true, Err: errors.New("unavailable")},
+			expected: true,
+		},
+		{
+			name:     "server error",
+			err:      &compassservice.Error{Class: compassservice.ErrorClassServer, StatusCode: http.StatusBadGateway, Err: errors.New("bad gateway")},
+			expected: true,
+		},
+		{
+			name:     "connection reset after sending",
+			err:      &compassservice.Error{Class: compassservice.ErrorClassTransport, Err: errors.New("connection reset by peer")},
+			expected: true,
+		},
+		{
+			name:     "push canceled",
+			err:      &compassservice.Error{Class: compassservice.ErrorClassTransport, Err: fmt.Errorf("failed to send request: %w", context.Canceled)},
+			expected: false,
+		},
+		{
+			name:     "push rejected",
+			err:      &compassservice.Error{Class: compassservice.ErrorClassNotFound, StatusCode: http.StatusNotFound, Err: errors.New("metric source not found")},
+			expected: false,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("disk full"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repository.IsTransientPushError(tt.err))
+		})
+	}
+}
+
+func TestPushQueue_Reject(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	root := t.TempDir()
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetPushQueuePath().Return(root)
+	queue := repository.NewPushQueue(mockConfig)
+
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, queue.Spool(resources.MetricSource{ID: "metric-source"}, 1, recordedAt, errors.New("service unavailable")))
+	pending, err := queue.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	path, err := queue.Reject(pending[0], errors.New("metric source not found"))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "rejected-push", pending[0].ID+".json"), path)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"error": "metric source not found"`)
+
+	pending, err = queue.Pending()
+	require.NoError(t, err)
+	assert.Empty(t, pending, "rejected values are removed from the queue")
+}
@@ -85,7 +85,7 @@ func (r *Repository) Create(ctx context.Context, component resources.Component)
 
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return resources.Component{}, fmt.Errorf("Create component error for %s: %s", component.Name, runErr)
+		return resources.Component{}, fmt.Errorf("Create component error for %s: %w", component.Name, runErr)
 	}
 
 	metricSources := make(map[string]*resources.MetricSource)
@@ -145,7 +145,7 @@ func (r *Repository) Update(ctx context.Context, component resources.Component)
 
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return resources.Component{}, fmt.Errorf("Update component error for %s: %s", component.Name, runErr)
+		return resources.Component{}, fmt.Errorf("Update component error for %s: %w", component.Name, runErr)
 	}
 
 	return component, nil
@@ -155,7 +155,7 @@ func (r *Repository) Delete(ctx context.Context, component resources.Component)
 	input := &dtos.DeleteComponentInput{ComponentID: component.ID}
 	output := &dtos.DeleteComponentOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("Delete component error for %s: %s", component.ID, runErr)
+		return fmt.Errorf("Delete component error for %s: %w", component.ID, runErr)
 	}
 	return nil
 }
@@ -164,7 +164,7 @@ func (r *Repository) SetDependency(ctx context.Context, dependent, provider reso
 	input := &dtos.CreateDependencyInput{DependentId: dependent.ID, ProviderId: provider.ID}
 	output := &dtos.CreateDependencyOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("SetDependency error for %s: %s", dependent.ID, runErr)
+		return fmt.Errorf("SetDependency error for %s: %w", dependent.ID, runErr)
 	}
 	return nil
 }
@@ -173,7 +173,7 @@ func (r *Repository) UnsetDependency(ctx context.Context, dependent, provider re
 	input := &dtos.DeleteDependencyInput{DependentId: dependent.ID, ProviderId: provider.ID}
 	output := &dtos.DeleteDependencyOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("UnsetDependency dependency error for %s: %s", dependent.ID, runErr)
+		return fmt.Errorf("UnsetDependency dependency error for %s: %w", dependent.ID, runErr)
 	}
 	return nil
 }
@@ -183,7 +183,7 @@ func (r *Repository) GetBySlug(ctx context.Context, component resources.Componen
 	output := &dtos.ComponentByReferenceOutput{}
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return nil, fmt.Errorf("GetBySlug error for %s: %s", component.Slug, runErr)
+		return nil, fmt.Errorf("GetBySlug error for %s: %w", component.Slug, runErr)
 	}
 
 	metricSources := make(map[string]*resources.MetricSource)
@@ -204,8 +204,18 @@ func (r *Repository) GetBySlug(ctx context.Context, component resources.Componen
 		}
 	}
 
+	var labels []string
+	for _, label := range output.Compass.Component.Labels {
+		labels = append(labels, label.Name)
+	}
+
 	found := resources.Component{
 		ID:            output.Compass.Component.ID,
+		Name:          output.Compass.Component.Name,
+		Description:   output.Compass.Component.Description,
+		TypeID:        output.Compass.Component.TypeID,
+		OwnerID:       output.Compass.Component.OwnerID,
+		Labels:        labels,
 		MetricSources: metricSources,
 		Links:         links,
 	}
@@ -224,7 +234,7 @@ func (r *Repository) AddDocument(ctx context.Context, component resources.Compon
 	output := &dtos.CreateDocumentOutput{}
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return resources.Document{}, fmt.Errorf("AddDocument error for %s/%s: %s", component.ID, document.Title, runErr)
+		return resources.Document{}, fmt.Errorf("AddDocument error for %s/%s: %w", component.ID, document.Title, runErr)
 	}
 
 	doc := resources.Document{
@@ -244,7 +254,7 @@ func (r *Repository) AddLink(ctx context.Context, component resources.Component,
 	}
 	output := &dtos.CreateLinkOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return nil, fmt.Errorf("AddLink error for %s/%s: %s", component.ID, link.Name, runErr)
+		return nil, fmt.Errorf("AddLink error for %s/%s: %w", component.ID, link.Name, runErr)
 	}
 
 	return &resources.Link{ID: output.Compass.CreateComponentLink.Details.ID}, nil
@@ -258,7 +268,7 @@ func (r *Repository) RemoveLink(ctx context.Context, component resources.Compone
 
 	output := &dtos.RemoveLinkOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("RemoveLink error for %s/%s: %s", component.ID, id, runErr)
+		return fmt.Errorf("RemoveLink error for %s/%s: %w", component.ID, id, runErr)
 	}
 	return nil
 }
@@ -269,7 +279,7 @@ func (r *Repository) GetDocuments(ctx context.Context, component resources.Compo
 	}
 	output := &dtos.GetDocumentsOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return nil, fmt.Errorf("GetDocuments error for %s: %s", component.ID, runErr)
+		return nil, fmt.Errorf("GetDocuments error for %s: %w", component.ID, runErr)
 	}
 
 	if len(output.Compass.Documents.Nodes) == 0 {
@@ -297,7 +307,7 @@ func (r *Repository) UpdateDocument(ctx context.Context, component resources.Com
 	}
 	output := &dtos.UpdateDocumentOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("UpdateDocument error for %s/%s: %s", component.ID, document.Title, runErr)
+		return fmt.Errorf("UpdateDocument error for %s/%s: %w", component.ID, document.Title, runErr)
 	}
 	return nil
 }
@@ -308,7 +318,7 @@ func (r *Repository) RemoveDocument(ctx context.Context, component resources.Com
 	}
 	output := &dtos.DeleteDocumentOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("RemoveDocument error for %s/%s: %s", component.ID, document.Title, runErr)
+		return fmt.Errorf("RemoveDocument error for %s/%s: %w", component.ID, document.Title, runErr)
 	}
 	return nil
 }
@@ -322,7 +332,7 @@ func (r *Repository) BindMetric(ctx context.Context, component resources.Compone
 	output := &dtos.BindMetricOutput{}
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return "", fmt.Errorf("BindMetric error for %s/%s: %s", component.ID, metricID, runErr)
+		return "", fmt.Errorf("BindMetric error for %s/%s: %w", component.ID, metricID, runErr)
 	}
 
 	return output.Compass.CreateMetricSource.CreateMetricSource.ID, nil
@@ -332,7 +342,7 @@ func (r *Repository) UnbindMetric(ctx context.Context, metricSource resources.Me
 	input := &dtos.UnbindMetricInput{MetricID: metricSource.ID}
 	output := &dtos.UnbindMetricOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("UnbindMetric error for %s: %s", metricSource.ID, runErr)
+		return fmt.Errorf("UnbindMetric error for %s: %w", metricSource.ID, runErr)
 	}
 	return nil
 }
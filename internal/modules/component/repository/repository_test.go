@@ -390,7 +390,12 @@ func TestRepository_GetBySlug(t *testing.T) {
 					func(ctx context.Context, input, output interface{}) error {
 						getOutput := output.(*dtos.ComponentByReferenceOutput)
 						getOutput.Compass.Component = dtos.Component{
-							ID: "component-id",
+							ID:          "component-id",
+							Name:        "test",
+							Description: "description",
+							TypeID:      "SERVICE",
+							OwnerID:     "owner-id",
+							Labels:      []dtos.Label{{Name: "label1"}},
 							MetricSources: dtos.MetricSources{
 								Nodes: []dtos.MetricSource{
 									{
@@ -409,7 +414,12 @@ func TestRepository_GetBySlug(t *testing.T) {
 				)
 			},
 			expectedResult: &resources.Component{
-				ID: "component-id",
+				ID:          "component-id",
+				Name:        "test",
+				Description: "description",
+				TypeID:      "SERVICE",
+				OwnerID:     "owner-id",
+				Labels:      []string{"label1"},
 				MetricSources: map[string]*resources.MetricSource{
 					"metric-name": {
 						ID:     "metric-source-id",
@@ -602,7 +612,11 @@ func TestRepository_AddDocument(t *testing.T) {
 			tt.mockSetup()
 			got, err := repo.AddDocument(context.Background(), tt.component, tt.document)
 			assert.Equal(t, tt.expectedResult, got)
-			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -685,7 +699,11 @@ func TestRepository_UpdateDocument(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 			err := repo.UpdateDocument(context.Background(), tt.component, tt.document)
-			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"time"
+
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+)
+
+// BackfillTimes returns the times from from to to, both included, every step.
+func BackfillTimes(from, to time.Time, step time.Duration) []time.Time {
+	if step <= 0 {
+		return nil
+	}
+
+	var times []time.Time
+	for at := from; !at.After(to); at = at.Add(step) {
+		times = append(times, at)
+	}
+	return times
+}
+
+// IsBackfillable tells whether the facts of a metric can be evaluated in the past: their data must
+// come from the prometheus source only, the other sources return the current data.
+func IsBackfillable(tasks []*fsdtos.Task) bool {
+	extracts := 0
+	for _, task := range tasks {
+		if fsdtos.TaskType(task.Type) != fsdtos.ExtractType {
+			continue
+		}
+		if fsdtos.TaskSource(task.Source) != fsdtos.PrometheusTaskSource {
+			return false
+		}
+		extracts++
+	}
+	return extracts > 0
+}
+
+// CopyFacts returns copies of the facts of a metric without their run related fields, so that they
+// can be processed again.
+func CopyFacts(tasks []*fsdtos.Task) []*fsdtos.Task {
+	copies := make([]*fsdtos.Task, len(tasks))
+	for i, task := range tasks {
+		taskCopy := *task
+		taskCopy.Result = nil
+		taskCopy.Dependencies = nil
+		taskCopy.DoneCh = nil
+		copies[i] = &taskCopy
+	}
+	return copies
+}
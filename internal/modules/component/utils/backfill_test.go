@@ -0,0 +1,109 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/component/utils"
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackfillTimes(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		to       time.Time
+		step     time.Duration
+		expected []time.Time
+	}{
+		{
+			name:     "includes both ends",
+			to:       from.Add(2 * time.Hour),
+			step:     time.Hour,
+			expected: []time.Time{from, from.Add(time.Hour), from.Add(2 * time.Hour)},
+		},
+		{
+			name:     "stops before the end",
+			to:       from.Add(90 * time.Minute),
+			step:     time.Hour,
+			expected: []time.Time{from, from.Add(time.Hour)},
+		},
+		{
+			name:     "single time",
+			to:       from,
+			step:     time.Hour,
+			expected: []time.Time{from},
+		},
+		{
+			name: "end before start",
+			to:   from.Add(-time.Hour),
+			step: time.Hour,
+		},
+		{
+			name: "no step",
+			to:   from.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.BackfillTimes(from, tt.to, tt.step))
+		})
+	}
+}
+
+func TestIsBackfillable(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []*fsdtos.Task
+		expected bool
+	}{
+		{
+			name: "prometheus facts",
+			tasks: []*fsdtos.Task{
+				{ID: "error-rate", Type: "extract", Source: "prometheus", PrometheusQuery: "sum(rate(errors[5m]))"},
+				{ID: "latency", Type: "extract", Source: "prometheus", PrometheusQuery: "histogram_quantile(0.99, latency)"},
+				{ID: "healthy", Type: "aggregate", Method: "and", DependsOn: []string{"error-rate", "latency"}},
+			},
+			expected: true,
+		},
+		{
+			name: "github fact",
+			tasks: []*fsdtos.Task{
+				{ID: "error-rate", Type: "extract", Source: "prometheus", PrometheusQuery: "sum(rate(errors[5m]))"},
+				{ID: "readme", Type: "extract", Source: "github", FilePath: "README.md"},
+			},
+			expected: false,
+		},
+		{
+			name: "no extract fact",
+			tasks: []*fsdtos.Task{
+				{ID: "healthy", Type: "aggregate", Method: "and"},
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.IsBackfillable(tt.tasks))
+		})
+	}
+}
+
+func TestCopyFacts(t *testing.T) {
+	dependency := &fsdtos.Task{ID: "error-rate", Type: "extract", Source: "prometheus", Result: 0.1}
+	task := &fsdtos.Task{ID: "healthy", Type: "aggregate", DependsOn: []string{"error-rate"}, Result: true, Dependencies: []*fsdtos.Task{dependency}}
+
+	copies := utils.CopyFacts([]*fsdtos.Task{dependency, task})
+
+	assert.Equal(t, []*fsdtos.Task{
+		{ID: "error-rate", Type: "extract", Source: "prometheus"},
+		{ID: "healthy", Type: "aggregate", DependsOn: []string{"error-rate"}},
+	}, copies)
+	assert.Equal(t, true, task.Result, "the facts are not changed")
+	assert.Len(t, task.Dependencies, 1)
+}
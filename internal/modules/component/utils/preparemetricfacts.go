@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"github.com/motain/of-catalog/internal/modules/component/dtos"
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+)
+
+// PrepareMetricFacts returns copies of the facts of a metric with their placeholders replaced
+// by the values of component, as they are stored in the component metric sources.
+func PrepareMetricFacts(tasks []*fsdtos.Task, component dtos.ComponentDTO) []*fsdtos.Task {
+	processedFacts := make([]*fsdtos.Task, len(tasks))
+	for i, task := range tasks {
+		processedFacts[i] = prepareMetricFact(task, component)
+	}
+	return processedFacts
+}
+
+func prepareMetricFact(task *fsdtos.Task, component dtos.ComponentDTO) *fsdtos.Task {
+	if task == nil {
+		return nil
+	}
+
+	processedFact := fsdtos.Task{
+		ID:     task.ID,
+		Name:   task.Name,
+		Source: task.Source,
+		URI:    ReplaceMetricFactPlaceholders(task.URI, component),
+		Auth:   task.Auth,
+		// ComponentName: ReplaceMetricFactPlaceholders(task.ComponentName, component),
+		Repo:            ReplaceMetricFactPlaceholders(task.Repo, component),
+		Type:            task.Type,
+		FilePath:        task.FilePath,
+		JSONPath:        task.JSONPath,
+		Rule:            task.Rule,
+		Pattern:         ReplaceMetricFactPlaceholders(task.Pattern, component),
+		DependsOn:       task.DependsOn,
+		Method:          task.Method,
+		SearchString:    task.SearchString,
+		PrometheusQuery: ReplaceMetricFactPlaceholders(task.PrometheusQuery, component),
+		Query:           ReplaceMetricFactPlaceholders(task.Query, component),
+		Variables:       prepareMetricFactVariables(task.Variables, component),
+
+		// Are these still worth it?
+		// RegexPattern:     task.RegexPattern,
+		// RepoProperty:     task.RepoProperty,
+		// ReposSearchQuery: task.ReposSearchQuery,
+
+		// I need to reintegrate this !!
+		// ExpectedFormula:  task.ExpectedFormula,
+	}
+
+	return &processedFact
+}
+
+func prepareMetricFactVariables(variables map[string]string, component dtos.ComponentDTO) map[string]string {
+	if variables == nil {
+		return nil
+	}
+
+	processedVariables := make(map[string]string, len(variables))
+	for key, value := range variables {
+		processedVariables[key] = ReplaceMetricFactPlaceholders(value, component)
+	}
+	return processedVariables
+}
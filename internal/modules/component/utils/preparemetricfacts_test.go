@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/modules/component/dtos"
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareMetricFacts(t *testing.T) {
+	component := dtos.ComponentDTO{
+		Metadata: dtos.Metadata{Name: "bookmarks"},
+		Spec:     dtos.Spec{Name: "bookmarks", Slug: "svc-bookmarks"},
+	}
+	tasks := []*fsdtos.Task{
+		{
+			ID:              "read",
+			Type:            "extract",
+			Source:          "github",
+			Repo:            "${Metadata.Name}",
+			FilePath:        "app.toml",
+			PrometheusQuery: `up{namespace="${Metadata.Name}"}`,
+			Variables:       map[string]string{"slug": "${Spec.Slug}"},
+		},
+		{
+			ID:        "validate",
+			Type:      "validate",
+			Rule:      "regex_match",
+			Pattern:   "^${Metadata.Name}.*$",
+			DependsOn: []string{"read"},
+		},
+		nil,
+	}
+
+	prepared := PrepareMetricFacts(tasks, component)
+
+	assert.Equal(t, []*fsdtos.Task{
+		{
+			ID:              "read",
+			Type:            "extract",
+			Source:          "github",
+			Repo:            "bookmarks",
+			FilePath:        "app.toml",
+			PrometheusQuery: `up{namespace="bookmarks"}`,
+			Variables:       map[string]string{"slug": "svc-bookmarks"},
+		},
+		{
+			ID:        "validate",
+			Type:      "validate",
+			Rule:      "regex_match",
+			Pattern:   "^bookmarks.*$",
+			DependsOn: []string{"read"},
+		},
+		nil,
+	}, prepared)
+	assert.Equal(t, "${Metadata.Name}", tasks[0].Repo, "the facts of the metric are left unchanged")
+}
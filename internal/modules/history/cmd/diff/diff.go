@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/history/handler"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var componentName, metricName, since string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "List the metric values which changed since a time",
+		Long:  "Compare the last metric values recorded by compute with the values they had at a time, to find the components whose metrics changed.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if since == "" {
+				fmt.Println("Error: since is required")
+				cmd.Help()
+				return
+			}
+			sinceTime, sinceErr := handler.ParseSince(since, time.Now())
+			if sinceErr != nil {
+				fmt.Printf("Error: %v\n", sinceErr)
+				cmd.Help()
+				return
+			}
+
+			handler := initializeHandler()
+			filter := historyservice.Filter{Component: componentName, Metric: metricName}
+			if _, err := handler.Diff(filter, sinceTime, os.Stdout); err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&componentName, "component", "c", "", "Name of the component")
+	cmd.Flags().StringVarP(&metricName, "metric", "m", "", "Name of the metric")
+	cmd.Flags().StringVar(&since, "since", "", "Time to compare the last values with, RFC 3339 or a duration before now (e.g. 168h)")
+
+	return cmd
+}
@@ -0,0 +1,27 @@
+//go:build wireinject
+
+package diff
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/history/handler"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+)
+
+var ProviderSet = wire.NewSet(
+	// Configservice
+	configservice.NewConfigService,
+	wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)),
+
+	// HistoryService
+	historyservice.NewHistoryService,
+	wire.Bind(new(historyservice.HistoryServiceInterface), new(*historyservice.HistoryService)),
+
+	// DiffHandler
+	handler.NewDiffHandler,
+)
+
+func initializeHandler() *handler.DiffHandler {
+	panic(wire.Build(ProviderSet))
+}
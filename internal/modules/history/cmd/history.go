@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/motain/of-catalog/internal/modules/history/cmd/diff"
+	"github.com/motain/of-catalog/internal/modules/history/cmd/show"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "metric value history related commands",
+	}
+
+	historyCmd.AddCommand(show.Init())
+	historyCmd.AddCommand(diff.Init())
+
+	return historyCmd
+}
@@ -0,0 +1,46 @@
+package show
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/history/handler"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var componentName, metricName, since string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "List the metric values recorded by compute",
+		Long:  "List the metric values computed for the components, as recorded by compute in METRIC_HISTORY_PATH, oldest first.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			filter := historyservice.Filter{Component: componentName, Metric: metricName}
+			if since != "" {
+				sinceTime, sinceErr := handler.ParseSince(since, time.Now())
+				if sinceErr != nil {
+					fmt.Printf("Error: %v\n", sinceErr)
+					cmd.Help()
+					return
+				}
+				filter.Since = sinceTime
+			}
+
+			handler := initializeHandler()
+			if err := handler.Show(filter, os.Stdout); err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&componentName, "component", "c", "", "Name of the component")
+	cmd.Flags().StringVarP(&metricName, "metric", "m", "", "Name of the metric")
+	cmd.Flags().StringVar(&since, "since", "", "Only the values recorded since this time, RFC 3339 or a duration before now (e.g. 168h)")
+
+	return cmd
+}
@@ -0,0 +1,27 @@
+//go:build wireinject
+
+package show
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/history/handler"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+)
+
+var ProviderSet = wire.NewSet(
+	// Configservice
+	configservice.NewConfigService,
+	wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)),
+
+	// HistoryService
+	historyservice.NewHistoryService,
+	wire.Bind(new(historyservice.HistoryServiceInterface), new(*historyservice.HistoryService)),
+
+	// ShowHandler
+	handler.NewShowHandler,
+)
+
+func initializeHandler() *handler.ShowHandler {
+	panic(wire.Build(ProviderSet))
+}
@@ -0,0 +1,27 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package show
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/history/handler"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.ShowHandler {
+	configService := configservice.NewConfigService()
+	historyService := historyservice.NewHistoryService(configService)
+	showHandler := handler.NewShowHandler(historyService)
+	return showHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), historyservice.NewHistoryService, wire.Bind(new(historyservice.HistoryServiceInterface), new(*historyservice.HistoryService)), handler.NewShowHandler)
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/historyservice"
+)
+
+type DiffHandler struct {
+	history historyservice.HistoryServiceInterface
+}
+
+func NewDiffHandler(history historyservice.HistoryServiceInterface) *DiffHandler {
+	return &DiffHandler{history: history}
+}
+
+// Diff writes the metric values which changed since the given time, decreases first, and returns
+// the number of changes. Changes are not labelled as regressions, the better direction of a value
+// depending on the metric.
+func (h *DiffHandler) Diff(filter historyservice.Filter, since time.Time, output io.Writer) (int, error) {
+	// The values recorded before since are needed as the values the last ones are compared with
+	records, recordsErr := h.history.Records(filter)
+	if recordsErr != nil {
+		return 0, recordsErr
+	}
+
+	var decreases, increases []historyservice.Change
+	for _, change := range historyservice.Diff(records, since) {
+		if change.Decreased() {
+			decreases = append(decreases, change)
+		} else {
+			increases = append(increases, change)
+		}
+	}
+
+	if len(decreases)+len(increases) > 0 {
+		writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "CHANGE\tCOMPONENT\tMETRIC\tBEFORE\tAFTER\tRECORDED")
+		for _, change := range decreases {
+			writeChange(writer, "decreased", change)
+		}
+		for _, change := range increases {
+			writeChange(writer, "increased", change)
+		}
+		if err := writer.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	fmt.Fprintf(output, "%d decrease(s), %d increase(s) since %s\n", len(decreases), len(increases), since.Format(time.RFC3339))
+
+	return len(decreases) + len(increases), nil
+}
+
+func writeChange(writer io.Writer, kind string, change historyservice.Change) {
+	fmt.Fprintf(
+		writer, "%s\t%s\t%s\t%v\t%v\t%s\n",
+		kind, change.Component, change.Metric, change.Before.Value, change.After.Value, change.After.RecordedAt.Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/historyservice"
+)
+
+type ShowHandler struct {
+	history historyservice.HistoryServiceInterface
+}
+
+func NewShowHandler(history historyservice.HistoryServiceInterface) *ShowHandler {
+	return &ShowHandler{history: history}
+}
+
+// Show writes the metric values recorded by compute matching filter, oldest first.
+func (h *ShowHandler) Show(filter historyservice.Filter, output io.Writer) error {
+	records, recordsErr := h.history.Records(filter)
+	if recordsErr != nil {
+		return recordsErr
+	}
+
+	if len(records) == 0 {
+		fmt.Fprintln(output, "No metric value recorded yet.")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "RECORDED\tCOMPONENT\tMETRIC\tVALUE")
+	for _, record := range records {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%v\n", record.RecordedAt.Format(time.RFC3339), record.Component, record.Metric, record.Value)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseSince parses a time given as RFC 3339, or as a duration before now, e.g. 168h.
+func ParseSince(since string, now time.Time) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, since); err == nil {
+		return at, nil
+	}
+
+	ago, durationErr := time.ParseDuration(since)
+	if durationErr != nil || ago < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected an RFC 3339 time or a duration before now", since)
+	}
+
+	return now.Add(-ago), nil
+}
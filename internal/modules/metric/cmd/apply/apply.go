@@ -2,8 +2,11 @@ package apply
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/motain/of-catalog/internal/utils/drift"
 	"github.com/motain/of-catalog/internal/utils/yaml"
 	"github.com/spf13/cobra"
 )
@@ -11,6 +14,8 @@ import (
 func Init() *cobra.Command {
 	var configRootLocation string
 	var recursive bool
+	var lockTimeout time.Duration
+	var deletePolicy drift.DeletePolicy
 
 	cmd := &cobra.Command{
 		Use:   "apply",
@@ -23,12 +28,20 @@ func Init() *cobra.Command {
 			}
 			handler := initializeHandler()
 			ctx := commandcontext.Init()
-			handler.Apply(ctx, configRootLocation, yaml.StateLocation, recursive)
+			err := yaml.WithStateLock("metric apply", lockTimeout, func() error {
+				return handler.Apply(ctx, configRootLocation, yaml.StateLocation, recursive, deletePolicy)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
 		},
 	}
 
 	cmd.Flags().StringVarP(&configRootLocation, "configRootLocation", "l", "", "Root location of the config")
 	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Apply changes recursively")
+	cmd.Flags().BoolVar(&deletePolicy.AllowDelete, "allow-delete", false, "Delete the metrics missing from the configuration")
+	cmd.Flags().IntVar(&deletePolicy.MaxDelete, "max-delete", 0, "Maximum number of metrics deleted by this run, 0 for no limit")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
 
 	return cmd
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/metric/handler"
 	"github.com/motain/of-catalog/internal/modules/metric/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/githubservice"
@@ -27,6 +28,10 @@ var ProviderSet = wire.NewSet(
 	compassservice.NewCompassService,
 	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
 
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
 	// Githubservice
 	githubservice.NewGitHubClient,
 	githubservice.NewGitHubService,
@@ -34,8 +39,7 @@ var ProviderSet = wire.NewSet(
 
 	// --- metric module ---
 	// Repository
-	repository.NewRepository,
-	wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)),
+	repository.NewCatalogRepository,
 
 	// ApplyHandler
 	handler.NewApplyHandler,
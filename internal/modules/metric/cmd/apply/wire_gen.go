@@ -10,6 +10,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/metric/handler"
 	"github.com/motain/of-catalog/internal/modules/metric/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/githubservice"
@@ -23,11 +24,12 @@ func initializeHandler() *handler.ApplyHandler {
 	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
 	httpClientInterface := compassservice.NewHTTPClient(configService)
 	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
-	repositoryRepository := repository.NewRepository(compassService)
-	applyHandler := handler.NewApplyHandler(repositoryRepository)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
+	applyHandler := handler.NewApplyHandler(repositoryInterface)
 	return applyHandler
 }
 
 // wire.go:
 
-var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), repository.NewRepository, wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)), handler.NewApplyHandler)
+var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), repository.NewCatalogRepository, handler.NewApplyHandler)
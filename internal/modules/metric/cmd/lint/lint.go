@@ -0,0 +1,34 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var configRootLocation string
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate metric definitions without contacting any remote service",
+		Run: func(cmd *cobra.Command, args []string) {
+			if configRootLocation == "" {
+				fmt.Println("Error: configRootLocation is required")
+				cmd.Help()
+				return
+			}
+			handler := initializeHandler()
+			if issues := handler.Lint(configRootLocation, recursive, os.Stdout); issues > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&configRootLocation, "configRootLocation", "l", "", "Root location of the config")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Lint definitions recursively")
+
+	return cmd
+}
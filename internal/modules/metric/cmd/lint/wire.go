@@ -0,0 +1,24 @@
+//go:build wireinject
+
+package lint
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/metric/handler"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+)
+
+var ProviderSet = wire.NewSet(
+	// Fact System
+	builtin.NewBuiltinSpecs,
+	sources.NewSpecRegistry,
+	wire.Bind(new(sources.SpecRegistryInterface), new(*sources.SpecRegistry)),
+
+	// LintHandler
+	handler.NewLintHandler,
+)
+
+func initializeHandler() *handler.LintHandler {
+	panic(wire.Build(ProviderSet))
+}
@@ -0,0 +1,27 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package lint
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/metric/handler"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.LintHandler {
+	v := builtin.NewBuiltinSpecs()
+	specRegistry := sources.NewSpecRegistry(v)
+	lintHandler := handler.NewLintHandler(specRegistry)
+	return lintHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(builtin.NewBuiltinSpecs, sources.NewSpecRegistry, wire.Bind(new(sources.SpecRegistryInterface), new(*sources.SpecRegistry)), handler.NewLintHandler)
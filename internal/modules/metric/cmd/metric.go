@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"github.com/motain/of-catalog/internal/modules/metric/cmd/apply"
+	"github.com/motain/of-catalog/internal/modules/metric/cmd/lint"
+	"github.com/motain/of-catalog/internal/modules/metric/cmd/test"
 	"github.com/spf13/cobra"
 )
 
@@ -12,6 +14,8 @@ func Init() *cobra.Command {
 	}
 
 	metricCmd.AddCommand(apply.Init())
+	metricCmd.AddCommand(lint.Init())
+	metricCmd.AddCommand(test.Init())
 
 	return metricCmd
 }
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var configRootLocation string
+	var recursive bool
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run metric test cases against mocked fact sources",
+		Run: func(cmd *cobra.Command, args []string) {
+			if configRootLocation == "" {
+				fmt.Println("Error: configRootLocation is required")
+				cmd.Help()
+				return
+			}
+			ctx := commandcontext.Init()
+			handler := initializeHandler()
+			if failures := handler.Test(ctx, configRootLocation, recursive); failures > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&configRootLocation, "configRootLocation", "l", "", "Root location of the config")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Run test cases recursively")
+
+	return cmd
+}
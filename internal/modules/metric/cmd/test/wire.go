@@ -0,0 +1,27 @@
+//go:build wireinject
+
+package test
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/metric/handler"
+	"github.com/motain/of-catalog/internal/services/factsystem/aggregators"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/motain/of-catalog/internal/services/factsystem/validators"
+)
+
+var ProviderSet = wire.NewSet(
+	// Fact System
+	builtin.NewBuiltinSpecs,
+	aggregators.NewAggregator,
+	wire.Bind(new(aggregators.AggregatorInterface), new(*aggregators.Aggregator)),
+	validators.NewValidator,
+	wire.Bind(new(validators.ValidatorInterface), new(*validators.Validator)),
+
+	// TestHandler
+	handler.NewTestHandler,
+)
+
+func initializeHandler() *handler.TestHandler {
+	panic(wire.Build(ProviderSet))
+}
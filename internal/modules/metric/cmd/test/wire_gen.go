@@ -0,0 +1,29 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package test
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/metric/handler"
+	"github.com/motain/of-catalog/internal/services/factsystem/aggregators"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/motain/of-catalog/internal/services/factsystem/validators"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.TestHandler {
+	v := builtin.NewBuiltinSpecs()
+	aggregator := aggregators.NewAggregator()
+	validator := validators.NewValidator()
+	testHandler := handler.NewTestHandler(v, aggregator, validator)
+	return testHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(builtin.NewBuiltinSpecs, aggregators.NewAggregator, wire.Bind(new(aggregators.AggregatorInterface), new(*aggregators.Aggregator)), validators.NewValidator, wire.Bind(new(validators.ValidatorInterface), new(*validators.Validator)), handler.NewTestHandler)
@@ -4,6 +4,7 @@ import (
 	"reflect"
 
 	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/utils/drift"
 )
 
 // MetricDTO is a data transfer object representing a metric definition.
@@ -15,6 +16,9 @@ type MetricDTO struct {
 		Labels        map[string]string `yaml:"labels"`
 		ComponentType []string          `yaml:"componentType"`
 		Facts         []*fsdtos.Task    `yaml:"facts"`
+		PreviousNames []string          `yaml:"previousNames,omitempty"`
+		MovedFrom     string            `yaml:"movedFrom,omitempty"`
+		Lifecycle     drift.Lifecycle   `yaml:"lifecycle,omitempty"`
 	} `yaml:"metadata"`
 	Spec MetricSpec `yaml:"spec"`
 }
@@ -23,18 +27,52 @@ func GetMetricUniqueKey(m *MetricDTO) string {
 	return m.Spec.Name
 }
 
+// GetMetricPreviousNames returns the names the metric was known by before being renamed.
+func GetMetricPreviousNames(m *MetricDTO) []string {
+	return m.Metadata.PreviousNames
+}
+
+// GetMetricCompassName returns the name of the metric in Compass: the name it was moved from by state mv,
+// until the next apply renames it.
+func GetMetricCompassName(m *MetricDTO) string {
+	if m.Metadata.MovedFrom != "" {
+		return m.Metadata.MovedFrom
+	}
+
+	return m.Spec.Name
+}
+
+func GetMetricID(m *MetricDTO) string {
+	return m.Spec.ID
+}
+
+func GetMetricLifecycle(m *MetricDTO) drift.Lifecycle {
+	return m.Metadata.Lifecycle
+}
+
 func FromStateToConfig(state *MetricDTO, conf *MetricDTO) {
 	conf.Spec.ID = state.Spec.ID
 }
 
 func IsEqualMetric(m1, m2 *MetricDTO) bool {
-	return m1.Spec.Name == m2.Spec.Name &&
-		m1.Spec.Description == m2.Spec.Description &&
-		reflect.DeepEqual(m1.Spec.Format, m2.Spec.Format) &&
-		m1.Metadata.Name == m2.Metadata.Name &&
-		isEqualLabels(m1.Metadata.Labels, m2.Metadata.Labels) &&
-		isEqualComponentTypes(m1.Metadata.ComponentType, m2.Metadata.ComponentType) &&
-		isEqualFacts(m1.Metadata.Facts, m2.Metadata.Facts)
+	return len(DiffMetric(m1, m2)) == 0
+}
+
+// DiffMetric returns the changes between the state m1 and the configuration m2 of a metric.
+func DiffMetric(m1, m2 *MetricDTO) []drift.Change {
+	var changes []drift.Change
+	changes = append(changes, drift.Diff("spec.name", GetMetricCompassName(m1), m2.Spec.Name)...)
+	changes = append(changes, drift.Diff("spec.description", m1.Spec.Description, m2.Spec.Description)...)
+	changes = append(changes, drift.DiffUnlessEqual("spec.format", m1.Spec.Format, m2.Spec.Format, reflect.DeepEqual(m1.Spec.Format, m2.Spec.Format))...)
+	changes = append(changes, drift.Diff("metadata.name", m1.Metadata.Name, m2.Metadata.Name)...)
+	changes = append(changes, drift.DiffUnlessEqual("metadata.labels", m1.Metadata.Labels, m2.Metadata.Labels, isEqualLabels(m1.Metadata.Labels, m2.Metadata.Labels))...)
+	changes = append(changes, drift.DiffUnlessEqual(
+		"metadata.componentType", m1.Metadata.ComponentType, m2.Metadata.ComponentType,
+		isEqualComponentTypes(m1.Metadata.ComponentType, m2.Metadata.ComponentType),
+	)...)
+	changes = append(changes, drift.DiffUnlessEqual("metadata.facts", m1.Metadata.Facts, m2.Metadata.Facts, isEqualFacts(m1.Metadata.Facts, m2.Metadata.Facts))...)
+
+	return changes
 }
 
 func isEqualLabels(l1, l2 map[string]string) bool {
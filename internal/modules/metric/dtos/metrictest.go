@@ -0,0 +1,31 @@
+package dtos
+
+import (
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/fakesource"
+)
+
+// MetricTestDTO holds the test cases of a metric, written next to its definition in a metrictest-*.yaml file.
+type MetricTestDTO struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		// Name is the name of the metric under test
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Cases []*MetricTestCase `yaml:"cases"`
+	} `yaml:"spec"`
+}
+
+// MetricTestCase computes the metric for a component, with the data of the extract facts mocked by fact id.
+type MetricTestCase struct {
+	Name      string                      `yaml:"name"`
+	Component componentdtos.ComponentDTO  `yaml:"component"`
+	Mocks     map[string]*fakesource.Mock `yaml:"mocks"`
+	Expected  float64                     `yaml:"expected"`
+}
+
+func GetMetricTestUniqueKey(m *MetricTestDTO) string {
+	return m.Metadata.Name
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/motain/of-catalog/internal/modules/metric/dtos"
@@ -23,7 +22,15 @@ func NewApplyHandler(
 	return &ApplyHandler{repository: repository}
 }
 
-func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, stateRootLocation string, recursive bool) {
+// Apply brings Compass in line with the metric definitions found under configRootLocation. It returns
+// an error, before making any change, when the deletions are not allowed by deletePolicy.
+func (h *ApplyHandler) Apply(
+	ctx context.Context,
+	configRootLocation string,
+	stateRootLocation string,
+	recursive bool,
+	deletePolicy drift.DeletePolicy,
+) error {
 	fmt.Println("DEBUG: Checking .state/metric/ directory:")
 	entries, err := os.ReadDir(".state/metric")
 	if err != nil {
@@ -43,7 +50,7 @@ func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, sta
 	stateMetrics, errState := yaml.Parse(stateInput, dtos.GetMetricUniqueKey)
 	if errState != nil {
 		fmt.Printf("DEBUG: Error reading split state: %v\n", errState)
-		log.Fatalf("error: %v", errState)
+		return errState
 	}
 
 	parseInput := yaml.ParseInput{
@@ -52,7 +59,7 @@ func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, sta
 	}
 	configMetrics, errConfig := yaml.Parse(parseInput, dtos.GetMetricUniqueKey)
 	if errConfig != nil {
-		log.Fatalf("error: %v", errConfig)
+		return errConfig
 	}
 
 	fmt.Printf("DEBUG: Config metrics count: %d\n", len(configMetrics))
@@ -73,11 +80,19 @@ func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, sta
 		}
 	}
 
-	created, updated, deleted, unchanged := drift.Detect(
+	renamed, errRename := drift.Rename(stateMetrics, configMetrics, dtos.GetMetricPreviousNames, dtos.GetMetricID)
+	if errRename != nil {
+		return errRename
+	}
+	for previousName, name := range renamed {
+		fmt.Printf("Renaming metric %s to %s\n", previousName, name)
+	}
+
+	created, updated, deleted, unchanged, changes := drift.DetectChanges(
 		stateMetrics,
 		configMetrics,
 		dtos.FromStateToConfig,
-		dtos.IsEqualMetric,
+		dtos.DiffMetric,
 	)
 
 	// DEBUG: Print drift detection results
@@ -91,16 +106,22 @@ func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, sta
 		fmt.Printf("  - Created: %s\n", name)
 	}
 
+	if errDelete := drift.CheckDeletions("metric", deleted, deletePolicy, dtos.GetMetricLifecycle); errDelete != nil {
+		return errDelete
+	}
+
 	var result []*dtos.MetricDTO
 	h.handleDeleted(ctx, deleted)
 	result = h.handleUnchanged(ctx, result, unchanged)
 	result = h.handleCreated(ctx, result, created)
-	result = h.handleUpdated(ctx, result, updated)
+	result = h.handleUpdated(ctx, result, updated, changes)
 
 	err = yaml.WriteMetricStates(result, dtos.GetMetricUniqueKey)
 	if err != nil {
-		log.Fatalf("error writing metrics to file: %v", err)
+		return fmt.Errorf("error writing metrics to file: %w", err)
 	}
+
+	return nil
 }
 
 func (h *ApplyHandler) handleDeleted(ctx context.Context, metrics map[string]*dtos.MetricDTO) {
@@ -138,8 +159,24 @@ func (h *ApplyHandler) handleCreated(ctx context.Context, result []*dtos.MetricD
 	return result
 }
 
-func (h *ApplyHandler) handleUpdated(ctx context.Context, result []*dtos.MetricDTO, metrics map[string]*dtos.MetricDTO) []*dtos.MetricDTO {
-	for _, metricDTO := range metrics {
+// handleUpdated updates the metrics in Compass, unless only their metadata changed, which is not sent to Compass.
+func (h *ApplyHandler) handleUpdated(
+	ctx context.Context,
+	result []*dtos.MetricDTO,
+	metrics map[string]*dtos.MetricDTO,
+	changes map[string][]drift.Change,
+) []*dtos.MetricDTO {
+	for name, metricDTO := range metrics {
+		fmt.Printf("Updating metric %s:\n", name)
+		for _, change := range changes[name] {
+			fmt.Printf("    %s\n", change)
+		}
+
+		if !drift.HasChange(changes[name], "spec") {
+			result = append(result, metricDTO)
+			continue
+		}
+
 		metric := metricDTOToResource(metricDTO)
 		err := h.repository.Update(ctx, metric)
 		if err != nil {
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/motain/of-catalog/internal/modules/metric/dtos"
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+type LintHandler struct {
+	specs sources.SpecRegistryInterface
+}
+
+func NewLintHandler(
+	specs sources.SpecRegistryInterface,
+) *LintHandler {
+	return &LintHandler{specs: specs}
+}
+
+// Lint validates the metric definitions found in configRootLocation and returns
+// the number of issues found, writing each of them to output.
+func (h *LintHandler) Lint(configRootLocation string, recursive bool, output io.Writer) int {
+	parseInput := yaml.ParseInput{
+		RootLocation: configRootLocation,
+		Recursive:    recursive,
+	}
+	configMetrics, errConfig := yaml.Parse(parseInput, dtos.GetMetricUniqueKey)
+	if errConfig != nil {
+		log.Fatalf("error: %v", errConfig)
+	}
+
+	names := make([]string, 0, len(configMetrics))
+	for name := range configMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	issues := 0
+	for _, name := range names {
+		for _, err := range h.lintMetric(configMetrics[name]) {
+			fmt.Fprintf(output, "%s: %v\n", name, err)
+			issues++
+		}
+	}
+
+	fmt.Fprintf(output, "%d metric(s) checked, %d issue(s) found\n", len(names), issues)
+
+	return issues
+}
+
+func (h *LintHandler) lintMetric(metric *dtos.MetricDTO) []error {
+	var errs []error
+
+	ids := make(map[string]bool, len(metric.Metadata.Facts))
+	for _, fact := range metric.Metadata.Facts {
+		if fact.ID == "" {
+			errs = append(errs, fmt.Errorf("fact %q has no id", fact.Name))
+			continue
+		}
+		if ids[fact.ID] {
+			errs = append(errs, fmt.Errorf("fact id %q is not unique", fact.ID))
+		}
+		ids[fact.ID] = true
+	}
+
+	for _, fact := range metric.Metadata.Facts {
+		for _, dependency := range fact.DependsOn {
+			if !ids[dependency] {
+				errs = append(errs, fmt.Errorf("fact %s depends on unknown fact %q", fact.ID, dependency))
+			}
+		}
+
+		switch fsdtos.TaskType(fact.Type) {
+		case fsdtos.ExtractType:
+			for _, err := range sources.Validate(h.specs, fact) {
+				errs = append(errs, fmt.Errorf("fact %s: %v", fact.ID, err))
+			}
+		case fsdtos.ValidateType, fsdtos.AggregateType:
+		default:
+			errs = append(errs, fmt.Errorf("fact %s has unknown type %q", fact.ID, fact.Type))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/modules/metric/handler"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const metricHeader = `apiVersion: of-catalog/v1alpha1
+kind: Metric
+metadata:
+  name: lint-check
+  componentType:
+    - service
+  facts:
+`
+
+const metricSpec = `spec:
+  name: lint-check
+  description: "Checks the linter"
+  format:
+    unit: "Lint Check"
+`
+
+func TestLintHandler_Lint(t *testing.T) {
+	tests := []struct {
+		name           string
+		facts          string
+		expectedIssues []string
+	}{
+		{
+			name: "clean metric",
+			facts: `    - id: read-readme
+      type: extract
+      source: github
+      repo: bookmarks
+      filePath: README.md
+      rule: notempty
+    - id: has-readme
+      type: aggregate
+      method: and
+      dependsOn: ["read-readme"]
+`,
+		},
+		{
+			name: "unknown source",
+			facts: `    - id: read-readme
+      type: extract
+      source: gitlab
+      repo: bookmarks
+`,
+			expectedIssues: []string{
+				`lint-check: fact read-readme: unknown source "gitlab", expected one of ` + strings.Join(specNames(), ", "),
+			},
+		},
+		{
+			name: "missing required fields",
+			facts: `    - id: trivy-in-ci
+      type: extract
+      source: github
+      rule: search
+    - id: error-rate
+      type: extract
+      source: prometheus
+`,
+			expectedIssues: []string{
+				`lint-check: fact trivy-in-ci: source github requires field "repo"`,
+				`lint-check: fact trivy-in-ci: source github requires field "searchString"`,
+				`lint-check: fact error-rate: source prometheus requires field "prometheusQuery"`,
+			},
+		},
+		{
+			name: "invalid fact graph",
+			facts: `    - id: read-readme
+      type: extract
+      source: github
+      repo: bookmarks
+      filePath: README.md
+    - id: read-readme
+      type: check
+      dependsOn: ["read-license"]
+`,
+			expectedIssues: []string{
+				`lint-check: fact id "read-readme" is not unique`,
+				`lint-check: fact read-readme depends on unknown fact "read-license"`,
+				`lint-check: fact read-readme has unknown type "check"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configRoot := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(configRoot, "metric-lint.yaml"), []byte(metricHeader+tt.facts+metricSpec), 0644))
+
+			var output strings.Builder
+			issues := handler.NewLintHandler(sources.NewSpecRegistry(builtin.NewBuiltinSpecs())).Lint(configRoot, false, &output)
+
+			expectedOutput := append(tt.expectedIssues, fmt.Sprintf("1 metric(s) checked, %d issue(s) found", len(tt.expectedIssues)))
+			assert.Equal(t, len(tt.expectedIssues), issues)
+			assert.Equal(t, strings.Join(expectedOutput, "\n")+"\n", output.String())
+		})
+	}
+}
+
+func specNames() []string {
+	return sources.NewSpecRegistry(builtin.NewBuiltinSpecs()).Names()
+}
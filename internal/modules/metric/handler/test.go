@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+
+	componentutils "github.com/motain/of-catalog/internal/modules/component/utils"
+	"github.com/motain/of-catalog/internal/modules/metric/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/aggregators"
+	fsdtos "github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/extractors"
+	"github.com/motain/of-catalog/internal/services/factsystem/processor"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/fakesource"
+	"github.com/motain/of-catalog/internal/services/factsystem/validators"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+// tolerance absorbs floating point errors when comparing metric values
+const tolerance = 1e-9
+
+type TestHandler struct {
+	specs      []sources.Spec
+	aggregator aggregators.AggregatorInterface
+	validator  validators.ValidatorInterface
+}
+
+func NewTestHandler(
+	specs []sources.Spec,
+	aggregator aggregators.AggregatorInterface,
+	validator validators.ValidatorInterface,
+) *TestHandler {
+	return &TestHandler{specs: specs, aggregator: aggregator, validator: validator}
+}
+
+// Test runs the metric test cases found in configRootLocation against the metric definitions
+// found in the same location and returns the number of failed cases, printing the result of each case.
+func (h *TestHandler) Test(ctx context.Context, configRootLocation string, recursive bool) int {
+	parseInput := yaml.ParseInput{
+		RootLocation: configRootLocation,
+		Recursive:    recursive,
+	}
+	configMetrics, errConfig := yaml.Parse(parseInput, dtos.GetMetricUniqueKey)
+	if errConfig != nil {
+		log.Fatalf("error: %v", errConfig)
+	}
+	configTests, errTests := yaml.Parse(parseInput, dtos.GetMetricTestUniqueKey)
+	if errTests != nil {
+		log.Fatalf("error: %v", errTests)
+	}
+
+	names := make([]string, 0, len(configTests))
+	for name := range configTests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	cases, failures := 0, 0
+	for _, name := range names {
+		metric, exists := configMetrics[name]
+		if !exists {
+			fmt.Printf("FAIL %s: metric not found\n", name)
+			failures++
+			continue
+		}
+
+		for _, testCase := range configTests[name].Spec.Cases {
+			cases++
+			value, err := h.runCase(ctx, metric, testCase)
+			switch {
+			case err != nil:
+				fmt.Printf("FAIL %s/%s: %v\n", name, testCase.Name, err)
+				failures++
+			case math.Abs(value-testCase.Expected) > tolerance:
+				fmt.Printf("FAIL %s/%s: expected %v, got %v\n", name, testCase.Name, testCase.Expected, value)
+				failures++
+			default:
+				fmt.Printf("PASS %s/%s\n", name, testCase.Name)
+			}
+		}
+	}
+
+	fmt.Printf("%d case(s) run, %d failure(s)\n", cases, failures)
+
+	return failures
+}
+
+// runCase computes the metric for the component of testCase as compute does once the metric is bound,
+// its extract facts reading the mocks of testCase.
+func (h *TestHandler) runCase(ctx context.Context, metric *dtos.MetricDTO, testCase *dtos.MetricTestCase) (float64, error) {
+	factIDs := make(map[string]bool, len(metric.Metadata.Facts))
+	for _, fact := range metric.Metadata.Facts {
+		if fact != nil && fsdtos.TaskType(fact.Type) == fsdtos.ExtractType {
+			factIDs[fact.ID] = true
+		}
+	}
+	for id := range testCase.Mocks {
+		if !factIDs[id] {
+			return 0, fmt.Errorf("mock for unknown extract fact %q", id)
+		}
+	}
+
+	registry := sources.NewRegistry(fakesource.NewFakeSources(h.specs, testCase.Mocks))
+	factProcessor := processor.NewProcessor(h.aggregator, h.validator, extractors.NewExtractor(registry))
+
+	return factProcessor.Process(ctx, componentutils.PrepareMetricFacts(metric.Metadata.Facts, testCase.Component))
+}
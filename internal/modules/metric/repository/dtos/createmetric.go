@@ -35,6 +35,10 @@ func (dto *CreateMetricInput) GetQuery() string {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -55,8 +59,12 @@ func (dto *CreateMetricInput) SetVariables() map[string]interface{} {
  **************/
 
 type Metric struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Format      struct {
+		Suffix string `json:"suffix"`
+	} `json:"format"`
 }
 
 type CreateMetricOutput struct {
@@ -80,3 +88,7 @@ func (dto *CreateMetricOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateMetricOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateMetric.Errors
+}
@@ -30,6 +30,10 @@ func TestCreateMetricInput_GetQuery(t *testing.T) {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
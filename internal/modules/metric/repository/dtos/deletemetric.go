@@ -22,6 +22,10 @@ func (dto *DeleteMetricInput) GetQuery() string {
 					scorecardId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -59,3 +63,7 @@ func (dto *DeleteMetricOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *DeleteMetricOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteMetric.Errors
+}
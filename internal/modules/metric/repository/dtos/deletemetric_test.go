@@ -17,6 +17,10 @@ func TestDeleteMetricInput_GetQuery(t *testing.T) {
 					scorecardId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -12,17 +12,29 @@ type SearchMetricsInput struct {
 	compassdtos.InputDTO
 	CompassCloudID string
 	Metric         resources.Metric
+	// After is the cursor of the page to read, empty for the first page
+	After string
 }
 
 func (dto *SearchMetricsInput) GetQuery() string {
 	return `
-		query searchMetricDefinition($cloudId: ID!) {
+		query searchMetricDefinition($cloudId: ID!, $after: String) {
 			compass {
-				metricDefinitions(query: {cloudId: $cloudId, first: 100}) {
+				metricDefinitions(query: {cloudId: $cloudId, first: 100, after: $after}) {
 					... on CompassMetricDefinitionsConnection {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
 						nodes{
 							id
 							name
+							description
+							format {
+								... on CompassMetricDefinitionFormatSuffix {
+									suffix
+								}
+							}
 						}
 					}
 				}
@@ -34,6 +46,7 @@ func (dto *SearchMetricsInput) SetVariables() map[string]interface{} {
 	return map[string]interface{}{
 		"cloudId": dto.CompassCloudID,
 		"name":    dto.Metric.Name,
+		"after":   compassdtos.AfterVariable(dto.After),
 	}
 }
 
@@ -44,7 +57,8 @@ func (dto *SearchMetricsInput) SetVariables() map[string]interface{} {
 type SearchMetricsOutput struct {
 	Compass struct {
 		Definitions struct {
-			Nodes []Metric `json:"nodes"`
+			PageInfo compassdtos.PageInfo `json:"pageInfo"`
+			Nodes    []Metric             `json:"nodes"`
 		} `json:"metricDefinitions"`
 	} `json:"compass"`
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/motain/of-catalog/internal/modules/metric/repository/dtos"
 	"github.com/motain/of-catalog/internal/modules/metric/resources"
+	compassdtos "github.com/motain/of-catalog/internal/services/compassservice/dtos"
 )
 
 func TestSearchMetricsInput_GetQuery(t *testing.T) {
@@ -18,13 +19,23 @@ func TestSearchMetricsInput_GetQuery(t *testing.T) {
 			name: "valid query",
 			dto:  dtos.SearchMetricsInput{},
 			want: `
-		query searchMetricDefinition($cloudId: ID!) {
+		query searchMetricDefinition($cloudId: ID!, $after: String) {
 			compass {
-				metricDefinitions(query: {cloudId: $cloudId, first: 100}) {
+				metricDefinitions(query: {cloudId: $cloudId, first: 100, after: $after}) {
 					... on CompassMetricDefinitionsConnection {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
 						nodes{
 							id
 							name
+							description
+							format {
+								... on CompassMetricDefinitionFormatSuffix {
+									suffix
+								}
+							}
 						}
 					}
 				}
@@ -57,6 +68,20 @@ func TestSearchMetricsInput_SetVariables(t *testing.T) {
 			want: map[string]interface{}{
 				"cloudId": "cloud123",
 				"name":    "metricName",
+				"after":   nil,
+			},
+		},
+		{
+			name: "next page",
+			dto: dtos.SearchMetricsInput{
+				CompassCloudID: "cloud123",
+				Metric:         resources.Metric{Name: "metricName"},
+				After:          "cursor-1",
+			},
+			want: map[string]interface{}{
+				"cloudId": "cloud123",
+				"name":    "metricName",
+				"after":   "cursor-1",
 			},
 		},
 	}
@@ -81,11 +106,13 @@ func TestSearchMetricsOutput_IsSuccessful(t *testing.T) {
 			dto: dtos.SearchMetricsOutput{
 				Compass: struct {
 					Definitions struct {
-						Nodes []dtos.Metric `json:"nodes"`
+						PageInfo compassdtos.PageInfo `json:"pageInfo"`
+						Nodes    []dtos.Metric        `json:"nodes"`
 					} `json:"metricDefinitions"`
 				}{
 					Definitions: struct {
-						Nodes []dtos.Metric `json:"nodes"`
+						PageInfo compassdtos.PageInfo `json:"pageInfo"`
+						Nodes    []dtos.Metric        `json:"nodes"`
 					}{
 						Nodes: []dtos.Metric{{}},
 					},
@@ -98,11 +125,13 @@ func TestSearchMetricsOutput_IsSuccessful(t *testing.T) {
 			dto: dtos.SearchMetricsOutput{
 				Compass: struct {
 					Definitions struct {
-						Nodes []dtos.Metric `json:"nodes"`
+						PageInfo compassdtos.PageInfo `json:"pageInfo"`
+						Nodes    []dtos.Metric        `json:"nodes"`
 					} `json:"metricDefinitions"`
 				}{
 					Definitions: struct {
-						Nodes []dtos.Metric `json:"nodes"`
+						PageInfo compassdtos.PageInfo `json:"pageInfo"`
+						Nodes    []dtos.Metric        `json:"nodes"`
 					}{
 						Nodes: nil,
 					},
@@ -33,6 +33,10 @@ func (dto *UpdateMetricInput) GetQuery() string {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -73,3 +77,7 @@ func (dto *UpdateMetricOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *UpdateMetricOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.UpdateMetric.Errors
+}
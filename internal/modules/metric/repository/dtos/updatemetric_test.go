@@ -28,6 +28,10 @@ func TestUpdateMetricInput_GetQuery(t *testing.T) {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
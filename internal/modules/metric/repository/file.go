@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/motain/of-catalog/internal/modules/metric/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+)
+
+// FileRepository keeps metric definitions in a local file catalog.
+type FileRepository struct {
+	catalog catalogservice.FileCatalogInterface
+}
+
+func NewFileRepository(catalog catalogservice.FileCatalogInterface) *FileRepository {
+	return &FileRepository{catalog: catalog}
+}
+
+func (r *FileRepository) Create(ctx context.Context, metric resources.Metric) (string, error) {
+	// As in Compass, creating an existing metric updates it
+	if existing, searchErr := r.Search(ctx, resources.Metric{Name: metric.Name}); searchErr == nil {
+		metric.ID = existing.ID
+		return metric.ID, r.Update(ctx, metric)
+	}
+
+	id, idErr := r.catalog.NewID(catalogservice.MetricKind)
+	if idErr != nil {
+		return "", fmt.Errorf("Create error for %s: %w", metric, idErr)
+	}
+
+	metric.ID = id
+	if putErr := r.catalog.Put(catalogservice.MetricKind, metric.ID, metric); putErr != nil {
+		return "", fmt.Errorf("Create error for %s: %w", metric, putErr)
+	}
+
+	return metric.ID, nil
+}
+
+func (r *FileRepository) Update(ctx context.Context, metric resources.Metric) error {
+	var stored resources.Metric
+	if getErr := r.catalog.Get(catalogservice.MetricKind, metric.ID, &stored); getErr != nil {
+		return fmt.Errorf("Update error for %s: %w", metric, getErr)
+	}
+
+	if putErr := r.catalog.Put(catalogservice.MetricKind, metric.ID, metric); putErr != nil {
+		return fmt.Errorf("Update error for %s: %w", metric, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	if deleteErr := r.catalog.Delete(catalogservice.MetricKind, id); deleteErr != nil {
+		return fmt.Errorf("Delete error for %s: %w", id, deleteErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) Search(ctx context.Context, metric resources.Metric) (*resources.Metric, error) {
+	ids, listErr := r.catalog.List(catalogservice.MetricKind)
+	if listErr != nil {
+		return nil, fmt.Errorf("Search error for %s: %w", metric.Name, listErr)
+	}
+
+	for _, id := range ids {
+		var stored resources.Metric
+		if getErr := r.catalog.Get(catalogservice.MetricKind, id, &stored); getErr != nil {
+			return nil, fmt.Errorf("Search error for %s: %w", metric.Name, getErr)
+		}
+
+		if (metric.ID != "" && stored.ID == metric.ID) || (metric.ID == "" && stored.Name == metric.Name) {
+			return &stored, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Search error for %s: %w", metric.Name, &compassservice.Error{
+		Operation: "search metric",
+		Class:     compassservice.ErrorClassNotFound,
+		Err:       errors.New("metric not found"),
+	})
+}
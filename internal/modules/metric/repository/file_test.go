@@ -0,0 +1,44 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/modules/metric/repository"
+	"github.com/motain/of-catalog/internal/modules/metric/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileRepository(t *testing.T) {
+	repo := repository.NewFileRepository(catalogservice.NewFileCatalogAt(t.TempDir()))
+	ctx := context.Background()
+
+	id, createErr := repo.Create(ctx, resources.Metric{Name: "coverage", Description: "Test coverage"})
+	require.NoError(t, createErr)
+	assert.NotEmpty(t, id)
+
+	againID, againErr := repo.Create(ctx, resources.Metric{Name: "coverage", Description: "Unit test coverage"})
+	require.NoError(t, againErr)
+	assert.Equal(t, id, againID)
+
+	found, searchErr := repo.Search(ctx, resources.Metric{Name: "coverage"})
+	require.NoError(t, searchErr)
+	assert.Equal(t, "Unit test coverage", found.Description)
+
+	require.NoError(t, repo.Update(ctx, resources.Metric{ID: id, Name: "test-coverage"}))
+	found, searchErr = repo.Search(ctx, resources.Metric{ID: id, Name: "coverage"})
+	require.NoError(t, searchErr)
+	assert.Equal(t, "test-coverage", found.Name)
+
+	assert.Error(t, repo.Update(ctx, resources.Metric{ID: "metric-missing", Name: "missing"}))
+
+	require.NoError(t, repo.Delete(ctx, id))
+	_, searchErr = repo.Search(ctx, resources.Metric{Name: "test-coverage"})
+	assert.ErrorContains(t, searchErr, "metric not found")
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, searchErr, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassNotFound, compassErr.Class)
+}
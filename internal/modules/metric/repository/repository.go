@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/motain/of-catalog/internal/modules/metric/repository/dtos"
@@ -60,7 +61,7 @@ func (r *Repository) Create(ctx context.Context, metric resources.Metric) (strin
 
 	runErr := r.compass.RunWithDTOs(ctx, input, output)
 	if runErr != nil {
-		return "", fmt.Errorf("Create error for %s: %s", metric, runErr)
+		return "", fmt.Errorf("Create error for %s: %w", metric, runErr)
 	}
 
 	return output.Compass.CreateMetric.Definition.ID, nil
@@ -70,7 +71,7 @@ func (r *Repository) Update(ctx context.Context, metric resources.Metric) error
 	input := &dtos.UpdateMetricInput{CompassCloudID: r.compass.GetCompassCloudId(), Metric: metric}
 	output := &dtos.UpdateMetricOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("Update error for %s: %s", metric, runErr)
+		return fmt.Errorf("Update error for %s: %w", metric, runErr)
 	}
 	return nil
 }
@@ -79,24 +80,54 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 	input := &dtos.DeleteMetricInput{MetricID: id}
 	output := &dtos.DeleteMetricOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("Delete error for %s: %s", id, runErr)
+		return fmt.Errorf("Delete error for %s: %w", id, runErr)
 	}
 	return nil
 }
 
+// Search reads the metric definitions page by page until one matches metric.
 func (r *Repository) Search(ctx context.Context, metric resources.Metric) (*resources.Metric, error) {
-	input := &dtos.SearchMetricsInput{Metric: metric}
-	output := &dtos.SearchMetricsOutput{}
-	runErr := r.compass.RunWithDTOs(ctx, input, output)
-	if runErr != nil {
-		return nil, fmt.Errorf("Search error for %s: %s", metric.Name, runErr)
-	}
+	input := &dtos.SearchMetricsInput{CompassCloudID: r.compass.GetCompassCloudId(), Metric: metric}
+	for {
+		output := &dtos.SearchMetricsOutput{}
+		runErr := r.compass.RunWithDTOs(ctx, input, output)
+		if runErr != nil {
+			return nil, fmt.Errorf("Search error for %s: %w", metric.Name, runErr)
+		}
+
+		for _, node := range output.Compass.Definitions.Nodes {
+			if !matchesMetric(node, metric) {
+				continue
+			}
+
+			return &resources.Metric{
+				ID:          node.ID,
+				Name:        node.Name,
+				Description: node.Description,
+				Format:      resources.MetricFormat{Unit: node.Format.Suffix},
+			}, nil
+		}
 
-	for _, node := range output.Compass.Definitions.Nodes {
-		if node.Name == metric.Name {
-			return &resources.Metric{ID: node.ID}, nil
+		pageInfo := output.Compass.Definitions.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			break
 		}
+		input.After = pageInfo.EndCursor
+	}
+
+	return nil, fmt.Errorf("Search error for %s: %w", metric.Name, &compassservice.Error{
+		Operation: "query searchMetricDefinition",
+		Class:     compassservice.ErrorClassNotFound,
+		Err:       errors.New("metric not found"),
+	})
+}
+
+// matchesMetric matches a metric definition by ID when it is known, by name otherwise,
+// so that a definition renamed in Compass is still found.
+func matchesMetric(node dtos.Metric, metric resources.Metric) bool {
+	if metric.ID != "" {
+		return node.ID == metric.ID
 	}
 
-	return nil, fmt.Errorf("Search error for %s: %s", metric.Name, "metric not found")
+	return node.Name == metric.Name
 }
@@ -268,11 +268,13 @@ func TestRepository_Search(t *testing.T) {
 				Name: "test-metric",
 			},
 			setupMocks: func() {
+				mockCompass.EXPECT().GetCompassCloudId().Return("cloud-123")
 				mockCompass.EXPECT().RunWithDTOs(
 					gomock.Any(),
 					gomock.Any(),
 					gomock.Any(),
 				).DoAndReturn(func(_ context.Context, input *dtos.SearchMetricsInput, output *dtos.SearchMetricsOutput) error {
+					assert.Equal(t, "cloud-123", input.CompassCloudID)
 					output.Compass.Definitions.Nodes = []dtos.Metric{
 						{
 							ID:   "metric-123",
@@ -283,7 +285,44 @@ func TestRepository_Search(t *testing.T) {
 				})
 			},
 			expectedMetric: &resources.Metric{
-				ID: "metric-123",
+				ID:   "metric-123",
+				Name: "test-metric",
+			},
+			expectedError: false,
+		},
+		{
+			name: "successful search by id",
+			metric: resources.Metric{
+				ID:   "metric-123",
+				Name: "renamed-metric",
+			},
+			setupMocks: func() {
+				mockCompass.EXPECT().GetCompassCloudId().Return("cloud-123")
+				mockCompass.EXPECT().RunWithDTOs(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).DoAndReturn(func(_ context.Context, input *dtos.SearchMetricsInput, output *dtos.SearchMetricsOutput) error {
+					output.Compass.Definitions.Nodes = []dtos.Metric{
+						{
+							ID:   "metric-456",
+							Name: "renamed-metric",
+						},
+						{
+							ID:          "metric-123",
+							Name:        "test-metric",
+							Description: "remote description",
+						},
+					}
+					output.Compass.Definitions.Nodes[1].Format.Suffix = "%"
+					return nil
+				})
+			},
+			expectedMetric: &resources.Metric{
+				ID:          "metric-123",
+				Name:        "test-metric",
+				Description: "remote description",
+				Format:      resources.MetricFormat{Unit: "%"},
 			},
 			expectedError: false,
 		},
@@ -293,6 +332,7 @@ func TestRepository_Search(t *testing.T) {
 				Name: "non-existent-metric",
 			},
 			setupMocks: func() {
+				mockCompass.EXPECT().GetCompassCloudId().Return("cloud-123")
 				mockCompass.EXPECT().RunWithDTOs(
 					gomock.Any(),
 					gomock.Any(),
@@ -317,6 +357,7 @@ func TestRepository_Search(t *testing.T) {
 				Name: "test-metric",
 			},
 			setupMocks: func() {
+				mockCompass.EXPECT().GetCompassCloudId().Return("cloud-123")
 				mockCompass.EXPECT().RunWithDTOs(
 					gomock.Any(),
 					gomock.Any(),
@@ -333,6 +374,7 @@ func TestRepository_Search(t *testing.T) {
 				Name: "test-metric",
 			},
 			setupMocks: func() {
+				mockCompass.EXPECT().GetCompassCloudId().Return("cloud-123")
 				mockCompass.EXPECT().RunWithDTOs(
 					gomock.Any(),
 					gomock.Any(),
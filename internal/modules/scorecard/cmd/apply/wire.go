@@ -6,6 +6,7 @@ import (
 	"github.com/google/wire"
 	"github.com/motain/of-catalog/internal/modules/scorecard/handler"
 	"github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
 	"github.com/motain/of-catalog/internal/services/compassservice"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/keyringservice"
@@ -26,10 +27,13 @@ var ProviderSet = wire.NewSet(
 	compassservice.NewCompassService,
 	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
 
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
 	// --- component module ---
 	// Repository
-	repository.NewRepository,
-	wire.Bind(new(repository.RepositoryInterface), new(*repository.Repository)),
+	repository.NewCatalogRepository,
 
 	// ApplyHandler
 	handler.NewApplyHandler,
@@ -1,5 +1,11 @@
 package dtos
 
+import (
+	"fmt"
+
+	"github.com/motain/of-catalog/internal/utils/drift"
+)
+
 type ScorecardDTO struct {
 	APIVersion string   `yaml:"apiVersion"`
 	Kind       string   `yaml:"kind"`
@@ -11,6 +17,32 @@ func GetScorecardUniqueKey(c *ScorecardDTO) string {
 	return c.Spec.Name
 }
 
+// GetScorecardPreviousNames returns the names the scorecard was known by before being renamed.
+func GetScorecardPreviousNames(s *ScorecardDTO) []string {
+	return s.Metadata.PreviousNames
+}
+
+// GetScorecardCompassName returns the name of the scorecard in Compass: the name it was moved from by state mv,
+// until the next apply renames it.
+func GetScorecardCompassName(s *ScorecardDTO) string {
+	if s.Metadata.MovedFrom != "" {
+		return s.Metadata.MovedFrom
+	}
+
+	return s.Spec.Name
+}
+
+func GetScorecardID(s *ScorecardDTO) string {
+	if s.Spec.ID == nil {
+		return ""
+	}
+	return *s.Spec.ID
+}
+
+func GetScorecardLifecycle(s *ScorecardDTO) drift.Lifecycle {
+	return s.Metadata.Lifecycle
+}
+
 func FromStateToConfig(state *ScorecardDTO, conf *ScorecardDTO) {
 	conf.Spec.ID = state.Spec.ID
 }
@@ -50,18 +82,62 @@ func IsCriterionEqual(s1, s2 *Criterion) bool {
 }
 
 func IsScoreCardEqual(s1, s2 *ScorecardDTO) bool {
-	return s1.Spec.Name == s2.Spec.Name &&
-		s1.Spec.Description == s2.Spec.Description &&
-		s1.Spec.OwnerID == s2.Spec.OwnerID &&
-		s1.Spec.State == s2.Spec.State &&
-		IsComponentTypeIDsEqual(s1.Spec.ComponentTypeIDs, s2.Spec.ComponentTypeIDs) &&
-		s1.Spec.Importance == s2.Spec.Importance &&
-		s1.Spec.ScoringStrategyType == s2.Spec.ScoringStrategyType &&
-		IsCriteriaEqual(s1.Spec.Criteria, s2.Spec.Criteria)
+	return len(DiffScorecard(s1, s2)) == 0
+}
+
+// DiffScorecard returns the changes between the state s1 and the configuration s2 of a scorecard.
+func DiffScorecard(s1, s2 *ScorecardDTO) []drift.Change {
+	var changes []drift.Change
+	changes = append(changes, drift.Diff("spec.name", GetScorecardCompassName(s1), s2.Spec.Name)...)
+	changes = append(changes, drift.Diff("spec.description", s1.Spec.Description, s2.Spec.Description)...)
+	changes = append(changes, drift.Diff("spec.ownerId", s1.Spec.OwnerID, s2.Spec.OwnerID)...)
+	changes = append(changes, drift.Diff("spec.state", s1.Spec.State, s2.Spec.State)...)
+	changes = append(changes, drift.DiffUnlessEqual(
+		"spec.componentTypeIds", s1.Spec.ComponentTypeIDs, s2.Spec.ComponentTypeIDs,
+		IsComponentTypeIDsEqual(s1.Spec.ComponentTypeIDs, s2.Spec.ComponentTypeIDs),
+	)...)
+	changes = append(changes, drift.Diff("spec.importance", s1.Spec.Importance, s2.Spec.Importance)...)
+	changes = append(changes, drift.Diff("spec.scoringStrategyType", s1.Spec.ScoringStrategyType, s2.Spec.ScoringStrategyType)...)
+	changes = append(changes, diffCriteria(s1.Spec.Criteria, s2.Spec.Criteria)...)
+
+	return changes
+}
+
+// diffCriteria compares the criteria position by position, ignoring their Compass IDs.
+func diffCriteria(c1, c2 []*Criterion) []drift.Change {
+	var changes []drift.Change
+	for i := 0; i < max(len(c1), len(c2)); i++ {
+		path := fmt.Sprintf("spec.criteria[%d]", i)
+		if i >= len(c1) || i >= len(c2) {
+			var oldCriterion, newCriterion *Criterion
+			if i < len(c1) {
+				oldCriterion = c1[i]
+			}
+			if i < len(c2) {
+				newCriterion = c2[i]
+			}
+			changes = append(changes, drift.Diff(path, oldCriterion, newCriterion)...)
+			continue
+		}
+
+		v1, v2 := c1[i].HasMetricValue, c2[i].HasMetricValue
+		path += ".hasMetricValue"
+		changes = append(changes, drift.Diff(path+".name", v1.Name, v2.Name)...)
+		changes = append(changes, drift.Diff(path+".weight", v1.Weight, v2.Weight)...)
+		changes = append(changes, drift.Diff(path+".metricName", v1.MetricName, v2.MetricName)...)
+		changes = append(changes, drift.Diff(path+".metricDefinitionId", v1.MetricDefinitionId, v2.MetricDefinitionId)...)
+		changes = append(changes, drift.Diff(path+".comparatorValue", v1.ComparatorValue, v2.ComparatorValue)...)
+		changes = append(changes, drift.Diff(path+".comparator", v1.Comparator, v2.Comparator)...)
+	}
+
+	return changes
 }
 
 type Metadata struct {
-	Name string `yaml:"name"`
+	Name          string          `yaml:"name"`
+	PreviousNames []string        `yaml:"previousNames,omitempty"`
+	MovedFrom     string          `yaml:"movedFrom,omitempty"`
+	Lifecycle     drift.Lifecycle `yaml:"lifecycle,omitempty"`
 }
 
 type Spec struct {
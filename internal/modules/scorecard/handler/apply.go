@@ -2,7 +2,7 @@ package handler
 
 import (
 	"context"
-	"log"
+	"fmt"
 
 	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
 	"github.com/motain/of-catalog/internal/modules/scorecard/dtos"
@@ -22,20 +22,28 @@ func NewApplyHandler(
 	return &ApplyHandler{repository: repository}
 }
 
-func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, stateRootLocation string, recursive bool) {
+// Apply brings Compass in line with the scorecard definitions found under configRootLocation. It returns
+// an error, before making any change, when the deletions are not allowed by deletePolicy.
+func (h *ApplyHandler) Apply(
+	ctx context.Context,
+	configRootLocation string,
+	stateRootLocation string,
+	recursive bool,
+	deletePolicy drift.DeletePolicy,
+) error {
 	parseInput := yaml.ParseInput{
 		RootLocation: configRootLocation,
 		Recursive:    recursive,
 	}
 	configScorecards, errConfig := yaml.Parse(parseInput, dtos.GetScorecardUniqueKey)
 	if errConfig != nil {
-		log.Fatalf("error: %v", errConfig)
+		return errConfig
 	}
 
 	// Read metrics from split metric state files
 	stateMetrics, errMetricState := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
 	if errMetricState != nil {
-		log.Fatalf("error: %v", errMetricState)
+		return errMetricState
 	}
 
 	for _, scorecard := range configScorecards {
@@ -47,27 +55,41 @@ func (h *ApplyHandler) Apply(ctx context.Context, configRootLocation string, sta
 	// Read scorecards from split scorecard state files
 	stateScorecards, errState := yaml.Parse(yaml.GetScorecardStateInput(), dtos.GetScorecardUniqueKey)
 	if errState != nil {
-		log.Fatalf("error: %v", errState)
+		return errState
 	}
 
-	created, updated, deleted, unchanged := drift.Detect(
+	renamed, errRename := drift.Rename(stateScorecards, configScorecards, dtos.GetScorecardPreviousNames, dtos.GetScorecardID)
+	if errRename != nil {
+		return errRename
+	}
+	for previousName, name := range renamed {
+		fmt.Printf("Renaming scorecard %s to %s\n", previousName, name)
+	}
+
+	created, updated, deleted, unchanged, changes := drift.DetectChanges(
 		stateScorecards,
 		configScorecards,
 		dtos.FromStateToConfig,
-		dtos.IsScoreCardEqual,
+		dtos.DiffScorecard,
 	)
 
+	if errDelete := drift.CheckDeletions("scorecard", deleted, deletePolicy, dtos.GetScorecardLifecycle); errDelete != nil {
+		return errDelete
+	}
+
 	var result []*dtos.ScorecardDTO
 	h.handleDeleted(ctx, deleted)
 	result = h.handleUnchanged(ctx, result, unchanged, stateScorecards, configScorecards)
 	result = h.handleCreated(ctx, result, created)
-	result = h.handleUpdated(ctx, result, updated, stateScorecards)
+	result = h.handleUpdated(ctx, result, updated, stateScorecards, changes)
 
 	// Write each scorecard to its own state file
 	err := yaml.WriteScorecardStates(result, dtos.GetScorecardUniqueKey)
 	if err != nil {
-		log.Fatalf("error writing scorecards to files: %v", err)
+		return fmt.Errorf("error writing scorecards to files: %w", err)
 	}
+
+	return nil
 }
 
 func (h *ApplyHandler) handleDeleted(ctx context.Context, scorecards map[string]*dtos.ScorecardDTO) {
@@ -165,14 +187,20 @@ func (h *ApplyHandler) handleUpdated(
 	result []*dtos.ScorecardDTO,
 	scorecards map[string]*dtos.ScorecardDTO,
 	stateScorecards map[string]*dtos.ScorecardDTO,
+	changes map[string][]drift.Change,
 ) []*dtos.ScorecardDTO {
-	for _, scorecardDTO := range scorecards {
+	for name, scorecardDTO := range scorecards {
 
 		stateScorecard, ok := stateScorecards[scorecardDTO.Spec.Name]
 		if !ok {
 			continue
 		}
 
+		fmt.Printf("Updating scorecard %s:\n", name)
+		for _, change := range changes[name] {
+			fmt.Printf("    %s\n", change)
+		}
+
 		created, updated, deleted, _ := drift.Detect(
 			h.mapCriteria(stateScorecard.Spec.Criteria),
 			h.mapCriteria(scorecardDTO.Spec.Criteria),
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+// NewCatalogRepository returns the repository of the catalog backend selected with CATALOG_BACKEND.
+func NewCatalogRepository(
+	config configservice.ConfigServiceInterface,
+	compass compassservice.CompassServiceInterface,
+	catalog catalogservice.FileCatalogInterface,
+) RepositoryInterface {
+	if config.GetCatalogBackend() == catalogservice.FileBackendType {
+		return NewFileRepository(catalog)
+	}
+
+	return NewRepository(compass)
+}
@@ -32,6 +32,10 @@ func (dto *CreateScorecardInput) GetQuery() string {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -106,3 +110,7 @@ func (dto *CreateScorecardOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *CreateScorecardOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.CreateScorecard.Errors
+}
@@ -139,6 +139,10 @@ func TestCreateScorecardInputGetQuery(t *testing.T) {
 					}
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
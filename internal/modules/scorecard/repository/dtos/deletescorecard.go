@@ -22,6 +22,10 @@ func (dto *DeleteScorecardInput) GetQuery() string {
 					scorecardId
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 					success
 				}
@@ -59,3 +63,7 @@ func (dto *DeleteScorecardOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *DeleteScorecardOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.DeleteScorecardOutput.Errors
+}
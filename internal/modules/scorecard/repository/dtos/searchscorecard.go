@@ -0,0 +1,105 @@
+package dtos
+
+import (
+	"github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	compassdtos "github.com/motain/of-catalog/internal/services/compassservice/dtos"
+)
+
+/*************
+ * INPUT DTO *
+ *************/
+type SearchScorecardsInput struct {
+	compassdtos.InputDTO
+	CompassCloudID string
+	Scorecard      resources.Scorecard
+	// After is the cursor of the page to read, empty for the first page
+	After string
+}
+
+func (dto *SearchScorecardsInput) GetQuery() string {
+	return `
+		query searchScorecards($cloudId: ID!, $after: String) {
+			compass {
+				scorecards(cloudId: $cloudId, query: {first: 100, after: $after}) {
+					... on CompassScorecardConnection {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+						nodes {
+							id
+							name
+							description
+							state
+							importance
+							scoringStrategyType
+							componentTypeIds
+							owner {
+								accountId
+							}
+							criterias {
+								id
+								name
+								weight
+								... on CompassHasMetricValueScorecardCriteria {
+									metricDefinitionId
+									comparator
+									comparatorValue
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+}
+
+func (dto *SearchScorecardsInput) SetVariables() map[string]interface{} {
+	return map[string]interface{}{
+		"cloudId": dto.CompassCloudID,
+		"after":   compassdtos.AfterVariable(dto.After),
+	}
+}
+
+/**************
+ * OUTPUT DTO *
+ **************/
+type Scorecard struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description"`
+	State               string   `json:"state"`
+	Importance          string   `json:"importance"`
+	ScoringStrategyType string   `json:"scoringStrategyType"`
+	ComponentTypeIDs    []string `json:"componentTypeIds"`
+	Owner               struct {
+		AccountID string `json:"accountId"`
+	} `json:"owner"`
+	Criteria []ScorecardCriterion `json:"criterias"`
+}
+
+type ScorecardCriterion struct {
+	ID                 string  `json:"id"`
+	Name               string  `json:"name"`
+	Weight             int     `json:"weight"`
+	MetricDefinitionID string  `json:"metricDefinitionId"`
+	Comparator         string  `json:"comparator"`
+	ComparatorValue    float64 `json:"comparatorValue"`
+}
+
+type SearchScorecardsOutput struct {
+	Compass struct {
+		Scorecards struct {
+			PageInfo compassdtos.PageInfo `json:"pageInfo"`
+			Nodes    []Scorecard          `json:"nodes"`
+		} `json:"scorecards"`
+	} `json:"compass"`
+}
+
+func (dto *SearchScorecardsOutput) IsSuccessful() bool {
+	return dto.Compass.Scorecards.Nodes != nil
+}
+
+func (dto *SearchScorecardsOutput) GetErrors() []string {
+	return nil
+}
@@ -0,0 +1,52 @@
+package dtos_test
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/modules/scorecard/repository/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchScorecardsInput_SetVariables(t *testing.T) {
+	input := &dtos.SearchScorecardsInput{CompassCloudID: "cloud123"}
+
+	assert.Equal(t, map[string]interface{}{"cloudId": "cloud123", "after": nil}, input.SetVariables())
+
+	input.After = "cursor-1"
+	assert.Equal(t, map[string]interface{}{"cloudId": "cloud123", "after": "cursor-1"}, input.SetVariables())
+}
+
+func TestSearchScorecardsOutput_IsSuccessful(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   *dtos.SearchScorecardsOutput
+		expected bool
+	}{
+		{
+			name:     "no scorecards returned",
+			output:   &dtos.SearchScorecardsOutput{},
+			expected: false,
+		},
+		{
+			name: "scorecards returned",
+			output: func() *dtos.SearchScorecardsOutput {
+				output := &dtos.SearchScorecardsOutput{}
+				output.Compass.Scorecards.Nodes = []dtos.Scorecard{}
+				return output
+			}(),
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.output.IsSuccessful())
+		})
+	}
+}
+
+func TestSearchScorecardsOutput_GetErrors(t *testing.T) {
+	output := &dtos.SearchScorecardsOutput{}
+
+	assert.Nil(t, output.GetErrors())
+}
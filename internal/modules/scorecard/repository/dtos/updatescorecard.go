@@ -28,6 +28,10 @@ func (dto *UpdateScorecardInput) GetQuery() string {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -107,3 +111,7 @@ func (dto *UpdateScorecardOutput) GetErrors() []string {
 	}
 	return errors
 }
+
+func (dto *UpdateScorecardOutput) GetCompassErrors() []compassservice.CompassError {
+	return dto.Compass.UpdateScorecardOutput.Errors
+}
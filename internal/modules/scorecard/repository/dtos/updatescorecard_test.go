@@ -114,6 +114,10 @@ func TestGetQuery(t *testing.T) {
 					success
 					errors {
 						message
+						extensions {
+							statusCode
+							errorType
+						}
 					}
 				}
 			}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+)
+
+// FileRepository keeps scorecards in a local file catalog.
+type FileRepository struct {
+	catalog catalogservice.FileCatalogInterface
+}
+
+func NewFileRepository(catalog catalogservice.FileCatalogInterface) *FileRepository {
+	return &FileRepository{catalog: catalog}
+}
+
+func (r *FileRepository) Create(ctx context.Context, scorecard resources.Scorecard) (string, map[string]string, error) {
+	id, idErr := r.catalog.NewID(catalogservice.ScorecardKind)
+	if idErr != nil {
+		return "", nil, fmt.Errorf("Create error for %s: %w", scorecard.Name, idErr)
+	}
+	scorecard.ID = &id
+
+	criteria := make([]*resources.Criterion, len(scorecard.Criteria))
+	criteriaMap := make(map[string]string, len(scorecard.Criteria))
+	for i, criterion := range scorecard.Criteria {
+		created, criterionErr := r.newCriterion(criterion)
+		if criterionErr != nil {
+			return "", nil, fmt.Errorf("Create error for %s: %w", scorecard.Name, criterionErr)
+		}
+		criteria[i] = created
+		criteriaMap[created.HasMetricValue.Name] = created.HasMetricValue.ID
+	}
+	scorecard.Criteria = criteria
+
+	if putErr := r.catalog.Put(catalogservice.ScorecardKind, id, scorecard); putErr != nil {
+		return "", nil, fmt.Errorf("Create error for %s: %w", scorecard.Name, putErr)
+	}
+
+	return id, criteriaMap, nil
+}
+
+func (r *FileRepository) Update(
+	ctx context.Context,
+	scorecard resources.Scorecard,
+	createCriteria []*resources.Criterion,
+	updateCriteria []*resources.Criterion,
+	deleteCriteria []string,
+) error {
+	if scorecard.ID == nil {
+		return fmt.Errorf("Update error for %s: %s", scorecard.Name, "missing scorecard id")
+	}
+
+	var stored resources.Scorecard
+	if getErr := r.catalog.Get(catalogservice.ScorecardKind, *scorecard.ID, &stored); getErr != nil {
+		return fmt.Errorf("Update error for %s: %w", *scorecard.ID, getErr)
+	}
+
+	criteria := make([]*resources.Criterion, 0, len(stored.Criteria)+len(createCriteria))
+	for _, criterion := range stored.Criteria {
+		if slices.Contains(deleteCriteria, criterion.HasMetricValue.ID) {
+			continue
+		}
+
+		for _, updated := range updateCriteria {
+			if updated.HasMetricValue.ID == criterion.HasMetricValue.ID {
+				criterion = updated
+				break
+			}
+		}
+		criteria = append(criteria, criterion)
+	}
+	for _, criterion := range createCriteria {
+		created, criterionErr := r.newCriterion(criterion)
+		if criterionErr != nil {
+			return fmt.Errorf("Update error for %s: %w", *scorecard.ID, criterionErr)
+		}
+		criteria = append(criteria, created)
+	}
+	scorecard.Criteria = criteria
+
+	if putErr := r.catalog.Put(catalogservice.ScorecardKind, *scorecard.ID, scorecard); putErr != nil {
+		return fmt.Errorf("Update error for %s: %w", *scorecard.ID, putErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	if deleteErr := r.catalog.Delete(catalogservice.ScorecardKind, id); deleteErr != nil {
+		return fmt.Errorf("Delete error for %s: %w", id, deleteErr)
+	}
+	return nil
+}
+
+func (r *FileRepository) Search(ctx context.Context, scorecard resources.Scorecard) (*resources.Scorecard, error) {
+	ids, listErr := r.catalog.List(catalogservice.ScorecardKind)
+	if listErr != nil {
+		return nil, fmt.Errorf("Search error for %s: %w", scorecard.Name, listErr)
+	}
+
+	hasID := scorecard.ID != nil && *scorecard.ID != ""
+	for _, id := range ids {
+		var stored resources.Scorecard
+		if getErr := r.catalog.Get(catalogservice.ScorecardKind, id, &stored); getErr != nil {
+			return nil, fmt.Errorf("Search error for %s: %w", scorecard.Name, getErr)
+		}
+
+		if (hasID && id == *scorecard.ID) || (!hasID && stored.Name == scorecard.Name) {
+			return &stored, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Search error for %s: %w", scorecard.Name, &compassservice.Error{
+		Operation: "search scorecard",
+		Class:     compassservice.ErrorClassNotFound,
+		Err:       errors.New("scorecard not found"),
+	})
+}
+
+func (r *FileRepository) newCriterion(criterion *resources.Criterion) (*resources.Criterion, error) {
+	id, idErr := r.catalog.NewID(catalogservice.CriterionKind)
+	if idErr != nil {
+		return nil, idErr
+	}
+
+	created := *criterion
+	created.HasMetricValue.ID = id
+
+	return &created, nil
+}
@@ -0,0 +1,50 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func criterion(id, name string, weight int) *resources.Criterion {
+	return &resources.Criterion{HasMetricValue: resources.MetricValue{ID: id, Name: name, Weight: weight}}
+}
+
+func TestFileRepository(t *testing.T) {
+	repo := repository.NewFileRepository(catalogservice.NewFileCatalogAt(t.TempDir()))
+	ctx := context.Background()
+
+	id, criteriaMap, createErr := repo.Create(ctx, resources.Scorecard{
+		Name:     "quality",
+		Criteria: []*resources.Criterion{criterion("", "coverage", 50), criterion("", "lint", 50)},
+	})
+	require.NoError(t, createErr)
+	require.Len(t, criteriaMap, 2)
+
+	updateErr := repo.Update(
+		ctx,
+		resources.Scorecard{ID: &id, Name: "code-quality"},
+		[]*resources.Criterion{criterion("", "docs", 20)},
+		[]*resources.Criterion{criterion(criteriaMap["coverage"], "coverage", 80)},
+		[]string{criteriaMap["lint"]},
+	)
+	require.NoError(t, updateErr)
+
+	found, searchErr := repo.Search(ctx, resources.Scorecard{Name: "code-quality"})
+	require.NoError(t, searchErr)
+	assert.Equal(t, id, *found.ID)
+	require.Len(t, found.Criteria, 2)
+	assert.Equal(t, criteriaMap["coverage"], found.Criteria[0].HasMetricValue.ID)
+	assert.Equal(t, 80, found.Criteria[0].HasMetricValue.Weight)
+	assert.Equal(t, "docs", found.Criteria[1].HasMetricValue.Name)
+	assert.NotEmpty(t, found.Criteria[1].HasMetricValue.ID)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	_, searchErr = repo.Search(ctx, resources.Scorecard{ID: &id})
+	assert.ErrorContains(t, searchErr, "scorecard not found")
+}
@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/motain/of-catalog/internal/modules/scorecard/repository/dtos"
@@ -21,6 +22,7 @@ type RepositoryInterface interface {
 		deleteCriteria []string,
 	) error
 	Delete(ctx context.Context, id string) error
+	Search(ctx context.Context, scorecard resources.Scorecard) (*resources.Scorecard, error)
 }
 
 type Repository struct {
@@ -35,7 +37,7 @@ func (r *Repository) Create(ctx context.Context, scorecard resources.Scorecard)
 	input := &dtos.CreateScorecardInput{CompassCloudID: r.compass.GetCompassCloudId(), Scorecard: scorecard}
 	output := &dtos.CreateScorecardOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return "", nil, fmt.Errorf("Create error for %s: %s", *scorecard.ID, runErr)
+		return "", nil, fmt.Errorf("Create error for %s: %w", *scorecard.ID, runErr)
 	}
 
 	scorecardDetails := output.Compass.CreateScorecard.Scorecard
@@ -62,7 +64,7 @@ func (r *Repository) Update(
 	}
 	output := &dtos.UpdateScorecardOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("Update error for %s: %s", *scorecard.ID, runErr)
+		return fmt.Errorf("Update error for %s: %w", *scorecard.ID, runErr)
 	}
 	return nil
 }
@@ -71,7 +73,73 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 	input := &dtos.DeleteScorecardInput{ScorecardID: id}
 	output := &dtos.DeleteScorecardOutput{}
 	if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
-		return fmt.Errorf("Delete error for %s: %s", id, runErr)
+		return fmt.Errorf("Delete error for %s: %w", id, runErr)
 	}
 	return nil
 }
+
+// Search reads the scorecards page by page until one matches scorecard.
+func (r *Repository) Search(ctx context.Context, scorecard resources.Scorecard) (*resources.Scorecard, error) {
+	input := &dtos.SearchScorecardsInput{CompassCloudID: r.compass.GetCompassCloudId(), Scorecard: scorecard}
+	for {
+		output := &dtos.SearchScorecardsOutput{}
+		if runErr := r.compass.RunWithDTOs(ctx, input, output); runErr != nil {
+			return nil, fmt.Errorf("Search error for %s: %w", scorecard.Name, runErr)
+		}
+
+		for _, node := range output.Compass.Scorecards.Nodes {
+			if !matchesScorecard(node, scorecard) {
+				continue
+			}
+
+			id := node.ID
+			found := &resources.Scorecard{
+				ID:                  &id,
+				Name:                node.Name,
+				Description:         node.Description,
+				OwnerID:             node.Owner.AccountID,
+				State:               node.State,
+				ComponentTypeIDs:    node.ComponentTypeIDs,
+				Importance:          node.Importance,
+				ScoringStrategyType: node.ScoringStrategyType,
+				Criteria:            make([]*resources.Criterion, len(node.Criteria)),
+			}
+			for i, criterion := range node.Criteria {
+				found.Criteria[i] = &resources.Criterion{
+					HasMetricValue: resources.MetricValue{
+						ID:                 criterion.ID,
+						Weight:             criterion.Weight,
+						Name:               criterion.Name,
+						MetricDefinitionId: criterion.MetricDefinitionID,
+						ComparatorValue:    int(criterion.ComparatorValue),
+						Comparator:         criterion.Comparator,
+					},
+				}
+			}
+
+			return found, nil
+		}
+
+		pageInfo := output.Compass.Scorecards.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			break
+		}
+		input.After = pageInfo.EndCursor
+	}
+
+	return nil, fmt.Errorf("Search error for %s: %w", scorecard.Name, &compassservice.Error{
+		Operation: "query searchScorecards",
+		Class:     compassservice.ErrorClassNotFound,
+		Err:       errors.New("scorecard not found"),
+	})
+}
+
+// matchesScorecard matches a scorecard by ID when it is known, by name otherwise,
+// so that a scorecard renamed in Compass is still found.
+func matchesScorecard(node dtos.Scorecard, scorecard resources.Scorecard) bool {
+	if scorecard.ID != nil && *scorecard.ID != "" {
+		return node.ID == *scorecard.ID
+	}
+
+	return node.Name == scorecard.Name
+}
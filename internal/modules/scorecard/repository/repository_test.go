@@ -11,6 +11,7 @@ import (
 	"github.com/motain/of-catalog/internal/modules/scorecard/repository"
 	"github.com/motain/of-catalog/internal/modules/scorecard/repository/dtos"
 	"github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/services/compassservice"
 	compassmocks "github.com/motain/of-catalog/internal/services/compassservice/mocks"
 )
 
@@ -271,6 +272,147 @@ func TestRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestRepository_Search(t *testing.T) {
+	// Define test cases
+	testcases := []struct {
+		name              string
+		scorecard         resources.Scorecard
+		setupMocks        func(compassMock *compassmocks.MockCompassServiceInterface)
+		expectedScorecard *resources.Scorecard
+		expectError       bool
+		errorMessage      string
+		expectNotFound    bool
+	}{
+		{
+			name:      "successful search",
+			scorecard: resources.Scorecard{Name: "observability"},
+			setupMocks: func(compassMock *compassmocks.MockCompassServiceInterface) {
+				compassMock.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compassMock.EXPECT().RunWithDTOs(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).DoAndReturn(func(_ context.Context, input *dtos.SearchScorecardsInput, output *dtos.SearchScorecardsOutput) error {
+					assert.Equal(t, "cloud-id", input.CompassCloudID)
+					output.Compass.Scorecards.Nodes = []dtos.Scorecard{
+						{ID: "other-id", Name: "resiliency"},
+						{
+							ID:                  "scorecard-id",
+							Name:                "observability",
+							Description:         "description",
+							State:               "PUBLISHED",
+							Importance:          "REQUIRED",
+							ScoringStrategyType: "WEIGHT_BASED",
+							ComponentTypeIDs:    []string{"SERVICE"},
+							Criteria: []dtos.ScorecardCriterion{
+								{ID: "criterion-id", Name: "logging", Weight: 50, MetricDefinitionID: "metric-id", Comparator: "EQUALS", ComparatorValue: 1},
+							},
+						},
+					}
+					return nil
+				})
+			},
+			expectedScorecard: &resources.Scorecard{
+				ID:                  stringPtr("scorecard-id"),
+				Name:                "observability",
+				Description:         "description",
+				State:               "PUBLISHED",
+				Importance:          "REQUIRED",
+				ScoringStrategyType: "WEIGHT_BASED",
+				ComponentTypeIDs:    []string{"SERVICE"},
+				Criteria: []*resources.Criterion{
+					{HasMetricValue: resources.MetricValue{ID: "criterion-id", Name: "logging", Weight: 50, MetricDefinitionId: "metric-id", Comparator: "EQUALS", ComparatorValue: 1}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name:      "successful search by id",
+			scorecard: resources.Scorecard{ID: stringPtr("scorecard-id"), Name: "resiliency"},
+			setupMocks: func(compassMock *compassmocks.MockCompassServiceInterface) {
+				compassMock.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compassMock.EXPECT().RunWithDTOs(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).DoAndReturn(func(_ context.Context, input *dtos.SearchScorecardsInput, output *dtos.SearchScorecardsOutput) error {
+					output.Compass.Scorecards.Nodes = []dtos.Scorecard{
+						{ID: "other-id", Name: "resiliency"},
+						{ID: "scorecard-id", Name: "observability"},
+					}
+					return nil
+				})
+			},
+			expectedScorecard: &resources.Scorecard{
+				ID:       stringPtr("scorecard-id"),
+				Name:     "observability",
+				Criteria: []*resources.Criterion{},
+			},
+			expectError: false,
+		},
+		{
+			name:      "scorecard not found",
+			scorecard: resources.Scorecard{Name: "observability"},
+			setupMocks: func(compassMock *compassmocks.MockCompassServiceInterface) {
+				compassMock.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compassMock.EXPECT().RunWithDTOs(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).DoAndReturn(func(_ context.Context, input *dtos.SearchScorecardsInput, output *dtos.SearchScorecardsOutput) error {
+					output.Compass.Scorecards.Nodes = []dtos.Scorecard{{ID: "other-id", Name: "resiliency"}}
+					return nil
+				})
+			},
+			expectError:    true,
+			errorMessage:   "Search error for observability: scorecard not found",
+			expectNotFound: true,
+		},
+		{
+			name:      "search error",
+			scorecard: resources.Scorecard{Name: "observability"},
+			setupMocks: func(compassMock *compassmocks.MockCompassServiceInterface) {
+				compassMock.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compassMock.EXPECT().RunWithDTOs(
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
+				).Return(errors.New("compass error"))
+			},
+			expectError:  true,
+			errorMessage: "Search error for observability: compass error",
+		},
+	}
+
+	// Run test cases
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Setup
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			compassMock := compassmocks.NewMockCompassServiceInterface(ctrl)
+			tc.setupMocks(compassMock)
+
+			repo := repository.NewRepository(compassMock)
+
+			// Execute
+			scorecard, err := repo.Search(context.Background(), tc.scorecard)
+
+			// Assert
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Equal(t, tc.errorMessage, err.Error())
+				var compassErr *compassservice.Error
+				assert.Equal(t, tc.expectNotFound, errors.As(err, &compassErr) && compassErr.Class == compassservice.ErrorClassNotFound)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedScorecard, scorecard)
+			}
+		})
+	}
+}
+
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
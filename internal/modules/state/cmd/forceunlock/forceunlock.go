@@ -0,0 +1,26 @@
+package forceunlock
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "force-unlock LOCK_ID",
+		Short: "Release a stuck state lock",
+		Long:  "Release the state lock left behind by a run that did not terminate cleanly. The lock ID is printed when a command fails to acquire the lock.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			handler.ForceUnlock(args[0], force, os.Stdin)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Do not ask for confirmation")
+
+	return cmd
+}
@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package forceunlock
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// ForceUnlockHandler
+	handler.NewForceUnlockHandler,
+)
+
+func initializeHandler() *handler.ForceUnlockHandler {
+	panic(wire.Build(ProviderSet))
+}
@@ -0,0 +1,23 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package forceunlock
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.ForceUnlockHandler {
+	forceUnlockHandler := handler.NewForceUnlockHandler()
+	return forceUnlockHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(handler.NewForceUnlockHandler)
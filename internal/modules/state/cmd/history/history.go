@@ -0,0 +1,23 @@
+package history
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history",
+		Short: "List the recorded state versions",
+		Long:  "List the state versions recorded by the commands writing the state, with who ran them, when, from which git commit and with which command.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			if err := handler.History(os.Stdout); err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+}
@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package history
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// HistoryHandler
+	handler.NewHistoryHandler,
+)
+
+func initializeHandler() *handler.HistoryHandler {
+	panic(wire.Build(ProviderSet))
+}
@@ -0,0 +1,54 @@
+package importstate
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var configRootLocation string
+	var recursive bool
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "import [KIND] [NAME]",
+		Short: "Rebuild the state from the objects existing in Compass",
+		Long:  "Match the resources of the configuration with the metric definitions, scorecards and components existing in Compass and record their identifiers in the state. KIND is one of metric, scorecard or component.",
+		Args:  cobra.MaximumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if configRootLocation == "" {
+				fmt.Println("Error: configRootLocation required")
+				cmd.Help()
+				return
+			}
+
+			var kind, name string
+			if len(args) > 0 {
+				kind = args[0]
+			}
+			if len(args) > 1 {
+				name = args[1]
+			}
+
+			handler := initializeHandler()
+			ctx := commandcontext.Init()
+			err := yaml.WithStateLock("state import", lockTimeout, func() error {
+				return handler.Import(ctx, configRootLocation, recursive, kind, name)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&configRootLocation, "configRootLocation", "l", "", "Root location of the config")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Import resources recursively")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
@@ -0,0 +1,61 @@
+//go:build wireinject
+
+package importstate
+
+import (
+	"github.com/google/wire"
+	componentrepository "github.com/motain/of-catalog/internal/modules/component/repository"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository"
+	scorecardrepository "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/keyringservice"
+)
+
+var ProviderSet = wire.NewSet(
+	// Kyeringservice
+	keyringservice.NewKeyringService,
+	wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)),
+
+	// Configservice
+	configservice.NewConfigService,
+	wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)),
+
+	// Compassservice
+	compassservice.NewGraphQLClient,
+	compassservice.NewHTTPClient,
+	compassservice.NewCompassService,
+	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
+
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
+	// Githubservice
+	githubservice.NewGitHubClient,
+	githubservice.NewGitHubService,
+	wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)),
+
+	// --- component module ---
+	// Repository
+	componentrepository.NewCatalogRepository,
+
+	// --- metric module ---
+	// Repository
+	metricrepository.NewCatalogRepository,
+
+	// --- scorecard module ---
+	// Repository
+	scorecardrepository.NewCatalogRepository,
+
+	// --- state module ---
+	// ImportHandler
+	handler.NewImportHandler,
+)
+
+func initializeHandler() *handler.ImportHandler {
+	panic(wire.Build(ProviderSet))
+}
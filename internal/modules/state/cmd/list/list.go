@@ -0,0 +1,25 @@
+package list
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [KIND]",
+		Short: "List the resources recorded in the state",
+		Long:  "List the resources recorded in the state with their Compass IDs. KIND is one of metric, scorecard or component.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var kind string
+			if len(args) > 0 {
+				kind = args[0]
+			}
+
+			handler := initializeHandler()
+			handler.List(kind, os.Stdout)
+		},
+	}
+}
@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package list
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// ListHandler
+	handler.NewListHandler,
+)
+
+func initializeHandler() *handler.ListHandler {
+	panic(wire.Build(ProviderSet))
+}
@@ -0,0 +1,43 @@
+package migrate
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var dryRun bool
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Rewrite the state in the current state version",
+		Long:  "Rewrite the state files written by older versions of of-catalog in the current state version. Older state files are migrated on load, this command records the result.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			if dryRun {
+				if err := handler.Migrate(true, os.Stdout); err != nil {
+					log.Fatalf("error: %v", err)
+				}
+				return
+			}
+
+			err := yaml.WithStateLock("state migrate", lockTimeout, func() error {
+				return handler.Migrate(false, os.Stdout)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "List the state files to migrate without rewriting them")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
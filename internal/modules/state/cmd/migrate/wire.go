@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package migrate
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// MigrateHandler
+	handler.NewMigrateHandler,
+)
+
+func initializeHandler() *handler.MigrateHandler {
+	panic(wire.Build(ProviderSet))
+}
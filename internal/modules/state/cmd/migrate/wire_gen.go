@@ -0,0 +1,23 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package migrate
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.MigrateHandler {
+	migrateHandler := handler.NewMigrateHandler()
+	return migrateHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(handler.NewMigrateHandler)
@@ -0,0 +1,33 @@
+package mv
+
+import (
+	"log"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "mv KIND SOURCE DESTINATION",
+		Short: "Rename a resource in the state",
+		Long:  "Rename a resource in the state, keeping its Compass IDs and name, so that the next apply after renaming it in the configuration renames the Compass object instead of recreating it.",
+		Args:  cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			err := yaml.WithStateLock("state mv", lockTimeout, func() error {
+				return handler.Mv(args[0], args[1], args[2])
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
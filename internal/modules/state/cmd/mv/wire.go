@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package mv
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// MvHandler
+	handler.NewMvHandler,
+)
+
+func initializeHandler() *handler.MvHandler {
+	panic(wire.Build(ProviderSet))
+}
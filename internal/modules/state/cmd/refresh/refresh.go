@@ -0,0 +1,69 @@
+package refresh
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var configRootLocation string
+	var recursive, updateState, apply bool
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "refresh [KIND] [NAME]",
+		Short: "Detect changes made directly in Compass",
+		Long:  "Read the objects recorded in the state from Compass and report the fields changed outside of this tool. KIND is one of metric, scorecard or component. With --apply, NAME is only accepted for components: the apply of metrics and scorecards covers the whole configuration, use --update-state then apply instead.",
+		Args:  cobra.MaximumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if apply && configRootLocation == "" {
+				fmt.Println("Error: configRootLocation required with --apply")
+				cmd.Help()
+				return
+			}
+
+			var kind, name string
+			if len(args) > 0 {
+				kind = args[0]
+			}
+			if len(args) > 1 {
+				name = args[1]
+			}
+
+			if apply {
+				if reapplyErr := handler.ValidateReapply(kind, name); reapplyErr != nil {
+					fmt.Printf("Error: %v\n", reapplyErr)
+					cmd.Help()
+					return
+				}
+			}
+
+			handler := initializeHandler()
+			ctx := commandcontext.Init()
+			err := yaml.WithStateLock("state refresh", lockTimeout, func() error {
+				drifted, refreshErr := handler.Refresh(ctx, kind, name, updateState || apply)
+				if refreshErr != nil || !apply || drifted == 0 {
+					return refreshErr
+				}
+				return handler.Reapply(ctx, configRootLocation, recursive, kind, name)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&updateState, "update-state", false, "Record the changes found in Compass in the state")
+	cmd.Flags().BoolVar(&apply, "apply", false, "Apply the configuration again over the changes found in Compass")
+	cmd.Flags().StringVarP(&configRootLocation, "configRootLocation", "l", "", "Root location of the config, required with --apply")
+	cmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Apply changes recursively")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
@@ -0,0 +1,89 @@
+//go:build wireinject
+
+package refresh
+
+import (
+	"github.com/google/wire"
+	componenthandler "github.com/motain/of-catalog/internal/modules/component/handler"
+	componentrepository "github.com/motain/of-catalog/internal/modules/component/repository"
+	metrichandler "github.com/motain/of-catalog/internal/modules/metric/handler"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository"
+	scorecardhandler "github.com/motain/of-catalog/internal/modules/scorecard/handler"
+	scorecardrepository "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/documentservice"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/keyringservice"
+	"github.com/motain/of-catalog/internal/services/ownerservice"
+	"github.com/motain/of-catalog/internal/services/prometheusservice"
+)
+
+var ProviderSet = wire.NewSet(
+	// Kyeringservice
+	keyringservice.NewKeyringService,
+	wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)),
+
+	// Configservice
+	configservice.NewConfigService,
+	wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)),
+
+	// Compassservice
+	compassservice.NewGraphQLClient,
+	compassservice.NewHTTPClient,
+	compassservice.NewCompassService,
+	wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)),
+
+	// Catalogservice
+	catalogservice.NewFileCatalog,
+	wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)),
+
+	// Githubservice
+	githubservice.NewGitHubClient,
+	githubservice.NewGitHubService,
+	wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)),
+
+	// Prometheusservice
+	prometheusservice.NewPrometheusService,
+	prometheusservice.NewPrometheusClient,
+	wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)),
+
+	// OwnerService
+	ownerservice.NewOwnerService,
+	wire.Bind(new(ownerservice.OwnerServiceInterface), new(*ownerservice.OwnerService)),
+
+	// DocumentService
+	documentservice.NewDocumentService,
+	wire.Bind(new(documentservice.DocumentServiceInterface), new(*documentservice.DocumentService)),
+
+	// --- component module ---
+	// Repository
+	componentrepository.NewCatalogRepository,
+
+	// ApplyHandler
+	componenthandler.NewApplyHandler,
+
+	// --- metric module ---
+	// Repository
+	metricrepository.NewCatalogRepository,
+
+	// ApplyHandler
+	metrichandler.NewApplyHandler,
+
+	// --- scorecard module ---
+	// Repository
+	scorecardrepository.NewCatalogRepository,
+
+	// ApplyHandler
+	scorecardhandler.NewApplyHandler,
+
+	// --- state module ---
+	// RefreshHandler
+	handler.NewRefreshHandler,
+)
+
+func initializeHandler() *handler.RefreshHandler {
+	panic(wire.Build(ProviderSet))
+}
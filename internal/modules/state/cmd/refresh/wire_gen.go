@@ -0,0 +1,53 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package refresh
+
+import (
+	"github.com/google/wire"
+	handler2 "github.com/motain/of-catalog/internal/modules/component/handler"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	handler3 "github.com/motain/of-catalog/internal/modules/metric/handler"
+	repository2 "github.com/motain/of-catalog/internal/modules/metric/repository"
+	handler4 "github.com/motain/of-catalog/internal/modules/scorecard/handler"
+	repository3 "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/documentservice"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/keyringservice"
+	"github.com/motain/of-catalog/internal/services/ownerservice"
+	"github.com/motain/of-catalog/internal/services/prometheusservice"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.RefreshHandler {
+	configService := configservice.NewConfigService()
+	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
+	httpClientInterface := compassservice.NewHTTPClient(configService)
+	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
+	repositoryRepositoryInterface := repository2.NewCatalogRepository(configService, compassService, fileCatalog)
+	repositoryInterface2 := repository3.NewCatalogRepository(configService, compassService, fileCatalog)
+	keyringService := keyringservice.NewKeyringService()
+	gitHubClientInterface := githubservice.NewGitHubClient(configService, keyringService)
+	gitHubService := githubservice.NewGitHubService(gitHubClientInterface)
+	ownerService := ownerservice.NewOwnerService()
+	documentService := documentservice.NewDocumentService(gitHubService)
+	applyHandler := handler2.NewApplyHandler(gitHubService, repositoryInterface, ownerService, documentService)
+	handlerApplyHandler := handler3.NewApplyHandler(repositoryRepositoryInterface)
+	applyHandler2 := handler4.NewApplyHandler(repositoryInterface2)
+	refreshHandler := handler.NewRefreshHandler(repositoryInterface, repositoryRepositoryInterface, repositoryInterface2, applyHandler, handlerApplyHandler, applyHandler2)
+	return refreshHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), prometheusservice.NewPrometheusService, prometheusservice.NewPrometheusClient, wire.Bind(new(prometheusservice.PrometheusServiceInterface), new(*prometheusservice.PrometheusService)), ownerservice.NewOwnerService, wire.Bind(new(ownerservice.OwnerServiceInterface), new(*ownerservice.OwnerService)), documentservice.NewDocumentService, wire.Bind(new(documentservice.DocumentServiceInterface), new(*documentservice.DocumentService)), repository.NewCatalogRepository, handler2.NewApplyHandler, repository2.NewCatalogRepository, handler3.NewApplyHandler, repository3.NewCatalogRepository, handler4.NewApplyHandler, handler.NewRefreshHandler)
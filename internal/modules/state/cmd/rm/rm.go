@@ -0,0 +1,33 @@
+package rm
+
+import (
+	"log"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "rm KIND NAME...",
+		Short: "Remove resources from the state",
+		Long:  "Remove resources from the state without deleting them in Compass.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			err := yaml.WithStateLock("state rm", lockTimeout, func() error {
+				return handler.Rm(args[0], args[1:])
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package rm
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// RmHandler
+	handler.NewRmHandler,
+)
+
+func initializeHandler() *handler.RmHandler {
+	panic(wire.Build(ProviderSet))
+}
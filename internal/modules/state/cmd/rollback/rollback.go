@@ -0,0 +1,51 @@
+package rollback
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/commandcontext"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	var restoreState bool
+	var lockTimeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "rollback VERSION",
+		Short: "Compare the current state with a state version",
+		Long:  "Print the changes from the current state to a recorded state version. Compass is not read: use state refresh to compare the state with Compass. With --restore-state the state files of the version replace the current ones, without changing Compass, once every object they record is found in Compass with the same ID.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			version, parseErr := strconv.Atoi(args[0])
+			if parseErr != nil {
+				log.Fatalf("error: invalid state version %q", args[0])
+			}
+
+			handler := initializeHandler()
+			ctx := commandcontext.Init()
+			if !restoreState {
+				if err := handler.Rollback(ctx, version, false, os.Stdout); err != nil {
+					log.Fatalf("error: %v", err)
+				}
+				return
+			}
+
+			err := yaml.WithStateLock("state rollback", lockTimeout, func() error {
+				return handler.Rollback(ctx, version, true, os.Stdout)
+			})
+			if err != nil {
+				log.Fatalf("error: %v", err)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&restoreState, "restore-state", false, "Replace the state files with the ones of the version")
+	cmd.Flags().DurationVar(&lockTimeout, "lock-timeout", 0, "Duration to wait for the state lock held by another run")
+
+	return cmd
+}
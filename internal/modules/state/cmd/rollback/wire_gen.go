@@ -0,0 +1,39 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package rollback
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/component/repository"
+	repository2 "github.com/motain/of-catalog/internal/modules/metric/repository"
+	repository3 "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/keyringservice"
+)
+
+// Injectors from wire.go:
+
+func initializeHandler() *handler.RollbackHandler {
+	configService := configservice.NewConfigService()
+	graphQLClientInterface := compassservice.NewGraphQLClient(configService)
+	httpClientInterface := compassservice.NewHTTPClient(configService)
+	compassService := compassservice.NewCompassService(configService, graphQLClientInterface, httpClientInterface)
+	fileCatalog := catalogservice.NewFileCatalog(configService)
+	repositoryInterface := repository.NewCatalogRepository(configService, compassService, fileCatalog)
+	repositoryRepositoryInterface := repository2.NewCatalogRepository(configService, compassService, fileCatalog)
+	repositoryInterface2 := repository3.NewCatalogRepository(configService, compassService, fileCatalog)
+	rollbackHandler := handler.NewRollbackHandler(repositoryInterface, repositoryRepositoryInterface, repositoryInterface2)
+	return rollbackHandler
+}
+
+// wire.go:
+
+var ProviderSet = wire.NewSet(keyringservice.NewKeyringService, wire.Bind(new(keyringservice.KeyringServiceInterface), new(*keyringservice.KeyringService)), configservice.NewConfigService, wire.Bind(new(configservice.ConfigServiceInterface), new(*configservice.ConfigService)), compassservice.NewGraphQLClient, compassservice.NewHTTPClient, compassservice.NewCompassService, wire.Bind(new(compassservice.CompassServiceInterface), new(*compassservice.CompassService)), catalogservice.NewFileCatalog, wire.Bind(new(catalogservice.FileCatalogInterface), new(*catalogservice.FileCatalog)), githubservice.NewGitHubClient, githubservice.NewGitHubService, wire.Bind(new(githubservice.GitHubServiceInterface), new(*githubservice.GitHubService)), repository.NewCatalogRepository, repository2.NewCatalogRepository, repository3.NewCatalogRepository, handler.NewRollbackHandler)
@@ -0,0 +1,19 @@
+package show
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show KIND NAME",
+		Short: "Show a resource recorded in the state",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			handler := initializeHandler()
+			handler.Show(args[0], args[1], os.Stdout)
+		},
+	}
+}
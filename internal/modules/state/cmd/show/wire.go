@@ -0,0 +1,17 @@
+//go:build wireinject
+
+package show
+
+import (
+	"github.com/google/wire"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+)
+
+var ProviderSet = wire.NewSet(
+	// ShowHandler
+	handler.NewShowHandler,
+)
+
+func initializeHandler() *handler.ShowHandler {
+	panic(wire.Build(ProviderSet))
+}
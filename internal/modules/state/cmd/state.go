@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/motain/of-catalog/internal/modules/state/cmd/forceunlock"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/history"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/importstate"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/list"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/migrate"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/mv"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/refresh"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/rm"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/rollback"
+	"github.com/motain/of-catalog/internal/modules/state/cmd/show"
+	"github.com/spf13/cobra"
+)
+
+func Init() *cobra.Command {
+	stateCmd := &cobra.Command{
+		Use:   "state",
+		Short: "state related commands",
+	}
+
+	stateCmd.AddCommand(forceunlock.Init())
+	stateCmd.AddCommand(importstate.Init())
+	stateCmd.AddCommand(refresh.Init())
+	stateCmd.AddCommand(list.Init())
+	stateCmd.AddCommand(show.Init())
+	stateCmd.AddCommand(rm.Init())
+	stateCmd.AddCommand(mv.Init())
+	stateCmd.AddCommand(history.Init())
+	stateCmd.AddCommand(rollback.Init())
+	stateCmd.AddCommand(migrate.Init())
+
+	return stateCmd
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"fmt"
+
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+// stateEntry is the kind-independent view of a resource recorded in the state.
+type stateEntry struct {
+	Kind string
+	Name string
+	ID   string
+}
+
+// readStateEntries returns the resources of kind recorded in the state, sorted by name.
+func readStateEntries(kind string) ([]stateEntry, error) {
+	switch kind {
+	case MetricKind:
+		return readEntries(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey, MetricKind, func(m *metricdtos.MetricDTO) string {
+			return m.Spec.ID
+		})
+	case ScorecardKind:
+		return readEntries(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey, ScorecardKind, func(s *scorecarddtos.ScorecardDTO) string {
+			if s.Spec.ID == nil {
+				return ""
+			}
+			return *s.Spec.ID
+		})
+	case ComponentKind:
+		return readEntries(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey, ComponentKind, func(c *componentdtos.ComponentDTO) string {
+			return c.Spec.ID
+		})
+	}
+
+	return nil, ValidateKind(kind)
+}
+
+// encodeStateEntry returns the YAML recorded in the state for the resource of kind named name.
+func encodeStateEntry(kind, name string) ([]byte, error) {
+	switch kind {
+	case MetricKind:
+		return encodeEntry(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey, kind, name)
+	case ScorecardKind:
+		return encodeEntry(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey, kind, name)
+	case ComponentKind:
+		return encodeEntry(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey, kind, name)
+	}
+
+	return nil, ValidateKind(kind)
+}
+
+// removeStateEntries forgets the resources of kind named names, leaving the Compass objects in place.
+func removeStateEntries(kind string, names []string) error {
+	switch kind {
+	case MetricKind:
+		return removeEntries(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey, yaml.WriteMetricStates[metricdtos.MetricDTO], kind, names)
+	case ScorecardKind:
+		return removeEntries(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey, yaml.WriteScorecardStates[scorecarddtos.ScorecardDTO], kind, names)
+	case ComponentKind:
+		return removeEntries(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey, writeComponentStates, kind, names)
+	}
+
+	return ValidateKind(kind)
+}
+
+// moveStateEntry re-keys the resource of kind named from to to, keeping its Compass identifiers and
+// recording the name it has in Compass, so that the next apply renames the Compass object.
+// Renaming a metric also updates the scorecard criteria and component metric sources referencing it.
+func moveStateEntry(kind, from, to string) error {
+	switch kind {
+	case MetricKind:
+		moveErr := moveEntry(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey, yaml.WriteMetricStates[metricdtos.MetricDTO], kind, from, to, func(m *metricdtos.MetricDTO) {
+			m.Metadata.MovedFrom = movedFrom(metricdtos.GetMetricCompassName(m), to)
+			m.Spec.Name = to
+			m.Metadata.Name = to
+		})
+		if moveErr != nil {
+			return moveErr
+		}
+		return renameMetricReferences(from, to)
+	case ScorecardKind:
+		return moveEntry(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey, yaml.WriteScorecardStates[scorecarddtos.ScorecardDTO], kind, from, to, func(s *scorecarddtos.ScorecardDTO) {
+			s.Metadata.MovedFrom = movedFrom(scorecarddtos.GetScorecardCompassName(s), to)
+			s.Spec.Name = to
+			s.Metadata.Name = to
+		})
+	case ComponentKind:
+		return moveEntry(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey, writeComponentStates, kind, from, to, func(c *componentdtos.ComponentDTO) {
+			c.Metadata.MovedFrom = movedFrom(componentdtos.GetComponentCompassName(c), to)
+			c.Spec.Name = to
+			c.Metadata.Name = to
+		})
+	}
+
+	return ValidateKind(kind)
+}
+
+// movedFrom returns the name to record for an entry named compassName in Compass and moved to to,
+// none when it is moved back to its Compass name.
+func movedFrom(compassName, to string) string {
+	if compassName == to {
+		return ""
+	}
+
+	return compassName
+}
+
+func renameMetricReferences(from, to string) error {
+	scorecards, scorecardsErr := yaml.Parse(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey)
+	if scorecardsErr != nil {
+		return scorecardsErr
+	}
+
+	scorecardsChanged := false
+	for _, scorecard := range scorecards {
+		for _, criterion := range scorecard.Spec.Criteria {
+			if criterion.HasMetricValue.MetricName == from {
+				criterion.HasMetricValue.MetricName = to
+				scorecardsChanged = true
+			}
+		}
+	}
+	if scorecardsChanged {
+		if err := yaml.WriteScorecardStates(mapValues(scorecards), scorecarddtos.GetScorecardUniqueKey); err != nil {
+			return err
+		}
+	}
+
+	components, componentsErr := yaml.Parse(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey)
+	if componentsErr != nil {
+		return componentsErr
+	}
+
+	componentsChanged := false
+	for _, component := range components {
+		if metricSource, exists := component.Spec.MetricSources[from]; exists {
+			delete(component.Spec.MetricSources, from)
+			component.Spec.MetricSources[to] = metricSource
+			componentsChanged = true
+		}
+	}
+	if componentsChanged {
+		return writeComponentStates(mapValues(components), componentdtos.GetComponentUniqueKey)
+	}
+
+	return nil
+}
+
+func writeComponentStates(components []*componentdtos.ComponentDTO, getKey yaml.KeyExtractor[componentdtos.ComponentDTO]) error {
+	return yaml.WriteComponentStates(yaml.SortResults(components, getKey), getKey)
+}
+
+func readEntries[T any](input yaml.ParseInput, getKey yaml.KeyExtractor[T], kind string, getID func(*T) string) ([]stateEntry, error) {
+	definitions, parseErr := yaml.Parse(input, getKey)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	entries := make([]stateEntry, 0, len(definitions))
+	for _, name := range sortedKeys(definitions) {
+		entries = append(entries, stateEntry{Kind: kind, Name: name, ID: getID(definitions[name])})
+	}
+
+	return entries, nil
+}
+
+func encodeEntry[T any](input yaml.ParseInput, getKey yaml.KeyExtractor[T], kind, name string) ([]byte, error) {
+	definitions, parseErr := yaml.Parse(input, getKey)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+
+	definition, exists := definitions[name]
+	if !exists {
+		return nil, fmt.Errorf("%s %s not found in state", kind, name)
+	}
+
+	return yaml.Encode([]*T{definition})
+}
+
+func removeEntries[T any](
+	input yaml.ParseInput,
+	getKey yaml.KeyExtractor[T],
+	write func([]*T, yaml.KeyExtractor[T]) error,
+	kind string,
+	names []string,
+) error {
+	definitions, parseErr := yaml.Parse(input, getKey)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	for _, name := range names {
+		if _, exists := definitions[name]; !exists {
+			return fmt.Errorf("%s %s not found in state", kind, name)
+		}
+		delete(definitions, name)
+	}
+
+	return write(mapValues(definitions), getKey)
+}
+
+func moveEntry[T any](
+	input yaml.ParseInput,
+	getKey yaml.KeyExtractor[T],
+	write func([]*T, yaml.KeyExtractor[T]) error,
+	kind, from, to string,
+	rename func(*T),
+) error {
+	definitions, parseErr := yaml.Parse(input, getKey)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	definition, exists := definitions[from]
+	if !exists {
+		return fmt.Errorf("%s %s not found in state", kind, from)
+	}
+	if _, taken := definitions[to]; taken {
+		return fmt.Errorf("%s %s already exists in state", kind, to)
+	}
+
+	delete(definitions, from)
+	rename(definition)
+	definitions[to] = definition
+
+	return write(mapValues(definitions), getKey)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+type ForceUnlockHandler struct{}
+
+func NewForceUnlockHandler() *ForceUnlockHandler {
+	return &ForceUnlockHandler{}
+}
+
+// ForceUnlock removes the state lock held with lockID. Unless force is set, the user is asked
+// to confirm on input.
+func (h *ForceUnlockHandler) ForceUnlock(lockID string, force bool, input io.Reader) {
+	holder, lockErr := yaml.GetStateLock()
+	if lockErr != nil {
+		log.Fatalf("error: %v", lockErr)
+	}
+	if holder == nil {
+		log.Fatalf("error: state is not locked")
+	}
+	if holder.ID != lockID {
+		log.Fatalf("error: lock ID %q does not match the lock held with ID %q", lockID, holder.ID)
+	}
+
+	if !force {
+		fmt.Printf(
+			"The state is locked by %s since %s (operation: %s).\n"+
+				"Removing a lock held by a running command can corrupt the state.\n"+
+				"Do you really want to force-unlock? Only 'yes' will be accepted: ",
+			holder.Who, holder.Created.Format(time.RFC3339), holder.Operation,
+		)
+		answer, _ := bufio.NewReader(input).ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			fmt.Println("Force-unlock cancelled.")
+			return
+		}
+	}
+
+	if unlockErr := yaml.ForceUnlockState(lockID); unlockErr != nil {
+		log.Fatalf("error: %v", unlockErr)
+	}
+
+	fmt.Println("State has been successfully unlocked.")
+}
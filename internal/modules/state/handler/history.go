@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+const shortSHALength = 12
+
+type HistoryHandler struct{}
+
+func NewHistoryHandler() *HistoryHandler {
+	return &HistoryHandler{}
+}
+
+// History writes the recorded state versions, oldest first, with who recorded them, when and with which command.
+func (h *HistoryHandler) History(output io.Writer) error {
+	snapshots, historyErr := yaml.GetStateHistory()
+	if historyErr != nil {
+		return historyErr
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Fprintln(output, "No state version recorded yet.")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "VERSION\tCREATED\tWHO\tGIT SHA\tCOMMAND")
+	for _, snapshot := range snapshots {
+		command := snapshot.Command
+		if command == "" {
+			command = snapshot.Operation
+		}
+		fmt.Fprintf(
+			writer, "%d\t%s\t%s\t%s\t%s\n",
+			snapshot.Version, snapshot.Created.Format(time.RFC3339), snapshot.Who, shortSHA(snapshot.GitSHA), command,
+		)
+	}
+
+	return writer.Flush()
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+
+	return sha
+}
@@ -0,0 +1,337 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	componentrepository "github.com/motain/of-catalog/internal/modules/component/repository"
+	componentresources "github.com/motain/of-catalog/internal/modules/component/resources"
+	componentutils "github.com/motain/of-catalog/internal/modules/component/utils"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository"
+	metricresources "github.com/motain/of-catalog/internal/modules/metric/resources"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	scorecardrepository "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	scorecardresources "github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+type ImportHandler struct {
+	components componentrepository.RepositoryInterface
+	metrics    metricrepository.RepositoryInterface
+	scorecards scorecardrepository.RepositoryInterface
+}
+
+func NewImportHandler(
+	components componentrepository.RepositoryInterface,
+	metrics metricrepository.RepositoryInterface,
+	scorecards scorecardrepository.RepositoryInterface,
+) *ImportHandler {
+	return &ImportHandler{components: components, metrics: metrics, scorecards: scorecards}
+}
+
+// Import adopts the Compass objects matching the configuration into the state. Resources already
+// in the state are left untouched, as are resources missing in Compass, which apply will create.
+// Any other lookup error aborts the import.
+// kind and name optionally restrict the import to one kind or one resource.
+func (h *ImportHandler) Import(ctx context.Context, configRootLocation string, recursive bool, kind, name string) error {
+	if err := ValidateKind(kind); err != nil {
+		return err
+	}
+
+	parseInput := yaml.ParseInput{
+		RootLocation: configRootLocation,
+		Recursive:    recursive,
+	}
+
+	imported := 0
+	if includesKind(kind, MetricKind) {
+		count, importErr := h.importMetrics(ctx, parseInput, name)
+		if importErr != nil {
+			return importErr
+		}
+		imported += count
+	}
+	if includesKind(kind, ScorecardKind) {
+		count, importErr := h.importScorecards(ctx, parseInput, name)
+		if importErr != nil {
+			return importErr
+		}
+		imported += count
+	}
+	if includesKind(kind, ComponentKind) {
+		count, importErr := h.importComponents(ctx, parseInput, name)
+		if importErr != nil {
+			return importErr
+		}
+		imported += count
+	}
+
+	fmt.Printf("Import complete: %d resource(s) imported.\n", imported)
+
+	return nil
+}
+
+func (h *ImportHandler) importMetrics(ctx context.Context, parseInput yaml.ParseInput, name string) (int, error) {
+	configMetrics, errConfig := yaml.Parse(parseInput, metricdtos.GetMetricUniqueKey)
+	if errConfig != nil {
+		return 0, errConfig
+	}
+
+	stateMetrics, errState := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	imported := 0
+	for metricName, metric := range configMetrics {
+		if !h.shouldImport(MetricKind, metricName, name, stateMetrics[metricName] != nil) {
+			continue
+		}
+
+		remote, searchErr := h.metrics.Search(ctx, metricresources.Metric{Name: metric.Spec.Name})
+		if isNotFound(searchErr) {
+			fmt.Printf("Skipping metric %s: not found in Compass, apply will create it\n", metricName)
+			continue
+		}
+		if searchErr != nil {
+			return 0, fmt.Errorf("failed to import metric %s: %w", metricName, searchErr)
+		}
+
+		metric.Spec.ID = remote.ID
+		stateMetrics[metricName] = metric
+		imported++
+		fmt.Printf("Imported metric %s (%s)\n", metricName, remote.ID)
+	}
+
+	if imported == 0 {
+		return 0, nil
+	}
+
+	if err := yaml.WriteMetricStates(mapValues(stateMetrics), metricdtos.GetMetricUniqueKey); err != nil {
+		return 0, fmt.Errorf("error writing metrics to files: %w", err)
+	}
+
+	return imported, nil
+}
+
+func (h *ImportHandler) importScorecards(ctx context.Context, parseInput yaml.ParseInput, name string) (int, error) {
+	configScorecards, errConfig := yaml.Parse(parseInput, scorecarddtos.GetScorecardUniqueKey)
+	if errConfig != nil {
+		return 0, errConfig
+	}
+
+	stateMetrics, errMetricState := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+	if errMetricState != nil {
+		return 0, errMetricState
+	}
+
+	stateScorecards, errState := yaml.Parse(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	imported := 0
+	for scorecardName, scorecard := range configScorecards {
+		if !h.shouldImport(ScorecardKind, scorecardName, name, stateScorecards[scorecardName] != nil) {
+			continue
+		}
+
+		remote, searchErr := h.scorecards.Search(ctx, scorecardresources.Scorecard{Name: scorecard.Spec.Name})
+		if isNotFound(searchErr) {
+			fmt.Printf("Skipping scorecard %s: not found in Compass, apply will create it\n", scorecardName)
+			continue
+		}
+		if searchErr != nil {
+			return 0, fmt.Errorf("failed to import scorecard %s: %w", scorecardName, searchErr)
+		}
+
+		remoteCriteria := make(map[string]string, len(remote.Criteria))
+		for _, criterion := range remote.Criteria {
+			remoteCriteria[criterion.HasMetricValue.Name] = criterion.HasMetricValue.ID
+		}
+
+		scorecard.Spec.ID = remote.ID
+		for _, criterion := range scorecard.Spec.Criteria {
+			criterion.HasMetricValue.ID = remoteCriteria[criterion.HasMetricValue.Name]
+			if stateMetric, exists := stateMetrics[criterion.HasMetricValue.MetricName]; exists {
+				criterion.HasMetricValue.MetricDefinitionId = stateMetric.Spec.ID
+			}
+		}
+
+		stateScorecards[scorecardName] = scorecard
+		imported++
+		fmt.Printf("Imported scorecard %s (%s)\n", scorecardName, *remote.ID)
+	}
+
+	if imported == 0 {
+		return 0, nil
+	}
+
+	if err := yaml.WriteScorecardStates(mapValues(stateScorecards), scorecarddtos.GetScorecardUniqueKey); err != nil {
+		return 0, fmt.Errorf("error writing scorecards to files: %w", err)
+	}
+
+	return imported, nil
+}
+
+func (h *ImportHandler) importComponents(ctx context.Context, parseInput yaml.ParseInput, name string) (int, error) {
+	configComponents, errConfig := yaml.Parse(parseInput, componentdtos.GetComponentUniqueKey)
+	if errConfig != nil {
+		return 0, errConfig
+	}
+
+	stateComponents, errState := yaml.Parse(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	imported := 0
+	for componentName, component := range configComponents {
+		if !h.shouldImport(ComponentKind, componentName, name, stateComponents[componentName] != nil) {
+			continue
+		}
+
+		slug := componentutils.GetSlug(component.Spec.Name, component.Spec.TypeID)
+		remote, getErr := h.components.GetBySlug(ctx, componentresources.Component{Slug: slug})
+		if isNotFound(getErr) {
+			fmt.Printf("Skipping component %s: not found in Compass, apply will create it\n", componentName)
+			continue
+		}
+		if getErr != nil {
+			return 0, fmt.Errorf("failed to import component %s: %w", componentName, getErr)
+		}
+
+		component.Spec.ID = remote.ID
+		component.Spec.Slug = slug
+		component.Spec.Links = importLinks(component.Spec.Links, remote.Links)
+		component.Spec.MetricSources = importMetricSources(component, remote.MetricSources)
+
+		documents, documentsErr := h.components.GetDocuments(ctx, *remote)
+		if documentsErr != nil {
+			fmt.Printf("Warning: documents of component %s not imported: %v\n", componentName, documentsErr)
+		}
+		component.Spec.Documents = importDocuments(component.Spec.Documents, documents)
+
+		stateComponents[componentName] = component
+		imported++
+		fmt.Printf("Imported component %s (%s)\n", componentName, remote.ID)
+	}
+
+	if imported == 0 {
+		return 0, nil
+	}
+
+	result := yaml.SortResults(mapValues(stateComponents), componentdtos.GetComponentUniqueKey)
+	if err := yaml.WriteComponentStates(result, componentdtos.GetComponentUniqueKey); err != nil {
+		return 0, fmt.Errorf("error writing components to files: %w", err)
+	}
+
+	return imported, nil
+}
+
+func (h *ImportHandler) shouldImport(kind, resourceName, nameFilter string, inState bool) bool {
+	if nameFilter != "" && resourceName != nameFilter {
+		return false
+	}
+
+	if inState {
+		fmt.Printf("Skipping %s %s: already in state\n", kind, resourceName)
+		return false
+	}
+
+	return true
+}
+
+// importLinks keeps the configured links, taking the identifiers of the matching Compass links.
+func importLinks(configLinks []componentdtos.Link, remoteLinks []componentresources.Link) []componentdtos.Link {
+	remoteIDs := make(map[string]string, len(remoteLinks))
+	for _, link := range remoteLinks {
+		remoteIDs[link.Type+link.Name+link.URL] = link.ID
+	}
+
+	links := make([]componentdtos.Link, len(configLinks))
+	for i, link := range configLinks {
+		link.ID = remoteIDs[link.Type+link.Name+link.URL]
+		links[i] = link
+	}
+
+	return links
+}
+
+func importMetricSources(
+	component *componentdtos.ComponentDTO,
+	remoteMetricSources map[string]*componentresources.MetricSource,
+) map[string]*componentdtos.MetricSourceDTO {
+	if len(remoteMetricSources) == 0 {
+		return nil
+	}
+
+	metricSources := make(map[string]*componentdtos.MetricSourceDTO, len(remoteMetricSources))
+	for metricName, metricSource := range remoteMetricSources {
+		metricSources[metricName] = &componentdtos.MetricSourceDTO{
+			ID:     metricSource.ID,
+			Name:   componentutils.GetMetricSourceIdentifier(metricName, component.Metadata.Name, component.Metadata.ComponentType),
+			Metric: metricSource.Metric,
+		}
+	}
+
+	return metricSources
+}
+
+// importDocuments keeps the configured documents, taking the identifiers of the Compass documents
+// with the same title, and adds the documents only found in Compass.
+func importDocuments(configDocuments []*componentdtos.Document, remoteDocuments []componentresources.Document) []*componentdtos.Document {
+	remoteByTitle := make(map[string]componentresources.Document, len(remoteDocuments))
+	for _, document := range remoteDocuments {
+		remoteByTitle[document.Title] = document
+	}
+
+	documents := make([]*componentdtos.Document, 0, len(remoteDocuments))
+	for _, document := range configDocuments {
+		if remote, exists := remoteByTitle[document.Title]; exists {
+			document.ID = remote.ID
+			document.DocumentationCategoryId = remote.DocumentationCategoryId
+			delete(remoteByTitle, document.Title)
+		}
+		documents = append(documents, document)
+	}
+
+	for _, remote := range remoteDocuments {
+		if _, pending := remoteByTitle[remote.Title]; !pending {
+			continue
+		}
+		documents = append(documents, &componentdtos.Document{
+			ID:                      remote.ID,
+			Title:                   remote.Title,
+			Type:                    "Other",
+			DocumentationCategoryId: remote.DocumentationCategoryId,
+			URL:                     remote.URL,
+		})
+	}
+
+	return componentdtos.SortAndRemoveDuplicateDocuments(documents)
+}
+
+// isNotFound tells whether err reports a resource missing in Compass, or in the file catalog.
+func isNotFound(err error) bool {
+	var compassErr *compassservice.Error
+	if errors.As(err, &compassErr) {
+		return compassErr.Class == compassservice.ErrorClassNotFound
+	}
+
+	return errors.Is(err, catalogservice.ErrNotFound)
+}
+
+func mapValues[T any](m map[string]*T) []*T {
+	values := make([]*T, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+
+	return values
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ComponentKind = "component"
+	MetricKind    = "metric"
+	ScorecardKind = "scorecard"
+)
+
+// Kinds lists the kinds of resources kept in the state, in dependency order.
+var Kinds = []string{MetricKind, ScorecardKind, ComponentKind}
+
+// ValidateKind returns an error when kind is neither empty, meaning all kinds, nor a known kind.
+func ValidateKind(kind string) error {
+	if kind == "" {
+		return nil
+	}
+
+	for _, known := range Kinds {
+		if kind == known {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown kind %q, expected one of %s", kind, strings.Join(Kinds, ", "))
+}
+
+// validateRequiredKind returns an error unless kind is a known kind.
+func validateRequiredKind(kind string) error {
+	if kind == "" {
+		return fmt.Errorf("kind required, expected one of %s", strings.Join(Kinds, ", "))
+	}
+
+	return ValidateKind(kind)
+}
+
+func includesKind(filter, kind string) bool {
+	return filter == "" || filter == kind
+}
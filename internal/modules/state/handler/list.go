@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"text/tabwriter"
+)
+
+type ListHandler struct{}
+
+func NewListHandler() *ListHandler {
+	return &ListHandler{}
+}
+
+// List writes the resources recorded in the state, of every kind unless kind is set, with their Compass IDs.
+func (h *ListHandler) List(kind string, output io.Writer) {
+	if err := ValidateKind(kind); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	writer := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	for _, known := range Kinds {
+		if !includesKind(kind, known) {
+			continue
+		}
+
+		entries, readErr := readStateEntries(known)
+		if readErr != nil {
+			log.Fatalf("error: %v", readErr)
+		}
+
+		for _, entry := range entries {
+			fmt.Fprintf(writer, "%s/%s\t%s\n", entry.Kind, entry.Name, entry.ID)
+		}
+	}
+	writer.Flush()
+}
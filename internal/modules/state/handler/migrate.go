@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+type MigrateHandler struct{}
+
+func NewMigrateHandler() *MigrateHandler {
+	return &MigrateHandler{}
+}
+
+// Migrate rewrites the state files written with an older state version in the current one, moving the
+// legacy single file state of a kind to its directory. With dryRun it only writes what would be migrated.
+func (h *MigrateHandler) Migrate(dryRun bool, output io.Writer) error {
+	outdated, outdatedErr := yaml.GetOutdatedStateFiles()
+	if outdatedErr != nil {
+		return outdatedErr
+	}
+
+	if len(outdated) == 0 {
+		fmt.Fprintf(output, "State is up to date (state version %d).\n", yaml.CurrentStateVersion)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Fprintf(output, "Would migrate %d state file(s) to state version %d:\n", len(outdated), yaml.CurrentStateVersion)
+	} else {
+		fmt.Fprintf(output, "Migrating %d state file(s) to state version %d:\n", len(outdated), yaml.CurrentStateVersion)
+	}
+
+	oldest := yaml.CurrentStateVersion
+	kinds := make(map[string]bool, len(Kinds))
+	legacyFiles := make(map[string][]string, len(Kinds))
+	for _, file := range outdated {
+		oldest = min(oldest, file.Version)
+		if !file.Legacy {
+			kinds[path.Base(path.Dir(file.Path))] = true
+			fmt.Fprintf(output, "  %s: state version %d => %d\n", file.Path, file.Version, yaml.CurrentStateVersion)
+			continue
+		}
+
+		kind := yaml.GetLegacyStateKind(file.Path)
+		kinds[kind] = true
+		legacyFiles[kind] = append(legacyFiles[kind], file.Path)
+		fmt.Fprintf(
+			output, "  %s: state version %d => %d, moved to %s/\n",
+			file.Path, file.Version, yaml.CurrentStateVersion, path.Join(yaml.StateLocation, kind),
+		)
+	}
+
+	for _, migration := range yaml.GetStateMigrations(oldest) {
+		fmt.Fprintf(output, "State version %d => %d: %s\n", migration.From, migration.From+1, migration.Description)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	for _, kind := range Kinds {
+		if !kinds[kind] {
+			continue
+		}
+		if err := migrateStateKind(kind, legacyFiles[kind]); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(output, "Migrated %d state file(s).\n", len(outdated))
+
+	return nil
+}
+
+func migrateStateKind(kind string, legacyFiles []string) error {
+	switch kind {
+	case MetricKind:
+		return migrateEntries(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey, yaml.WriteMetricStates[metricdtos.MetricDTO], kind, legacyFiles)
+	case ScorecardKind:
+		return migrateEntries(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey, yaml.WriteScorecardStates[scorecarddtos.ScorecardDTO], kind, legacyFiles)
+	case ComponentKind:
+		return migrateEntries(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey, writeComponentStates, kind, legacyFiles)
+	}
+
+	return ValidateKind(kind)
+}
+
+// migrateEntries rewrites the state of a kind, which is migrated on load, adding the resources of its legacy files.
+func migrateEntries[T any](
+	input yaml.ParseInput,
+	getKey yaml.KeyExtractor[T],
+	write func([]*T, yaml.KeyExtractor[T]) error,
+	kind string,
+	legacyFiles []string,
+) error {
+	definitions, parseErr := yaml.Parse(input, getKey)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	if len(legacyFiles) > 0 {
+		// Once split, the legacy files are no longer read: merging them could bring back removed resources.
+		if len(definitions) > 0 {
+			return fmt.Errorf(
+				"both %v and %s/ hold the %s state, remove the stale legacy file(s) and run migrate again",
+				legacyFiles, input.RootLocation, kind,
+			)
+		}
+
+		legacy, legacyErr := yaml.Parse(yaml.GetStateInput(yaml.StateLocation), getKey)
+		if legacyErr != nil {
+			return legacyErr
+		}
+		definitions = legacy
+	}
+
+	if err := write(mapValues(definitions), getKey); err != nil {
+		return err
+	}
+
+	for _, filePath := range legacyFiles {
+		if err := yaml.GetStateBackend().Delete(filePath); err != nil {
+			return fmt.Errorf("failed to remove legacy state file %s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
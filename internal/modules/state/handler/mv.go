@@ -0,0 +1,26 @@
+package handler
+
+import "fmt"
+
+type MvHandler struct{}
+
+func NewMvHandler() *MvHandler {
+	return &MvHandler{}
+}
+
+// Mv renames the resource of kind named from to to in the state, keeping its Compass identifiers and
+// name. Once the resource is renamed in the configuration, the next apply renames the Compass object
+// instead of deleting it and creating a new one.
+func (h *MvHandler) Mv(kind, from, to string) error {
+	if err := validateRequiredKind(kind); err != nil {
+		return err
+	}
+
+	if err := moveStateEntry(kind, from, to); err != nil {
+		return err
+	}
+
+	fmt.Printf("Moved %s %s to %s\n", kind, from, to)
+
+	return nil
+}
@@ -0,0 +1,448 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	componenthandler "github.com/motain/of-catalog/internal/modules/component/handler"
+	componentrepository "github.com/motain/of-catalog/internal/modules/component/repository"
+	componentresources "github.com/motain/of-catalog/internal/modules/component/resources"
+	componentutils "github.com/motain/of-catalog/internal/modules/component/utils"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	metrichandler "github.com/motain/of-catalog/internal/modules/metric/handler"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository"
+	metricresources "github.com/motain/of-catalog/internal/modules/metric/resources"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	scorecardhandler "github.com/motain/of-catalog/internal/modules/scorecard/handler"
+	scorecardrepository "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	scorecardresources "github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/utils/drift"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+type RefreshHandler struct {
+	components     componentrepository.RepositoryInterface
+	metrics        metricrepository.RepositoryInterface
+	scorecards     scorecardrepository.RepositoryInterface
+	componentApply *componenthandler.ApplyHandler
+	metricApply    *metrichandler.ApplyHandler
+	scorecardApply *scorecardhandler.ApplyHandler
+}
+
+func NewRefreshHandler(
+	components componentrepository.RepositoryInterface,
+	metrics metricrepository.RepositoryInterface,
+	scorecards scorecardrepository.RepositoryInterface,
+	componentApply *componenthandler.ApplyHandler,
+	metricApply *metrichandler.ApplyHandler,
+	scorecardApply *scorecardhandler.ApplyHandler,
+) *RefreshHandler {
+	return &RefreshHandler{
+		components:     components,
+		metrics:        metrics,
+		scorecards:     scorecards,
+		componentApply: componentApply,
+		metricApply:    metricApply,
+		scorecardApply: scorecardApply,
+	}
+}
+
+// Refresh reads the objects recorded in the state from Compass and reports the fields edited
+// outside of this tool. With updateState the remote values are written to the state, so that the
+// next apply restores the configuration; names are the keys of the state, so a name changed in Compass
+// is recorded as the name the object was moved from, which the next apply renames back.
+// Only resources Compass reports as not found are treated as missing; any other lookup error aborts
+// the refresh before the state is written. It returns the number of resources that drifted.
+func (h *RefreshHandler) Refresh(ctx context.Context, kind, name string, updateState bool) (int, error) {
+	if err := ValidateKind(kind); err != nil {
+		return 0, err
+	}
+
+	drifted := 0
+	if includesKind(kind, MetricKind) {
+		count, refreshErr := h.refreshMetrics(ctx, name, updateState)
+		if refreshErr != nil {
+			return drifted, refreshErr
+		}
+		drifted += count
+	}
+	if includesKind(kind, ScorecardKind) {
+		count, refreshErr := h.refreshScorecards(ctx, name, updateState)
+		if refreshErr != nil {
+			return drifted, refreshErr
+		}
+		drifted += count
+	}
+	if includesKind(kind, ComponentKind) {
+		count, refreshErr := h.refreshComponents(ctx, name, updateState)
+		if refreshErr != nil {
+			return drifted, refreshErr
+		}
+		drifted += count
+	}
+
+	switch {
+	case drifted == 0:
+		fmt.Println("No changes. Compass matches the state.")
+	case updateState:
+		fmt.Printf("Refresh complete: %d resource(s) updated in state.\n", drifted)
+	default:
+		fmt.Printf("Refresh complete: %d resource(s) drifted. Use --update-state to record them, or --apply to restore the configuration.\n", drifted)
+	}
+
+	return drifted, nil
+}
+
+// ValidateReapply returns an error when name cannot be honoured by Reapply: the apply of metrics and
+// scorecards is not limited to one object, only the apply of components is.
+func ValidateReapply(kind, name string) error {
+	if name != "" && kind != ComponentKind {
+		return fmt.Errorf("NAME cannot be used with --apply for %s, which applies every %s of the configuration: use --update-state, then apply", kind, kind)
+	}
+
+	return nil
+}
+
+// Reapply applies the configuration of the refreshed kinds, pushing it over the remote edits
+// recorded in the state. It never deletes anything. Only components can be limited to name.
+func (h *RefreshHandler) Reapply(ctx context.Context, configRootLocation string, recursive bool, kind, name string) error {
+	if err := ValidateReapply(kind, name); err != nil {
+		return err
+	}
+
+	noDelete := drift.DeletePolicy{}
+	if includesKind(kind, MetricKind) {
+		if err := h.metricApply.Apply(ctx, configRootLocation, yaml.StateLocation, recursive, noDelete); err != nil {
+			return err
+		}
+	}
+	if includesKind(kind, ScorecardKind) {
+		if err := h.scorecardApply.Apply(ctx, configRootLocation, yaml.StateLocation, recursive, noDelete); err != nil {
+			return err
+		}
+	}
+	if includesKind(kind, ComponentKind) {
+		return h.componentApply.Apply(ctx, configRootLocation, yaml.StateLocation, recursive, name, noDelete)
+	}
+
+	return nil
+}
+
+func (h *RefreshHandler) refreshMetrics(ctx context.Context, name string, updateState bool) (int, error) {
+	stateMetrics, errState := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	drifted := 0
+	for _, metricName := range sortedKeys(stateMetrics) {
+		if name != "" && metricName != name {
+			continue
+		}
+
+		metric := stateMetrics[metricName]
+		remote, searchErr := h.metrics.Search(ctx, metricresources.Metric{ID: metric.Spec.ID, Name: metric.Spec.Name})
+		if isNotFound(searchErr) {
+			drifted++
+			reportMissing(MetricKind, metricName, searchErr)
+			if updateState {
+				delete(stateMetrics, metricName)
+			}
+			continue
+		}
+		if searchErr != nil {
+			return 0, fmt.Errorf("failed to refresh metric %s: %w", metricName, searchErr)
+		}
+
+		drifts := compareMetric(metric, remote)
+		if len(drifts) == 0 {
+			continue
+		}
+
+		drifted++
+		reportDrift(MetricKind, metricName, drifts)
+		if updateState {
+			metric.Metadata.MovedFrom = movedFrom(remote.Name, metric.Spec.Name)
+			metric.Spec.Description = remote.Description
+			metric.Spec.Format.Unit = remote.Format.Unit
+		}
+	}
+
+	if updateState && drifted > 0 {
+		if err := yaml.WriteMetricStates(mapValues(stateMetrics), metricdtos.GetMetricUniqueKey); err != nil {
+			return 0, fmt.Errorf("error writing metrics to files: %w", err)
+		}
+	}
+
+	return drifted, nil
+}
+
+func (h *RefreshHandler) refreshScorecards(ctx context.Context, name string, updateState bool) (int, error) {
+	stateScorecards, errState := yaml.Parse(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	drifted := 0
+	for _, scorecardName := range sortedKeys(stateScorecards) {
+		if name != "" && scorecardName != name {
+			continue
+		}
+
+		scorecard := stateScorecards[scorecardName]
+		remote, searchErr := h.scorecards.Search(ctx, scorecardresources.Scorecard{ID: scorecard.Spec.ID, Name: scorecard.Spec.Name})
+		if isNotFound(searchErr) {
+			drifted++
+			reportMissing(ScorecardKind, scorecardName, searchErr)
+			if updateState {
+				delete(stateScorecards, scorecardName)
+			}
+			continue
+		}
+		if searchErr != nil {
+			return 0, fmt.Errorf("failed to refresh scorecard %s: %w", scorecardName, searchErr)
+		}
+
+		drifts := compareScorecard(scorecard, remote)
+		if len(drifts) == 0 {
+			continue
+		}
+
+		drifted++
+		reportDrift(ScorecardKind, scorecardName, drifts)
+		if updateState {
+			updateScorecardState(scorecard, remote)
+		}
+	}
+
+	if updateState && drifted > 0 {
+		if err := yaml.WriteScorecardStates(mapValues(stateScorecards), scorecarddtos.GetScorecardUniqueKey); err != nil {
+			return 0, fmt.Errorf("error writing scorecards to files: %w", err)
+		}
+	}
+
+	return drifted, nil
+}
+
+func (h *RefreshHandler) refreshComponents(ctx context.Context, name string, updateState bool) (int, error) {
+	stateComponents, errState := yaml.Parse(yaml.GetComponentStateInput(), componentdtos.GetComponentUniqueKey)
+	if errState != nil {
+		return 0, errState
+	}
+
+	drifted := 0
+	for _, componentName := range sortedKeys(stateComponents) {
+		if name != "" && componentName != name {
+			continue
+		}
+
+		component := stateComponents[componentName]
+		slug := component.Spec.Slug
+		if slug == "" {
+			slug = componentutils.GetSlug(component.Spec.Name, component.Spec.TypeID)
+		}
+
+		remote, getErr := h.components.GetBySlug(ctx, componentresources.Component{Slug: slug})
+		if isNotFound(getErr) {
+			drifted++
+			reportMissing(ComponentKind, componentName, getErr)
+			if updateState {
+				delete(stateComponents, componentName)
+			}
+			continue
+		}
+		if getErr != nil {
+			return 0, fmt.Errorf("failed to refresh component %s: %w", componentName, getErr)
+		}
+
+		drifts := compareComponent(component, remote)
+		if len(drifts) == 0 {
+			continue
+		}
+
+		drifted++
+		reportDrift(ComponentKind, componentName, drifts)
+		if updateState {
+			updateComponentState(component, remote)
+		}
+	}
+
+	if updateState && drifted > 0 {
+		result := yaml.SortResults(mapValues(stateComponents), componentdtos.GetComponentUniqueKey)
+		if err := yaml.WriteComponentStates(result, componentdtos.GetComponentUniqueKey); err != nil {
+			return 0, fmt.Errorf("error writing components to files: %w", err)
+		}
+	}
+
+	return drifted, nil
+}
+
+func compareMetric(state *metricdtos.MetricDTO, remote *metricresources.Metric) []drift.Change {
+	drifts := make([]drift.Change, 0)
+	drifts = compareField(drifts, "name", metricdtos.GetMetricCompassName(state), remote.Name)
+	drifts = compareField(drifts, "description", state.Spec.Description, remote.Description)
+	drifts = compareField(drifts, "format.unit", state.Spec.Format.Unit, remote.Format.Unit)
+
+	return drifts
+}
+
+func compareScorecard(state *scorecarddtos.ScorecardDTO, remote *scorecardresources.Scorecard) []drift.Change {
+	drifts := make([]drift.Change, 0)
+	drifts = compareField(drifts, "name", scorecarddtos.GetScorecardCompassName(state), remote.Name)
+	drifts = compareField(drifts, "description", state.Spec.Description, remote.Description)
+	drifts = compareField(drifts, "ownerId", state.Spec.OwnerID, remote.OwnerID)
+	drifts = compareField(drifts, "state", state.Spec.State, remote.State)
+	drifts = compareField(drifts, "componentTypeIds", sortedCopy(state.Spec.ComponentTypeIDs), sortedCopy(remote.ComponentTypeIDs))
+	drifts = compareField(drifts, "importance", state.Spec.Importance, remote.Importance)
+	drifts = compareField(drifts, "scoringStrategyType", state.Spec.ScoringStrategyType, remote.ScoringStrategyType)
+
+	remoteCriteria := make(map[string]scorecardresources.MetricValue, len(remote.Criteria))
+	for _, criterion := range remote.Criteria {
+		remoteCriteria[criterion.HasMetricValue.Name] = criterion.HasMetricValue
+	}
+
+	for _, criterion := range state.Spec.Criteria {
+		stateValue := criterion.HasMetricValue
+		field := fmt.Sprintf("criteria[%s]", stateValue.Name)
+		remoteValue, exists := remoteCriteria[stateValue.Name]
+		if !exists {
+			drifts = append(drifts, drift.Change{Path: field, Old: "present"})
+			continue
+		}
+		delete(remoteCriteria, stateValue.Name)
+
+		drifts = compareField(drifts, field+".weight", stateValue.Weight, remoteValue.Weight)
+		drifts = compareField(drifts, field+".metricDefinitionId", stateValue.MetricDefinitionId, remoteValue.MetricDefinitionId)
+		drifts = compareField(drifts, field+".comparator", stateValue.Comparator, remoteValue.Comparator)
+		drifts = compareField(drifts, field+".comparatorValue", stateValue.ComparatorValue, remoteValue.ComparatorValue)
+	}
+
+	for _, criterionName := range sortedKeys(remoteCriteria) {
+		drifts = append(drifts, drift.Change{Path: fmt.Sprintf("criteria[%s]", criterionName), New: "present"})
+	}
+
+	return drifts
+}
+
+func compareComponent(state *componentdtos.ComponentDTO, remote *componentresources.Component) []drift.Change {
+	drifts := make([]drift.Change, 0)
+	drifts = compareField(drifts, "name", componentdtos.GetComponentCompassName(state), remote.Name)
+	drifts = compareField(drifts, "description", state.Spec.Description, remote.Description)
+	drifts = compareField(drifts, "typeId", state.Spec.TypeID, remote.TypeID)
+	drifts = compareField(drifts, "ownerId", state.Spec.OwnerID, remote.OwnerID)
+	drifts = compareField(drifts, "labels", sortedCopy(state.Spec.Labels), sortedCopy(remote.Labels))
+
+	stateLinks := make(map[string]string, len(state.Spec.Links))
+	for _, link := range state.Spec.Links {
+		stateLinks[linkKey(link.Type, link.Name)] = link.URL
+	}
+	remoteLinks := make(map[string]string, len(remote.Links))
+	for _, link := range remote.Links {
+		remoteLinks[linkKey(link.Type, link.Name)] = link.URL
+	}
+
+	for _, key := range sortedKeys(stateLinks) {
+		var remoteURL interface{}
+		if url, exists := remoteLinks[key]; exists {
+			remoteURL = url
+		}
+		drifts = compareField(drifts, fmt.Sprintf("links[%s]", key), stateLinks[key], remoteURL)
+	}
+	for _, key := range sortedKeys(remoteLinks) {
+		if _, exists := stateLinks[key]; !exists {
+			drifts = append(drifts, drift.Change{Path: fmt.Sprintf("links[%s]", key), New: remoteLinks[key]})
+		}
+	}
+
+	return drifts
+}
+
+func updateScorecardState(state *scorecarddtos.ScorecardDTO, remote *scorecardresources.Scorecard) {
+	state.Metadata.MovedFrom = movedFrom(remote.Name, state.Spec.Name)
+	state.Spec.Description = remote.Description
+	state.Spec.OwnerID = remote.OwnerID
+	state.Spec.State = remote.State
+	state.Spec.ComponentTypeIDs = remote.ComponentTypeIDs
+	state.Spec.Importance = remote.Importance
+	state.Spec.ScoringStrategyType = remote.ScoringStrategyType
+
+	metricNames := make(map[string]string, len(state.Spec.Criteria))
+	for _, criterion := range state.Spec.Criteria {
+		metricNames[criterion.HasMetricValue.MetricDefinitionId] = criterion.HasMetricValue.MetricName
+	}
+
+	criteria := make([]*scorecarddtos.Criterion, len(remote.Criteria))
+	for i, criterion := range remote.Criteria {
+		criteria[i] = &scorecarddtos.Criterion{
+			HasMetricValue: scorecarddtos.MetricValue{
+				ID:                 criterion.HasMetricValue.ID,
+				Weight:             criterion.HasMetricValue.Weight,
+				Name:               criterion.HasMetricValue.Name,
+				MetricName:         metricNames[criterion.HasMetricValue.MetricDefinitionId],
+				MetricDefinitionId: criterion.HasMetricValue.MetricDefinitionId,
+				ComparatorValue:    criterion.HasMetricValue.ComparatorValue,
+				Comparator:         criterion.HasMetricValue.Comparator,
+			},
+		}
+	}
+	state.Spec.Criteria = criteria
+}
+
+func updateComponentState(state *componentdtos.ComponentDTO, remote *componentresources.Component) {
+	state.Metadata.MovedFrom = movedFrom(remote.Name, state.Spec.Name)
+	state.Spec.Description = remote.Description
+	state.Spec.TypeID = remote.TypeID
+	state.Spec.OwnerID = remote.OwnerID
+	state.Spec.Labels = remote.Labels
+
+	links := make([]componentdtos.Link, len(remote.Links))
+	for i, link := range remote.Links {
+		links[i] = componentdtos.Link{ID: link.ID, Name: link.Name, Type: link.Type, URL: link.URL}
+	}
+	state.Spec.Links = componentdtos.UniqueAndSortLinks(links)
+}
+
+func compareField(drifts []drift.Change, field string, state, remote interface{}) []drift.Change {
+	if reflect.DeepEqual(state, remote) {
+		return drifts
+	}
+
+	return append(drifts, drift.Change{Path: field, Old: state, New: remote})
+}
+
+func reportDrift(kind, name string, drifts []drift.Change) {
+	fmt.Printf("~ %s %s changed in Compass:\n", kind, name)
+	for _, change := range drifts {
+		fmt.Printf("    %s\n", change)
+	}
+}
+
+func reportMissing(kind, name string, err error) {
+	fmt.Printf("- %s %s not found in Compass: %v\n", kind, name, err)
+}
+
+func linkKey(linkType, name string) string {
+	return linkType + " " + name
+}
+
+// sortedCopy returns a sorted copy of values, treating nil and empty slices alike.
+func sortedCopy(values []string) []string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
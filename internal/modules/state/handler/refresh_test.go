@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository/mocks"
+	metricresources "github.com/motain/of-catalog/internal/modules/metric/resources"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshHandler_Refresh_MissingMetric(t *testing.T) {
+	tests := []struct {
+		name            string
+		searchErr       error
+		expectedError   string
+		expectedDrifted int
+		expectedState   []string
+	}{
+		{
+			name:            "not found in Compass",
+			searchErr:       &compassservice.Error{Class: compassservice.ErrorClassNotFound, Err: errors.New("metric not found")},
+			expectedDrifted: 1,
+			expectedState:   []string{"coverage"},
+		},
+		{
+			name:            "not found in the file catalog",
+			searchErr:       fmt.Errorf("Search error for availability: metric metric-1 %w", catalogservice.ErrNotFound),
+			expectedDrifted: 1,
+			expectedState:   []string{"coverage"},
+		},
+		{
+			name:          "lookup failure",
+			searchErr:     &compassservice.Error{Class: compassservice.ErrorClassTransport, Err: errors.New("connection reset")},
+			expectedError: "failed to refresh metric availability: connection reset",
+			expectedState: []string{"availability", "coverage"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useStateDir(t)
+			require.NoError(t, yaml.WriteMetricStates(
+				[]*metricdtos.MetricDTO{metricState("availability", "metric-1"), metricState("coverage", "metric-2")},
+				metricdtos.GetMetricUniqueKey,
+			))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			metrics := metricrepository.NewMockRepositoryInterface(ctrl)
+			metrics.EXPECT().Search(gomock.Any(), gomock.Any()).Return(nil, tt.searchErr)
+
+			refreshHandler := handler.NewRefreshHandler(nil, metrics, nil, nil, nil, nil)
+			drifted, err := refreshHandler.Refresh(context.Background(), handler.MetricKind, "availability", true)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedDrifted, drifted)
+
+			state, parseErr := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+			require.NoError(t, parseErr)
+			names := make([]string, 0, len(state))
+			for name := range state {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.expectedState, names)
+		})
+	}
+}
+
+func TestRefreshHandler_Refresh_RenamedMetric(t *testing.T) {
+	tests := []struct {
+		name              string
+		stateMovedFrom    string
+		remoteName        string
+		expectedDrifted   int
+		expectedMovedFrom string
+	}{
+		{
+			name:              "records the name changed in Compass",
+			remoteName:        "availability-renamed",
+			expectedDrifted:   1,
+			expectedMovedFrom: "availability-renamed",
+		},
+		{
+			name:            "clears the name once renamed back in Compass",
+			stateMovedFrom:  "availability-renamed",
+			remoteName:      "availability",
+			expectedDrifted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useStateDir(t)
+			metric := metricState("availability", "metric-1")
+			metric.Metadata.MovedFrom = tt.stateMovedFrom
+			require.NoError(t, yaml.WriteMetricStates([]*metricdtos.MetricDTO{metric}, metricdtos.GetMetricUniqueKey))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			metrics := metricrepository.NewMockRepositoryInterface(ctrl)
+			metrics.EXPECT().Search(gomock.Any(), gomock.Any()).Times(2).Return(&metricresources.Metric{ID: "metric-1", Name: tt.remoteName}, nil)
+
+			refreshHandler := handler.NewRefreshHandler(nil, metrics, nil, nil, nil, nil)
+			drifted, err := refreshHandler.Refresh(context.Background(), handler.MetricKind, "", true)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedDrifted, drifted)
+
+			state, parseErr := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+			require.NoError(t, parseErr)
+			require.Contains(t, state, "availability", "the state stays keyed by the configured name")
+			assert.Equal(t, tt.expectedMovedFrom, state["availability"].Metadata.MovedFrom)
+
+			drifted, err = refreshHandler.Refresh(context.Background(), handler.MetricKind, "", true)
+			require.NoError(t, err)
+			assert.Zero(t, drifted, "the recorded name is not reported again")
+		})
+	}
+}
+
+func TestRefreshHandler_Reapply_Name(t *testing.T) {
+	assert.NoError(t, handler.ValidateReapply("", ""))
+	assert.NoError(t, handler.ValidateReapply(handler.MetricKind, ""))
+	assert.NoError(t, handler.ValidateReapply(handler.ComponentKind, "my-service"))
+	assert.EqualError(
+		t, handler.ValidateReapply(handler.MetricKind, "coverage"),
+		"NAME cannot be used with --apply for metric, which applies every metric of the configuration: use --update-state, then apply",
+	)
+
+	refreshHandler := handler.NewRefreshHandler(nil, nil, nil, nil, nil, nil)
+	assert.Error(t, refreshHandler.Reapply(context.Background(), "config", false, handler.ScorecardKind, "Service Readiness"), "nothing is applied")
+}
+
+func useStateDir(t *testing.T) {
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { os.Chdir(workingDir) })
+
+	previousBackend := yaml.GetStateBackend()
+	yaml.SetStateBackend(statebackend.NewFilesystemBackend())
+	t.Cleanup(func() { yaml.SetStateBackend(previousBackend) })
+}
+
+func metricState(name, id string) *metricdtos.MetricDTO {
+	metric := &metricdtos.MetricDTO{APIVersion: "of-catalog/v1alpha1", Kind: "Metric"}
+	metric.Metadata.Name = name
+	metric.Spec.ID = id
+	metric.Spec.Name = name
+
+	return metric
+}
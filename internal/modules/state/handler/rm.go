@@ -0,0 +1,27 @@
+package handler
+
+import "fmt"
+
+type RmHandler struct{}
+
+func NewRmHandler() *RmHandler {
+	return &RmHandler{}
+}
+
+// Rm forgets the resources of kind named names. The Compass objects are left in place: the next
+// apply creates them again unless they are removed from the configuration, or imported back.
+func (h *RmHandler) Rm(kind string, names []string) error {
+	if err := validateRequiredKind(kind); err != nil {
+		return err
+	}
+
+	if err := removeStateEntries(kind, names); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fmt.Printf("Removed %s %s from state\n", kind, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	componentdtos "github.com/motain/of-catalog/internal/modules/component/dtos"
+	componentrepository "github.com/motain/of-catalog/internal/modules/component/repository"
+	componentresources "github.com/motain/of-catalog/internal/modules/component/resources"
+	componentutils "github.com/motain/of-catalog/internal/modules/component/utils"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository"
+	metricresources "github.com/motain/of-catalog/internal/modules/metric/resources"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	scorecardrepository "github.com/motain/of-catalog/internal/modules/scorecard/repository"
+	scorecardresources "github.com/motain/of-catalog/internal/modules/scorecard/resources"
+	"github.com/motain/of-catalog/internal/utils/drift"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+)
+
+const (
+	planCreate = "+"
+	planUpdate = "~"
+	planDelete = "-"
+)
+
+// planChange is a change between the current state and a state version.
+type planChange struct {
+	Action  string
+	Kind    string
+	Name    string
+	Changes []drift.Change
+}
+
+type RollbackHandler struct {
+	components componentrepository.RepositoryInterface
+	metrics    metricrepository.RepositoryInterface
+	scorecards scorecardrepository.RepositoryInterface
+}
+
+func NewRollbackHandler(
+	components componentrepository.RepositoryInterface,
+	metrics metricrepository.RepositoryInterface,
+	scorecards scorecardrepository.RepositoryInterface,
+) *RollbackHandler {
+	return &RollbackHandler{
+		components: components,
+		metrics:    metrics,
+		scorecards: scorecards,
+	}
+}
+
+// Rollback writes the changes from the current state to the given state version. The state is compared
+// with the state only, Compass is not read: when the current state matches Compass, they are the changes
+// apply makes with the configuration the version was recorded from. When restoreState is set the state
+// files of the version replace the current ones, without changing Compass, provided that every object
+// they record still exists in Compass with the same ID.
+func (h *RollbackHandler) Rollback(ctx context.Context, version int, restoreState bool, output io.Writer) error {
+	info, files, readErr := yaml.ReadStateVersion(version)
+	if readErr != nil {
+		return readErr
+	}
+
+	changes, planErr := planRollback(files)
+	if planErr != nil {
+		return planErr
+	}
+
+	fmt.Fprintf(output, "State version %d was recorded %s by %s", info.Version, info.Created.Format(time.RFC3339), info.Who)
+	if info.GitSHA != "" {
+		fmt.Fprintf(output, " at commit %s", info.GitSHA)
+	}
+	fmt.Fprintf(output, " (%s).\n", info.Operation)
+
+	if len(changes) == 0 {
+		fmt.Fprintf(output, "The current state already matches state version %d.\n", version)
+	} else {
+		writePlan(output, version, changes)
+	}
+
+	if !restoreState {
+		return nil
+	}
+
+	missing, checkErr := h.missingInCompass(ctx, files)
+	if checkErr != nil {
+		return checkErr
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"cannot restore state version %d, objects it records no longer exist in Compass:\n  %s",
+			version, strings.Join(missing, "\n  "),
+		)
+	}
+
+	if err := yaml.RestoreStateVersion(version); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "Restored the state files of version %d, Compass was not changed.\n", version)
+
+	return nil
+}
+
+func planRollback(files map[string][]byte) ([]planChange, error) {
+	metricChanges, metricErr := planKind(MetricKind, yaml.GetMetricStateInput(), files, metricdtos.GetMetricUniqueKey)
+	if metricErr != nil {
+		return nil, metricErr
+	}
+
+	scorecardChanges, scorecardErr := planKind(ScorecardKind, yaml.GetScorecardStateInput(), files, scorecarddtos.GetScorecardUniqueKey)
+	if scorecardErr != nil {
+		return nil, scorecardErr
+	}
+
+	componentChanges, componentErr := planKind(ComponentKind, yaml.GetComponentStateInput(), files, componentdtos.GetComponentUniqueKey)
+	if componentErr != nil {
+		return nil, componentErr
+	}
+
+	changes := append(metricChanges, scorecardChanges...)
+	return append(changes, componentChanges...), nil
+}
+
+// planKind compares the current state of a kind with the one recorded in files.
+func planKind[T any](kind string, stateInput yaml.ParseInput, files map[string][]byte, getKey yaml.KeyExtractor[T]) ([]planChange, error) {
+	current, currentErr := yaml.Parse(stateInput, getKey)
+	if currentErr != nil {
+		return nil, currentErr
+	}
+
+	target, targetErr := yaml.ParseStateFiles(files, getKey)
+	if targetErr != nil {
+		return nil, targetErr
+	}
+
+	var changes []planChange
+	for _, name := range sortedKeys(target) {
+		currentDefinition, exists := current[name]
+		if !exists {
+			changes = append(changes, planChange{Action: planCreate, Kind: kind, Name: name})
+			continue
+		}
+
+		if fieldChanges := drift.Diff("", currentDefinition, target[name]); len(fieldChanges) > 0 {
+			changes = append(changes, planChange{Action: planUpdate, Kind: kind, Name: name, Changes: fieldChanges})
+		}
+	}
+
+	for _, name := range sortedKeys(current) {
+		if _, exists := target[name]; !exists {
+			changes = append(changes, planChange{Action: planDelete, Kind: kind, Name: name})
+		}
+	}
+
+	return changes, nil
+}
+
+// missingInCompass returns the objects recorded in files whose ID does not exist in Compass anymore.
+// Restoring them would make apply update objects that are gone instead of creating them.
+func (h *RollbackHandler) missingInCompass(ctx context.Context, files map[string][]byte) ([]string, error) {
+	var missing []string
+
+	metrics, metricErr := yaml.ParseStateFiles(files, metricdtos.GetMetricUniqueKey)
+	if metricErr != nil {
+		return nil, metricErr
+	}
+	for _, name := range sortedKeys(metrics) {
+		metric := metrics[name]
+		if metric.Spec.ID == "" {
+			continue
+		}
+
+		_, searchErr := h.metrics.Search(ctx, metricresources.Metric{ID: metric.Spec.ID, Name: metricdtos.GetMetricCompassName(metric)})
+		if isNotFound(searchErr) {
+			missing = append(missing, fmt.Sprintf("%s %s (%s)", MetricKind, name, metric.Spec.ID))
+			continue
+		}
+		if searchErr != nil {
+			return nil, fmt.Errorf("failed to check metric %s in Compass: %w", name, searchErr)
+		}
+	}
+
+	scorecards, scorecardErr := yaml.ParseStateFiles(files, scorecarddtos.GetScorecardUniqueKey)
+	if scorecardErr != nil {
+		return nil, scorecardErr
+	}
+	for _, name := range sortedKeys(scorecards) {
+		scorecard := scorecards[name]
+		if scorecard.Spec.ID == nil || *scorecard.Spec.ID == "" {
+			continue
+		}
+
+		_, searchErr := h.scorecards.Search(ctx, scorecardresources.Scorecard{ID: scorecard.Spec.ID, Name: scorecarddtos.GetScorecardCompassName(scorecard)})
+		if isNotFound(searchErr) {
+			missing = append(missing, fmt.Sprintf("%s %s (%s)", ScorecardKind, name, *scorecard.Spec.ID))
+			continue
+		}
+		if searchErr != nil {
+			return nil, fmt.Errorf("failed to check scorecard %s in Compass: %w", name, searchErr)
+		}
+	}
+
+	components, componentErr := yaml.ParseStateFiles(files, componentdtos.GetComponentUniqueKey)
+	if componentErr != nil {
+		return nil, componentErr
+	}
+	for _, name := range sortedKeys(components) {
+		component := components[name]
+		if component.Spec.ID == "" {
+			continue
+		}
+
+		slug := component.Spec.Slug
+		if slug == "" {
+			slug = componentutils.GetSlug(component.Spec.Name, component.Spec.TypeID)
+		}
+
+		remote, getErr := h.components.GetBySlug(ctx, componentresources.Component{Slug: slug})
+		if isNotFound(getErr) || (getErr == nil && remote.ID != component.Spec.ID) {
+			missing = append(missing, fmt.Sprintf("%s %s (%s)", ComponentKind, name, component.Spec.ID))
+			continue
+		}
+		if getErr != nil {
+			return nil, fmt.Errorf("failed to check component %s in Compass: %w", name, getErr)
+		}
+	}
+
+	return missing, nil
+}
+
+func writePlan(output io.Writer, version int, changes []planChange) {
+	counts := make(map[string]int, 3)
+	fmt.Fprintf(output, "Changes from the current state to state version %d:\n", version)
+	for _, change := range changes {
+		fmt.Fprintf(output, "  %s %s %s\n", change.Action, change.Kind, change.Name)
+		for _, fieldChange := range change.Changes {
+			fmt.Fprintf(output, "      %s\n", fieldChange)
+		}
+		counts[change.Action]++
+	}
+
+	fmt.Fprintf(
+		output, "Changes: %d to create, %d to update, %d to delete.\n",
+		counts[planCreate], counts[planUpdate], counts[planDelete],
+	)
+}
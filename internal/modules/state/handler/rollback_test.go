@@ -0,0 +1,94 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	metricrepository "github.com/motain/of-catalog/internal/modules/metric/repository/mocks"
+	metricresources "github.com/motain/of-catalog/internal/modules/metric/resources"
+	"github.com/motain/of-catalog/internal/modules/state/handler"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackHandler_Rollback_RestoreState(t *testing.T) {
+	notFound := &compassservice.Error{Class: compassservice.ErrorClassNotFound, Err: errors.New("metric not found")}
+
+	tests := []struct {
+		name          string
+		searchErrs    map[string]error
+		expectedError string
+		expectedState []string
+	}{
+		{
+			name:          "objects still in Compass",
+			searchErrs:    map[string]error{},
+			expectedState: []string{"availability", "coverage"},
+		},
+		{
+			name:          "object deleted in Compass",
+			searchErrs:    map[string]error{"metric-2": notFound},
+			expectedError: "cannot restore state version 1, objects it records no longer exist in Compass:\n  metric coverage (metric-2)",
+			expectedState: []string{"availability"},
+		},
+		{
+			name:          "lookup failure",
+			searchErrs:    map[string]error{"metric-1": &compassservice.Error{Class: compassservice.ErrorClassTransport, Err: errors.New("connection reset")}},
+			expectedError: "failed to check metric availability in Compass: connection reset",
+			expectedState: []string{"availability"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useStateDir(t)
+			require.NoError(t, yaml.WriteMetricStates(
+				[]*metricdtos.MetricDTO{metricState("availability", "metric-1"), metricState("coverage", "metric-2")},
+				metricdtos.GetMetricUniqueKey,
+			))
+			_, snapshotErr := yaml.SnapshotState("metric apply")
+			require.NoError(t, snapshotErr)
+			require.NoError(t, yaml.WriteMetricStates(
+				[]*metricdtos.MetricDTO{metricState("availability", "metric-1")},
+				metricdtos.GetMetricUniqueKey,
+			))
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			metrics := metricrepository.NewMockRepositoryInterface(ctrl)
+			metrics.EXPECT().Search(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, metric metricresources.Metric) (*metricresources.Metric, error) {
+					if err := tt.searchErrs[metric.ID]; err != nil {
+						return nil, err
+					}
+					return &metric, nil
+				},
+			).AnyTimes()
+
+			var output bytes.Buffer
+			rollbackHandler := handler.NewRollbackHandler(nil, metrics, nil)
+			err := rollbackHandler.Rollback(context.Background(), 1, true, &output)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, output.String(), "Changes from the current state to state version 1:\n  + metric coverage\n")
+
+			state, parseErr := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+			require.NoError(t, parseErr)
+			names := make([]string, 0, len(state))
+			for name := range state {
+				names = append(names, name)
+			}
+			assert.ElementsMatch(t, tt.expectedState, names)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"io"
+	"log"
+)
+
+type ShowHandler struct{}
+
+func NewShowHandler() *ShowHandler {
+	return &ShowHandler{}
+}
+
+// Show writes the definition recorded in the state for the resource of kind named name.
+func (h *ShowHandler) Show(kind, name string, output io.Writer) {
+	if err := validateRequiredKind(kind); err != nil {
+		log.Fatalf("error: %v", err)
+	}
+
+	data, encodeErr := encodeStateEntry(kind, name)
+	if encodeErr != nil {
+		log.Fatalf("error: %v", encodeErr)
+	}
+
+	output.Write(data)
+}
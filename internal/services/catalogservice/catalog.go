@@ -0,0 +1,30 @@
+package catalogservice
+
+import "fmt"
+
+const (
+	CompassBackendType = "compass"
+	FileBackendType    = "file"
+)
+
+// Kinds of the entities stored in a file catalog
+const (
+	ComponentKind    = "component"
+	DocumentKind     = "document"
+	LinkKind         = "link"
+	MetricKind       = "metric"
+	MetricSourceKind = "metric-source"
+	MetricValueKind  = "metric-value"
+	ScorecardKind    = "scorecard"
+	CriterionKind    = "criterion"
+)
+
+// ValidateBackend returns an error when backendType, the value of CATALOG_BACKEND, is not a supported catalog backend.
+func ValidateBackend(backendType string) error {
+	switch backendType {
+	case CompassBackendType, FileBackendType:
+		return nil
+	default:
+		return fmt.Errorf("unknown catalog backend %q, expected %s or %s", backendType, CompassBackendType, FileBackendType)
+	}
+}
@@ -0,0 +1,137 @@
+package catalogservice
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+const filePermission = 0644
+
+// ErrNotFound is wrapped by the errors returned for entities missing from a file catalog.
+var ErrNotFound = errors.New("not found in the catalog")
+
+// FileCatalogInterface stores catalog entities as JSON files, one directory per kind.
+type FileCatalogInterface interface {
+	// NewID returns a new unique ID for an entity of kind.
+	NewID(kind string) (string, error)
+	// Get decodes the entity of kind with the given ID into entity. It returns an error wrapping
+	// ErrNotFound when the entity does not exist.
+	Get(kind, id string, entity interface{}) error
+	Put(kind, id string, entity interface{}) error
+	// Delete removes an entity. Deleting a missing entity is not an error.
+	Delete(kind, id string) error
+	// List returns the IDs of the entities of kind, sorted.
+	List(kind string) ([]string, error)
+}
+
+// FileCatalog is a catalog kept in local files, to apply configurations without a remote catalog, e.g. in tests.
+type FileCatalog struct {
+	root string
+}
+
+func NewFileCatalog(config configservice.ConfigServiceInterface) *FileCatalog {
+	return NewFileCatalogAt(config.GetCatalogPath())
+}
+
+// NewFileCatalogAt returns a file catalog stored in the root directory.
+func NewFileCatalogAt(root string) *FileCatalog {
+	return &FileCatalog{root: root}
+}
+
+func (c *FileCatalog) NewID(kind string) (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate %s id: %w", kind, err)
+	}
+
+	return fmt.Sprintf("%s-%s", kind, hex.EncodeToString(id)), nil
+}
+
+func (c *FileCatalog) Get(kind, id string, entity interface{}) error {
+	filePath, pathErr := c.entityPath(kind, id)
+	if pathErr != nil {
+		return pathErr
+	}
+
+	data, readErr := os.ReadFile(filePath)
+	if readErr != nil {
+		if os.IsNotExist(readErr) {
+			return fmt.Errorf("%s %s %w", kind, id, ErrNotFound)
+		}
+		return readErr
+	}
+
+	if err := json.Unmarshal(data, entity); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", kind, id, err)
+	}
+
+	return nil
+}
+
+func (c *FileCatalog) Put(kind, id string, entity interface{}) error {
+	filePath, pathErr := c.entityPath(kind, id)
+	if pathErr != nil {
+		return pathErr
+	}
+
+	data, encodeErr := json.MarshalIndent(entity, "", "  ")
+	if encodeErr != nil {
+		return fmt.Errorf("failed to encode %s %s: %w", kind, id, encodeErr)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, data, filePermission)
+}
+
+func (c *FileCatalog) Delete(kind, id string) error {
+	filePath, pathErr := c.entityPath(kind, id)
+	if pathErr != nil {
+		return pathErr
+	}
+
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (c *FileCatalog) List(kind string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(c.root, kind))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if id, isEntity := strings.CutSuffix(entry.Name(), ".json"); isEntity && !entry.IsDir() {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+func (c *FileCatalog) entityPath(kind, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.HasPrefix(id, ".") {
+		return "", fmt.Errorf("invalid %s id %q", kind, id)
+	}
+
+	return filepath.Join(c.root, kind, id+".json"), nil
+}
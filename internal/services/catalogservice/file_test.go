@@ -0,0 +1,65 @@
+package catalogservice_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/catalogservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type entity struct {
+	Name  string
+	Value int
+}
+
+func TestFileCatalog(t *testing.T) {
+	catalog := catalogservice.NewFileCatalogAt(t.TempDir())
+
+	id, idErr := catalog.NewID("thing")
+	require.NoError(t, idErr)
+	assert.True(t, strings.HasPrefix(id, "thing-"))
+	otherID, otherIDErr := catalog.NewID("thing")
+	require.NoError(t, otherIDErr)
+	assert.NotEqual(t, id, otherID)
+
+	ids, listErr := catalog.List("thing")
+	require.NoError(t, listErr)
+	assert.Empty(t, ids)
+
+	var missing entity
+	assert.True(t, errors.Is(catalog.Get("thing", id, &missing), catalogservice.ErrNotFound))
+
+	require.NoError(t, catalog.Put("thing", id, entity{Name: "foo", Value: 42}))
+	require.NoError(t, catalog.Put("thing", "other", entity{Name: "bar"}))
+
+	var stored entity
+	require.NoError(t, catalog.Get("thing", id, &stored))
+	assert.Equal(t, entity{Name: "foo", Value: 42}, stored)
+
+	ids, listErr = catalog.List("thing")
+	require.NoError(t, listErr)
+	assert.ElementsMatch(t, []string{id, "other"}, ids)
+
+	require.NoError(t, catalog.Delete("thing", id))
+	require.NoError(t, catalog.Delete("thing", id))
+	ids, listErr = catalog.List("thing")
+	require.NoError(t, listErr)
+	assert.Equal(t, []string{"other"}, ids)
+}
+
+func TestFileCatalog_InvalidID(t *testing.T) {
+	catalog := catalogservice.NewFileCatalogAt(t.TempDir())
+
+	for _, id := range []string{"", "../escape", `a\b`, ".hidden"} {
+		assert.Error(t, catalog.Put("thing", id, entity{}), id)
+	}
+}
+
+func TestValidateBackend(t *testing.T) {
+	assert.NoError(t, catalogservice.ValidateBackend(catalogservice.CompassBackendType))
+	assert.NoError(t, catalogservice.ValidateBackend(catalogservice.FileBackendType))
+	assert.Error(t, catalogservice.ValidateBackend("backstage"))
+}
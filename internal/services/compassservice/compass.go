@@ -12,6 +12,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strings"
+	"sync/atomic"
 
 	"github.com/machinebox/graphql"
 	"github.com/motain/of-catalog/internal/services/compassservice/dtos"
@@ -29,6 +30,11 @@ type OutputDTOInterface interface {
 	GetErrors() []string
 }
 
+// ErrorsOutputDTOInterface is implemented by the output DTOs of mutations, whose payload holds errors.
+type ErrorsOutputDTOInterface interface {
+	GetCompassErrors() []CompassError
+}
+
 type CompassServiceInterface interface {
 	Run(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error
 	RunWithDTOs(ctx context.Context, input InputDTOInterface, output OutputDTOInterface) error
@@ -43,10 +49,13 @@ const (
 )
 
 type CompassService struct {
-	gqlClient  GraphQLClientInterface
-	httpClient HTTPClientInterface
-	token      string
-	cloudId    string
+	gqlClient   GraphQLClientInterface
+	httpClient  HTTPClientInterface
+	token       string
+	cloudId     string
+	retryPolicy RetryPolicy
+	// limiter is shared by the calls of the service, a single instance being built per run
+	limiter *TokenBucket
 }
 
 func NewCompassService(
@@ -55,10 +64,12 @@ func NewCompassService(
 	httpClient HTTPClientInterface,
 ) *CompassService {
 	return &CompassService{
-		gqlClient:  gqlClient,
-		httpClient: httpClient,
-		token:      config.GetCompassToken(),
-		cloudId:    config.GetCompassCloudId(),
+		gqlClient:   gqlClient,
+		httpClient:  httpClient,
+		token:       config.GetCompassToken(),
+		cloudId:     config.GetCompassCloudId(),
+		retryPolicy: NewRetryPolicy(config),
+		limiter:     NewTokenBucket(config.GetCompassRateLimit()),
 	}
 }
 
@@ -70,7 +81,13 @@ func (c *CompassService) Run(ctx context.Context, query string, variables map[st
 
 	req.Header.Set("Authorization", "Basic "+c.token)
 
-	if err := c.gqlClient.Run(ctx, req, response); err != nil {
+	operation := operationName(query)
+	err := c.withRetry(ctx, !strings.HasPrefix(operation, "mutation"), func() (bool, error) {
+		attemptCtx, meta := withResponseMeta(ctx)
+		runErr := c.runError(operation, meta, c.gqlClient.Run(attemptCtx, req, response))
+		return meta.Sent.Load(), runErr
+	})
+	if err != nil {
 		log.Printf("Failed to execute query: %v", err)
 		return err
 	}
@@ -80,7 +97,7 @@ func (c *CompassService) Run(ctx context.Context, query string, variables map[st
 
 func (c *CompassService) RunWithDTOs(ctx context.Context, input InputDTOInterface, output OutputDTOInterface) error {
 	query := input.GetQuery()
-	operation := strings.TrimSpace(query[:strings.Index(query, "(")])
+	operation := operationName(query)
 
 	if runErr := c.Run(ctx, query, input.SetVariables(), output); runErr != nil {
 		log.Printf("failed to run %s: %v", operation, runErr)
@@ -95,7 +112,11 @@ func (c *CompassService) RunWithDTOs(ctx context.Context, input InputDTOInterfac
 	}
 
 	if !output.IsSuccessful() {
-		return fmt.Errorf("failed to execute %s: %v", operation, output.GetErrors())
+		var errs []CompassError
+		if errorsOutput, isErrorsOutput := output.(ErrorsOutputDTOInterface); isErrorsOutput {
+			errs = errorsOutput.GetCompassErrors()
+		}
+		return newPayloadError(operation, errs, fmt.Errorf("failed to execute %s: %v", operation, output.GetErrors()))
 	}
 
 	return nil
@@ -141,9 +162,33 @@ func (c *CompassService) GetCompassCloudId() string {
 }
 
 func (c *CompassService) do(req *http.Request) (string, error) {
+	var respBody string
+	err := c.withRetry(req.Context(), req.Method != http.MethodPost, func() (bool, error) {
+		attemptReq := req
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return false, fmt.Errorf("failed to rewind request body: %v", bodyErr)
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		var sent atomic.Bool
+		attemptReq = attemptReq.WithContext(withSentTrace(attemptReq.Context(), &sent))
+
+		var sendErr error
+		respBody, sendErr = c.send(attemptReq)
+		return sent.Load(), sendErr
+	})
+
+	return respBody, err
+}
+
+func (c *CompassService) send(req *http.Request) (string, error) {
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to send request: %v", err)
+		return "", newTransportError(req.URL.Path, fmt.Errorf("failed to send request: %w", err))
 	}
 	defer resp.Body.Close()
 
@@ -152,7 +197,7 @@ func (c *CompassService) do(req *http.Request) (string, error) {
 		if err != nil {
 			return "", fmt.Errorf("failed to read response body: %v", err)
 		}
-		return "", fmt.Errorf("response body: %s", string(body))
+		return "", newResponseError(req.URL.Path, resp.StatusCode, resp.Header, fmt.Errorf("response body: %s", string(body)))
 	}
 
 	respBody, err := io.ReadAll(resp.Body)
@@ -163,6 +208,38 @@ func (c *CompassService) do(req *http.Request) (string, error) {
 	return string(respBody), nil
 }
 
+// runError returns the error of a GraphQL call from the error of the graphql client and the response meta.
+// The graphql client ignores the status code, so a failed response with a JSON body is seen as a success.
+func (c *CompassService) runError(operation string, meta *responseMeta, err error) error {
+	switch {
+	case meta.StatusCode != 0 && meta.StatusCode != http.StatusOK:
+		if err == nil {
+			err = fmt.Errorf("%s returned status code %d", operation, meta.StatusCode)
+		}
+		return newResponseError(operation, meta.StatusCode, meta.Header, err)
+	case err == nil:
+		return nil
+	case meta.StatusCode == 0:
+		return newTransportError(operation, err)
+	}
+
+	// The call was answered with GraphQL errors, classified from their extensions
+	errs := meta.Errors
+	if len(errs) == 0 {
+		errs = []CompassError{{Message: strings.TrimPrefix(err.Error(), "graphql: ")}}
+	}
+
+	return newPayloadError(operation, errs, err)
+}
+
+// operationName returns the operation of query, e.g. mutation createComponent.
+func operationName(query string) string {
+	operation, _, _ := strings.Cut(query, "(")
+	operation, _, _ = strings.Cut(operation, "{")
+
+	return strings.TrimSpace(operation)
+}
+
 func (c *CompassService) buildMultiPartBody(input dtos.APISpecificationsInput) (*bytes.Buffer, string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
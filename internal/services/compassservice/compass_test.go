@@ -51,6 +51,7 @@ func TestCompassService_Run(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
@@ -71,6 +72,7 @@ func TestCompassService_Run(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("query execution failed"))
 			},
@@ -122,6 +124,7 @@ func TestCompassService_SendMetric(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
 					assert.Equal(t, "/gateway/api/compass/v1/metrics", req.URL.Path)
@@ -147,6 +150,7 @@ func TestCompassService_SendMetric(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).Return(nil, errors.New("http error"))
 			},
@@ -163,6 +167,7 @@ func TestCompassService_SendMetric(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
 					StatusCode: http.StatusBadRequest,
@@ -222,6 +227,7 @@ func TestCompassService_SendAPISpecifications(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
 					assert.Equal(t, "/gateway/api/compass/v1/component/component123/api_specs", req.URL.Path)
@@ -249,6 +255,7 @@ func TestCompassService_SendAPISpecifications(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).Return(nil, errors.New("http error"))
 			},
@@ -267,6 +274,7 @@ func TestCompassService_SendAPISpecifications(t *testing.T) {
 			mockSetup: func() {
 				mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
 				mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+				expectRetryPolicy(mockConfigService, 0)
 
 				mockHttpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
 					StatusCode: http.StatusBadRequest,
@@ -0,0 +1,480 @@
+// Package compasstest provides an in-memory stand-in for Atlassian Compass, implementing the subset of the
+// GraphQL API and of the REST endpoints used by the repositories, so that commands can be run end to end
+// without network. Responses use the field names of the Compass API: a DTO decoding another field fails
+// against the stand-in as it does against Compass.
+package compasstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// CloudID is the cloud ID of the stand-in site, to be set as COMPASS_CLOUD_ID.
+const CloudID = "compasstest-cloud"
+
+const workspaceID = "compasstest-workspace"
+
+// DocumentationCategories are the documentation categories of the stand-in site, keyed by name.
+var DocumentationCategories = map[string]string{
+	"Discover":   "category-discover",
+	"Contribute": "category-contribute",
+	"Maintain":   "category-maintain",
+	"Other":      "category-other",
+}
+
+type Metric struct {
+	ID          string
+	Name        string
+	Description string
+	Unit        string
+}
+
+type Criterion struct {
+	ID                 string
+	Name               string
+	Weight             int
+	MetricDefinitionID string
+	Comparator         string
+	ComparatorValue    float64
+}
+
+type Scorecard struct {
+	ID                  string
+	Name                string
+	Description         string
+	State               string
+	Importance          string
+	ScoringStrategyType string
+	ComponentTypeIDs    []string
+	OwnerID             string
+	Criteria            []Criterion
+}
+
+type Link struct {
+	ID   string
+	Type string
+	Name string
+	URL  string
+}
+
+type Component struct {
+	ID          string
+	Name        string
+	Slug        string
+	Description string
+	TypeID      string
+	OwnerID     string
+	Labels      []string
+	Links       []Link
+	// DependsOn holds the IDs of the components this one depends on
+	DependsOn []string
+}
+
+type MetricSource struct {
+	ID                 string
+	ComponentID        string
+	MetricDefinitionID string
+	ExternalID         string
+}
+
+type Document struct {
+	ID          string
+	ComponentID string
+	Title       string
+	URL         string
+	CategoryID  string
+}
+
+// MetricValue is a value pushed to the metrics REST endpoint.
+type MetricValue struct {
+	MetricSourceID string
+	Value          float64
+	Timestamp      string
+}
+
+// APISpecification is a specification uploaded to the api_specs REST endpoint.
+type APISpecification struct {
+	FileName string
+	Content  string
+}
+
+type Server struct {
+	*httptest.Server
+
+	mutex             sync.Mutex
+	nextID            int
+	metrics           map[string]*Metric
+	scorecards        map[string]*Scorecard
+	components        map[string]*Component
+	metricSources     map[string]*MetricSource
+	documents         map[string]*Document
+	metricValues      []MetricValue
+	apiSpecifications map[string]APISpecification
+	operations        []string
+	pageSize          int
+	unavailable       atomic.Bool
+}
+
+// NewServer starts a stand-in server. Its URL is to be set as COMPASS_HOST.
+func NewServer() *Server {
+	server := &Server{
+		metrics:           make(map[string]*Metric),
+		scorecards:        make(map[string]*Scorecard),
+		components:        make(map[string]*Component),
+		metricSources:     make(map[string]*MetricSource),
+		documents:         make(map[string]*Document),
+		apiSpecifications: make(map[string]APISpecification),
+		pageSize:          100,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /gateway/api/graphql", server.handleGraphQL)
+	mux.HandleFunc("POST /gateway/api/compass/v1/metrics", server.handleMetrics)
+	mux.HandleFunc("PUT /gateway/api/compass/v1/component/{componentId}/api_specs", server.handleAPISpecifications)
+	server.Server = httptest.NewServer(server.availability(authenticated(mux)))
+
+	return server
+}
+
+// SetUnavailable makes the server answer every request with 503 Service Unavailable, as during an outage,
+// until it is called with false.
+func (s *Server) SetUnavailable(unavailable bool) {
+	s.unavailable.Store(unavailable)
+}
+
+// SetPageSize sets the number of nodes per page of the metric definition and scorecard connections.
+func (s *Server) SetPageSize(pageSize int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.pageSize = pageSize
+}
+
+// Operations returns the root fields of the GraphQL operations received, in order, such as "createComponent".
+func (s *Server) Operations() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return slices.Clone(s.operations)
+}
+
+// Metrics returns the metric definitions sorted by name.
+func (s *Server) Metrics() []Metric {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedValues(s.metrics, func(metric Metric) string { return metric.Name })
+}
+
+// Scorecards returns the scorecards sorted by name.
+func (s *Server) Scorecards() []Scorecard {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedValues(s.scorecards, func(scorecard Scorecard) string { return scorecard.Name })
+}
+
+// Components returns the components sorted by slug.
+func (s *Server) Components() []Component {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedValues(s.components, func(component Component) string { return component.Slug })
+}
+
+// MetricSources returns the metric sources sorted by external ID.
+func (s *Server) MetricSources() []MetricSource {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedValues(s.metricSources, func(metricSource MetricSource) string { return metricSource.ExternalID })
+}
+
+// Documents returns the documents sorted by title.
+func (s *Server) Documents() []Document {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return sortedValues(s.documents, func(document Document) string { return document.Title })
+}
+
+// MetricValues returns the pushed metric values, in order.
+func (s *Server) MetricValues() []MetricValue {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return slices.Clone(s.metricValues)
+}
+
+// APISpecifications returns the uploaded API specifications keyed by component ID.
+func (s *Server) APISpecifications() map[string]APISpecification {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	specifications := make(map[string]APISpecification, len(s.apiSpecifications))
+	for id, specification := range s.apiSpecifications {
+		specifications[s.findComponentID(id)] = specification
+	}
+
+	return specifications
+}
+
+// AddMetric stores a metric definition directly, bypassing the API, and returns its ID.
+func (s *Server) AddMetric(metric Metric) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	metric.ID = s.newID("metric-definition")
+	s.metrics[metric.ID] = &metric
+
+	return metric.ID
+}
+
+// AddComponent stores a component directly, bypassing the API, and returns its ID.
+func (s *Server) AddComponent(component Component) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	component.ID = s.newID("component")
+	s.components[component.ID] = &component
+
+	return component.ID
+}
+
+func (s *Server) availability(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.unavailable.Load() {
+			http.Error(w, `{"message":"Service Unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Basic ") {
+			http.Error(w, `{"message":"Unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type payloadError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		StatusCode int    `json:"statusCode"`
+		ErrorType  string `json:"errorType"`
+	} `json:"extensions"`
+}
+
+var (
+	operationPattern = regexp.MustCompile(`^\s*(?:query|mutation)\s+\w+\s*(?:\(([^)]*)\))?`)
+	variablePattern  = regexp.MustCompile(`\$(\w+)\s*:\s*([\w\[\]!]+)`)
+	fieldPattern     = regexp.MustCompile(`compass[^{]*\{\s*(\w+)`)
+)
+
+// resolvers resolve the root fields of the compass object
+var resolvers = map[string]func(s *Server, variables map[string]interface{}) interface{}{
+	"createMetricDefinition":  (*Server).createMetricDefinition,
+	"updateMetricDefinition":  (*Server).updateMetricDefinition,
+	"deleteMetricDefinition":  (*Server).deleteMetricDefinition,
+	"metricDefinitions":       (*Server).metricDefinitions,
+	"createScorecard":         (*Server).createScorecard,
+	"updateScorecard":         (*Server).updateScorecard,
+	"deleteScorecard":         (*Server).deleteScorecard,
+	"scorecards":              (*Server).listScorecards,
+	"createComponent":         (*Server).createComponent,
+	"updateComponent":         (*Server).updateComponent,
+	"deleteComponent":         (*Server).deleteComponent,
+	"componentByReference":    (*Server).componentByReference,
+	"createRelationship":      (*Server).createRelationship,
+	"deleteRelationship":      (*Server).deleteRelationship,
+	"createComponentLink":     (*Server).createComponentLink,
+	"deleteComponentLink":     (*Server).deleteComponentLink,
+	"createMetricSource":      (*Server).createMetricSource,
+	"deleteMetricSource":      (*Server).deleteMetricSource,
+	"addDocument":             (*Server).addDocument,
+	"updateDocument":          (*Server).updateDocument,
+	"deleteDocument":          (*Server).deleteDocument,
+	"documents":               (*Server).listDocuments,
+	"documentationCategories": (*Server).documentationCategories,
+}
+
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var request graphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	field, resolveErr := s.resolveField(request)
+	if resolveErr != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"errors": []graphQLError{{Message: resolveErr.Error()}}})
+		return
+	}
+	s.operations = append(s.operations, field)
+
+	data := map[string]interface{}{field: resolvers[field](s, request.Variables)}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"compass": data}})
+}
+
+// resolveField returns the root field of the compass object queried, after checking that the required variables are set.
+func (s *Server) resolveField(request graphQLRequest) (string, error) {
+	operation := operationPattern.FindStringSubmatch(request.Query)
+	if operation == nil {
+		return "", fmt.Errorf("invalid syntax: expected a named query or mutation")
+	}
+
+	for _, definition := range variablePattern.FindAllStringSubmatch(operation[1], -1) {
+		if strings.HasSuffix(definition[2], "!") && request.Variables[definition[1]] == nil {
+			return "", fmt.Errorf("Variable '%s' has an invalid value: null", definition[1])
+		}
+	}
+
+	field := fieldPattern.FindStringSubmatch(request.Query)
+	if field == nil {
+		return "", fmt.Errorf("invalid syntax: expected a compass field")
+	}
+	if _, exists := resolvers[field[1]]; !exists {
+		return "", fmt.Errorf("Field '%s' in type 'CompassCatalogMutationApi' is undefined", field[1])
+	}
+
+	return field[1], nil
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	var body map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	value, parseErr := strconv.ParseFloat(body["value"], 64)
+	if parseErr != nil {
+		http.Error(w, `{"message":"invalid value"}`, http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.metricSources[body["metricSourceId"]]; !exists {
+		http.Error(w, `{"message":"metric source not found"}`, http.StatusNotFound)
+		return
+	}
+
+	s.metricValues = append(s.metricValues, MetricValue{
+		MetricSourceID: body["metricSourceId"],
+		Value:          value,
+		Timestamp:      body["timestamp"],
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) handleAPISpecifications(w http.ResponseWriter, r *http.Request) {
+	file, header, fileErr := r.FormFile("file")
+	if fileErr != nil {
+		http.Error(w, fileErr.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	content, readErr := io.ReadAll(file)
+	if readErr != nil {
+		http.Error(w, readErr.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	componentID := r.PathValue("componentId")
+	if s.findComponentID(componentID) == "" {
+		http.Error(w, `{"message":"component not found"}`, http.StatusNotFound)
+		return
+	}
+
+	s.apiSpecifications[componentID] = APISpecification{FileName: header.Filename, Content: string(content)}
+	writeJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// findComponentID returns the ID of the component whose ID ends with the given resource ID, as used by the REST API
+func (s *Server) findComponentID(resourceID string) string {
+	for id := range s.components {
+		if strings.HasSuffix(id, "/"+resourceID) {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func (s *Server) newID(resourceType string) string {
+	s.nextID++
+	return fmt.Sprintf("ari:cloud:compass:%s:%s/%s/%d", CloudID, resourceType, workspaceID, s.nextID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func sortedValues[T any](items map[string]*T, key func(T) string) []T {
+	values := make([]T, 0, len(items))
+	for _, item := range items {
+		values = append(values, *item)
+	}
+	sort.Slice(values, func(i, j int) bool { return key(values[i]) < key(values[j]) })
+
+	return values
+}
+
+func payload(errs ...payloadError) map[string]interface{} {
+	return map[string]interface{}{"success": len(errs) == 0, "errors": append([]payloadError{}, errs...)}
+}
+
+func newPayloadError(statusCode int, errorType, format string, args ...interface{}) payloadError {
+	err := payloadError{Message: fmt.Sprintf(format, args...)}
+	err.Extensions.StatusCode = statusCode
+	err.Extensions.ErrorType = errorType
+
+	return err
+}
+
+func notFound(format string, args ...interface{}) payloadError {
+	return newPayloadError(http.StatusNotFound, "NOT_FOUND", format, args...)
+}
+
+func alreadyExists(format string, args ...interface{}) payloadError {
+	return newPayloadError(http.StatusConflict, "ALREADY_EXISTS", format, args...)
+}
+
+func with(result map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result[key] = value
+	return result
+}
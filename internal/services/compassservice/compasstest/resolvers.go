@@ -0,0 +1,569 @@
+package compasstest
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+func (s *Server) createMetricDefinition(variables map[string]interface{}) interface{} {
+	name := stringOf(variables["name"])
+	for _, metric := range s.metrics {
+		if metric.Name == name {
+			return with(payload(alreadyExists("Metric definition with name %s already exists", name)), "createdMetricDefinition", nil)
+		}
+	}
+
+	metric := &Metric{
+		ID:          s.newID("metric-definition"),
+		Name:        name,
+		Description: stringOf(variables["description"]),
+		Unit:        stringOf(variables["unit"]),
+	}
+	s.metrics[metric.ID] = metric
+
+	return with(payload(), "createdMetricDefinition", map[string]interface{}{"id": metric.ID})
+}
+
+func (s *Server) updateMetricDefinition(variables map[string]interface{}) interface{} {
+	metric, exists := s.metrics[stringOf(variables["id"])]
+	if !exists {
+		return payload(notFound("Metric definition not found"))
+	}
+
+	metric.Name = stringOf(variables["name"])
+	metric.Description = stringOf(variables["description"])
+	metric.Unit = stringOf(variables["unit"])
+
+	return payload()
+}
+
+func (s *Server) deleteMetricDefinition(variables map[string]interface{}) interface{} {
+	id := stringOf(mapOf(variables["input"])["id"])
+	if _, exists := s.metrics[id]; !exists {
+		return with(payload(notFound("Metric definition not found")), "deletedMetricDefinitionId", nil)
+	}
+
+	delete(s.metrics, id)
+	for sourceID, metricSource := range s.metricSources {
+		if metricSource.MetricDefinitionID == id {
+			delete(s.metricSources, sourceID)
+		}
+	}
+
+	return with(payload(), "deletedMetricDefinitionId", id)
+}
+
+func (s *Server) metricDefinitions(variables map[string]interface{}) interface{} {
+	nodes := make([]map[string]interface{}, 0, len(s.metrics))
+	for _, metric := range sortedValues(s.metrics, func(metric Metric) string { return metric.Name }) {
+		nodes = append(nodes, map[string]interface{}{
+			"id":          metric.ID,
+			"name":        metric.Name,
+			"description": metric.Description,
+			"format":      map[string]interface{}{"suffix": metric.Unit},
+		})
+	}
+
+	return s.page(nodes, variables)
+}
+
+func (s *Server) createScorecard(variables map[string]interface{}) interface{} {
+	details := mapOf(variables["scorecardDetails"])
+	name := stringOf(details["name"])
+	for _, scorecard := range s.scorecards {
+		if scorecard.Name == name {
+			return with(payload(alreadyExists("Scorecard with name %s already exists", name)), "scorecardDetails", nil)
+		}
+	}
+
+	scorecard := &Scorecard{ID: s.newID("scorecard")}
+	setScorecardDetails(scorecard, details)
+	for _, criterion := range mapsOf(details["criterias"]) {
+		scorecard.Criteria = append(scorecard.Criteria, s.newCriterion(criterion))
+	}
+	s.scorecards[scorecard.ID] = scorecard
+
+	criteria := make([]map[string]interface{}, len(scorecard.Criteria))
+	for i, criterion := range scorecard.Criteria {
+		criteria[i] = map[string]interface{}{"id": criterion.ID, "name": criterion.Name}
+	}
+
+	return with(payload(), "scorecardDetails", map[string]interface{}{"id": scorecard.ID, "criterias": criteria})
+}
+
+func (s *Server) updateScorecard(variables map[string]interface{}) interface{} {
+	scorecard, exists := s.scorecards[stringOf(variables["scorecardId"])]
+	if !exists {
+		return payload(notFound("Scorecard not found"))
+	}
+
+	details := mapOf(variables["scorecardDetails"])
+	setScorecardDetails(scorecard, details)
+
+	for _, id := range listOf(details["deleteCriteria"]) {
+		scorecard.Criteria = slices.DeleteFunc(scorecard.Criteria, func(criterion Criterion) bool { return criterion.ID == id })
+	}
+	for _, updated := range mapsOf(details["updateCriteria"]) {
+		criterion := criterionOf(updated)
+		for i := range scorecard.Criteria {
+			if scorecard.Criteria[i].ID == criterion.ID {
+				scorecard.Criteria[i] = criterion
+			}
+		}
+	}
+	for _, created := range mapsOf(details["createCriteria"]) {
+		scorecard.Criteria = append(scorecard.Criteria, s.newCriterion(created))
+	}
+
+	return payload()
+}
+
+func (s *Server) deleteScorecard(variables map[string]interface{}) interface{} {
+	id := stringOf(variables["scorecardId"])
+	if _, exists := s.scorecards[id]; !exists {
+		return with(payload(notFound("Scorecard not found")), "scorecardId", nil)
+	}
+
+	delete(s.scorecards, id)
+
+	return with(payload(), "scorecardId", id)
+}
+
+func (s *Server) listScorecards(variables map[string]interface{}) interface{} {
+	nodes := make([]map[string]interface{}, 0, len(s.scorecards))
+	for _, scorecard := range sortedValues(s.scorecards, func(scorecard Scorecard) string { return scorecard.Name }) {
+		criteria := make([]map[string]interface{}, len(scorecard.Criteria))
+		for i, criterion := range scorecard.Criteria {
+			criteria[i] = map[string]interface{}{
+				"id":                 criterion.ID,
+				"name":               criterion.Name,
+				"weight":             criterion.Weight,
+				"metricDefinitionId": criterion.MetricDefinitionID,
+				"comparator":         criterion.Comparator,
+				"comparatorValue":    criterion.ComparatorValue,
+			}
+		}
+
+		nodes = append(nodes, map[string]interface{}{
+			"id":                  scorecard.ID,
+			"name":                scorecard.Name,
+			"description":         scorecard.Description,
+			"state":               scorecard.State,
+			"importance":          scorecard.Importance,
+			"scoringStrategyType": scorecard.ScoringStrategyType,
+			"componentTypeIds":    scorecard.ComponentTypeIDs,
+			"owner":               map[string]interface{}{"accountId": scorecard.OwnerID},
+			"criterias":           criteria,
+		})
+	}
+
+	return s.page(nodes, variables)
+}
+
+// page returns the nodes of the page starting at the cursor of the after variable, which is the index
+// of the first node of the page.
+func (s *Server) page(nodes []map[string]interface{}, variables map[string]interface{}) map[string]interface{} {
+	start, _ := strconv.Atoi(stringOf(variables["after"]))
+	start = min(start, len(nodes))
+	end := min(start+s.pageSize, len(nodes))
+
+	return map[string]interface{}{
+		"nodes":    nodes[start:end],
+		"pageInfo": map[string]interface{}{"hasNextPage": end < len(nodes), "endCursor": strconv.Itoa(end)},
+	}
+}
+
+func (s *Server) createComponent(variables map[string]interface{}) interface{} {
+	details := mapOf(variables["componentDetails"])
+	slug := stringOf(details["slug"])
+	if s.findComponentBySlug(slug) != nil {
+		return with(payload(alreadyExists("Component with slug %s already exists", slug)), "componentDetails", nil)
+	}
+
+	component := &Component{
+		ID:          s.newID("component"),
+		Name:        stringOf(details["name"]),
+		Slug:        slug,
+		Description: stringOf(details["description"]),
+		TypeID:      stringOf(details["typeId"]),
+		OwnerID:     stringOf(details["ownerId"]),
+		Labels:      listOf(details["labels"]),
+	}
+	for _, link := range mapsOf(details["links"]) {
+		component.Links = append(component.Links, s.newLink(link))
+	}
+	s.components[component.ID] = component
+
+	return with(payload(), "componentDetails", map[string]interface{}{"id": component.ID, "links": linksOf(component)})
+}
+
+func (s *Server) updateComponent(variables map[string]interface{}) interface{} {
+	details := mapOf(variables["componentDetails"])
+	component, exists := s.components[stringOf(details["id"])]
+	if !exists {
+		return payload(notFound("Component not found"))
+	}
+
+	component.Name = stringOf(details["name"])
+	component.Slug = stringOf(details["slug"])
+	component.Description = stringOf(details["description"])
+	if ownerID, set := details["ownerId"]; set {
+		component.OwnerID = stringOf(ownerID)
+	}
+
+	return payload()
+}
+
+func (s *Server) deleteComponent(variables map[string]interface{}) interface{} {
+	id := stringOf(mapOf(variables["input"])["id"])
+	if id == "" {
+		id = stringOf(variables["id"])
+	}
+	if _, exists := s.components[id]; !exists {
+		return with(payload(notFound("Component not found")), "deletedComponentId", nil)
+	}
+
+	delete(s.components, id)
+	for sourceID, metricSource := range s.metricSources {
+		if metricSource.ComponentID == id {
+			delete(s.metricSources, sourceID)
+		}
+	}
+	for documentID, document := range s.documents {
+		if document.ComponentID == id {
+			delete(s.documents, documentID)
+		}
+	}
+	for _, component := range s.components {
+		component.DependsOn = slices.DeleteFunc(component.DependsOn, func(providerID string) bool { return providerID == id })
+	}
+
+	return with(payload(), "deletedComponentId", id)
+}
+
+func (s *Server) componentByReference(variables map[string]interface{}) interface{} {
+	component := s.findComponentBySlug(stringOf(variables["slug"]))
+	if component == nil {
+		return nil
+	}
+
+	labels := make([]map[string]interface{}, len(component.Labels))
+	for i, label := range component.Labels {
+		labels[i] = map[string]interface{}{"name": label}
+	}
+
+	metricSources := make([]map[string]interface{}, 0)
+	for _, metricSource := range sortedValues(s.metricSources, func(metricSource MetricSource) string { return metricSource.ID }) {
+		if metricSource.ComponentID != component.ID {
+			continue
+		}
+
+		definition := map[string]interface{}{"id": metricSource.MetricDefinitionID, "name": ""}
+		if metric, exists := s.metrics[metricSource.MetricDefinitionID]; exists {
+			definition["name"] = metric.Name
+		}
+		metricSources = append(metricSources, map[string]interface{}{"id": metricSource.ID, "metricDefinition": definition})
+	}
+
+	return map[string]interface{}{
+		"id":            component.ID,
+		"name":          component.Name,
+		"description":   component.Description,
+		"typeId":        component.TypeID,
+		"ownerId":       component.OwnerID,
+		"labels":        labels,
+		"metricSources": map[string]interface{}{"nodes": metricSources},
+		"links":         linksOf(component),
+	}
+}
+
+func (s *Server) createRelationship(variables map[string]interface{}) interface{} {
+	dependent, exists := s.components[stringOf(variables["dependentId"])]
+	if !exists {
+		return payload(notFound("Component not found"))
+	}
+	providerID := stringOf(variables["providerId"])
+	if _, providerExists := s.components[providerID]; !providerExists {
+		return payload(notFound("Component not found"))
+	}
+	if slices.Contains(dependent.DependsOn, providerID) {
+		return payload(alreadyExists("Relationship already exists"))
+	}
+
+	dependent.DependsOn = append(dependent.DependsOn, providerID)
+
+	return payload()
+}
+
+func (s *Server) deleteRelationship(variables map[string]interface{}) interface{} {
+	dependent, exists := s.components[stringOf(variables["dependentId"])]
+	providerID := stringOf(variables["providerId"])
+	if !exists || !slices.Contains(dependent.DependsOn, providerID) {
+		return payload(notFound("Relationship not found"))
+	}
+
+	dependent.DependsOn = slices.DeleteFunc(dependent.DependsOn, func(id string) bool { return id == providerID })
+
+	return payload()
+}
+
+func (s *Server) createComponentLink(variables map[string]interface{}) interface{} {
+	input := mapOf(variables["input"])
+	component, exists := s.components[stringOf(input["componentId"])]
+	if !exists {
+		return with(payload(notFound("Component not found")), "createdComponentLink", nil)
+	}
+
+	link := s.newLink(mapOf(input["link"]))
+	component.Links = append(component.Links, link)
+
+	return with(payload(), "createdComponentLink", map[string]interface{}{"id": link.ID})
+}
+
+func (s *Server) deleteComponentLink(variables map[string]interface{}) interface{} {
+	input := mapOf(variables["input"])
+	component, exists := s.components[stringOf(input["componentId"])]
+	if !exists {
+		return with(payload(notFound("Component not found")), "deletedCompassLinkId", nil)
+	}
+
+	linkID := stringOf(input["link"])
+	if !slices.ContainsFunc(component.Links, func(link Link) bool { return link.ID == linkID }) {
+		return with(payload(notFound("Link not found")), "deletedCompassLinkId", nil)
+	}
+	component.Links = slices.DeleteFunc(component.Links, func(link Link) bool { return link.ID == linkID })
+
+	return with(payload(), "deletedCompassLinkId", linkID)
+}
+
+func (s *Server) createMetricSource(variables map[string]interface{}) interface{} {
+	componentID := stringOf(variables["componentId"])
+	metricID := stringOf(variables["metricId"])
+	if _, exists := s.components[componentID]; !exists {
+		return with(payload(notFound("Component not found")), "createdMetricSource", nil)
+	}
+	if _, exists := s.metrics[metricID]; !exists {
+		return with(payload(notFound("Metric definition not found")), "createdMetricSource", nil)
+	}
+	for _, metricSource := range s.metricSources {
+		if metricSource.ComponentID == componentID && metricSource.MetricDefinitionID == metricID {
+			return with(payload(alreadyExists("Metric source already exists")), "createdMetricSource", nil)
+		}
+	}
+
+	metricSource := &MetricSource{
+		ID:                 s.newID("metric-source"),
+		ComponentID:        componentID,
+		MetricDefinitionID: metricID,
+		ExternalID:         stringOf(variables["externalId"]),
+	}
+	s.metricSources[metricSource.ID] = metricSource
+
+	return with(payload(), "createdMetricSource", map[string]interface{}{"id": metricSource.ID})
+}
+
+func (s *Server) deleteMetricSource(variables map[string]interface{}) interface{} {
+	id := stringOf(mapOf(variables["input"])["id"])
+	if id == "" {
+		id = stringOf(variables["id"])
+	}
+	if _, exists := s.metricSources[id]; !exists {
+		return with(payload(notFound("Metric source not found")), "deletedMetricSourceId", nil)
+	}
+
+	delete(s.metricSources, id)
+
+	return with(payload(), "deletedMetricSourceId", id)
+}
+
+func (s *Server) addDocument(variables map[string]interface{}) interface{} {
+	input := mapOf(variables["input"])
+	componentID := stringOf(input["componentId"])
+	if _, exists := s.components[componentID]; !exists {
+		return with(payload(notFound("Component not found")), "documentDetails", nil)
+	}
+
+	document := &Document{
+		ID:          s.newID("document"),
+		ComponentID: componentID,
+		Title:       stringOf(input["title"]),
+		URL:         stringOf(input["url"]),
+		CategoryID:  stringOf(input["documentationCategoryId"]),
+	}
+	s.documents[document.ID] = document
+
+	return with(payload(), "documentDetails", documentOf(document))
+}
+
+func (s *Server) updateDocument(variables map[string]interface{}) interface{} {
+	input := mapOf(variables["input"])
+	document, exists := s.documents[stringOf(input["id"])]
+	if !exists {
+		return with(payload(notFound("Document not found")), "documentDetails", nil)
+	}
+
+	document.Title = stringOf(input["title"])
+	document.URL = stringOf(input["url"])
+	document.CategoryID = stringOf(input["documentationCategoryId"])
+
+	return with(payload(), "documentDetails", documentOf(document))
+}
+
+func (s *Server) deleteDocument(variables map[string]interface{}) interface{} {
+	id := stringOf(mapOf(variables["input"])["id"])
+	if _, exists := s.documents[id]; !exists {
+		return payload(notFound("Document not found"))
+	}
+
+	delete(s.documents, id)
+
+	return payload()
+}
+
+func (s *Server) listDocuments(variables map[string]interface{}) interface{} {
+	componentID := stringOf(variables["componentId"])
+	nodes := make([]map[string]interface{}, 0)
+	for _, document := range sortedValues(s.documents, func(document Document) string { return document.ID }) {
+		if document.ComponentID == componentID {
+			nodes = append(nodes, documentOf(&document))
+		}
+	}
+
+	return map[string]interface{}{"nodes": nodes}
+}
+
+func (s *Server) documentationCategories(variables map[string]interface{}) interface{} {
+	names := make([]string, 0, len(DocumentationCategories))
+	for name := range DocumentationCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	nodes := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		nodes[i] = map[string]interface{}{"id": DocumentationCategories[name], "name": name, "description": ""}
+	}
+
+	return map[string]interface{}{"nodes": nodes}
+}
+
+func (s *Server) findComponentBySlug(slug string) *Component {
+	for _, component := range s.components {
+		if component.Slug == slug {
+			return component
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) newLink(input map[string]interface{}) Link {
+	return Link{
+		ID:   s.newID("link"),
+		Type: stringOf(input["type"]),
+		Name: stringOf(input["name"]),
+		URL:  stringOf(input["url"]),
+	}
+}
+
+func (s *Server) newCriterion(input map[string]interface{}) Criterion {
+	criterion := criterionOf(input)
+	criterion.ID = s.newID("scorecard-criteria")
+
+	return criterion
+}
+
+func setScorecardDetails(scorecard *Scorecard, details map[string]interface{}) {
+	scorecard.Name = stringOf(details["name"])
+	scorecard.Description = stringOf(details["description"])
+	scorecard.State = stringOf(details["state"])
+	scorecard.Importance = stringOf(details["importance"])
+	scorecard.ScoringStrategyType = stringOf(details["scoringStrategyType"])
+	scorecard.ComponentTypeIDs = listOf(details["componentTypeIds"])
+	if ownerID, set := details["ownerId"]; set {
+		scorecard.OwnerID = stringOf(ownerID)
+	}
+}
+
+func criterionOf(input map[string]interface{}) Criterion {
+	value := mapOf(input["hasMetricValue"])
+
+	return Criterion{
+		ID:                 stringOf(value["id"]),
+		Name:               stringOf(value["name"]),
+		Weight:             int(numberOf(value["weight"])),
+		MetricDefinitionID: stringOf(value["metricDefinitionId"]),
+		Comparator:         stringOf(value["comparator"]),
+		ComparatorValue:    numberOf(value["comparatorValue"]),
+	}
+}
+
+func linksOf(component *Component) []map[string]interface{} {
+	links := make([]map[string]interface{}, len(component.Links))
+	for i, link := range component.Links {
+		links[i] = map[string]interface{}{"id": link.ID, "type": link.Type, "name": link.Name, "url": link.URL}
+	}
+
+	return links
+}
+
+func documentOf(document *Document) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                      document.ID,
+		"title":                   document.Title,
+		"url":                     document.URL,
+		"componentId":             document.ComponentID,
+		"documentationCategoryId": document.CategoryID,
+	}
+}
+
+func stringOf(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// numberOf reads numbers, which the repositories send either as numbers or as strings
+func numberOf(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case string:
+		number, _ := strconv.ParseFloat(v, 64)
+		return number
+	}
+
+	return 0
+}
+
+func mapOf(value interface{}) map[string]interface{} {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m
+	}
+
+	return map[string]interface{}{}
+}
+
+func mapsOf(value interface{}) []map[string]interface{} {
+	items, _ := value.([]interface{})
+	maps := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		maps = append(maps, mapOf(item))
+	}
+
+	return maps
+}
+
+func listOf(value interface{}) []string {
+	items, _ := value.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		list = append(list, stringOf(item))
+	}
+
+	return list
+}
@@ -0,0 +1,16 @@
+package dtos
+
+// PageInfo is the pagination state of a connection.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// AfterVariable returns the after variable of a paginated query, null for the first page.
+func AfterVariable(cursor string) interface{} {
+	if cursor == "" {
+		return nil
+	}
+
+	return cursor
+}
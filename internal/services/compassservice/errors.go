@@ -1,14 +1,81 @@
 package compassservice
 
-import "regexp"
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
+// ErrorClass is the kind of failure of a Compass call, for callers to branch on.
+type ErrorClass string
+
+const (
+	ErrorClassUnknown          ErrorClass = "UNKNOWN"
+	ErrorClassAlreadyExists    ErrorClass = "ALREADY_EXISTS"
+	ErrorClassNotFound         ErrorClass = "NOT_FOUND"
+	ErrorClassInvalidInput     ErrorClass = "INVALID_INPUT"
+	ErrorClassUnauthenticated  ErrorClass = "UNAUTHENTICATED"
+	ErrorClassPermissionDenied ErrorClass = "PERMISSION_DENIED"
+	ErrorClassRateLimited      ErrorClass = "RATE_LIMITED"
+	ErrorClassServer           ErrorClass = "SERVER_ERROR"
+	ErrorClassTransport        ErrorClass = "TRANSPORT_ERROR"
+)
+
+// errorTypeClasses maps the error types set by Compass in the error extensions to their class
+var errorTypeClasses = map[string]ErrorClass{
+	"ALREADY_EXISTS":        ErrorClassAlreadyExists,
+	"CONFLICT":              ErrorClassAlreadyExists,
+	"NOT_FOUND":             ErrorClassNotFound,
+	"BAD_REQUEST":           ErrorClassInvalidInput,
+	"INVALID_INPUT":         ErrorClassInvalidInput,
+	"VALIDATION_ERROR":      ErrorClassInvalidInput,
+	"UNAUTHORIZED":          ErrorClassUnauthenticated,
+	"UNAUTHENTICATED":       ErrorClassUnauthenticated,
+	"FORBIDDEN":             ErrorClassPermissionDenied,
+	"PERMISSION_DENIED":     ErrorClassPermissionDenied,
+	"RATE_LIMITED":          ErrorClassRateLimited,
+	"TOO_MANY_REQUESTS":     ErrorClassRateLimited,
+	"INTERNAL_SERVER_ERROR": ErrorClassServer,
+	"SERVICE_UNAVAILABLE":   ErrorClassServer,
+}
+
+// CompassError is an error of a mutation payload.
 type CompassError struct {
-	Message string
+	Message    string                 `json:"message"`
+	Extensions CompassErrorExtensions `json:"extensions"`
+}
+
+type CompassErrorExtensions struct {
+	StatusCode int    `json:"statusCode"`
+	ErrorType  string `json:"errorType"`
+}
+
+// Class returns the class of the error from its extensions. Errors without extensions are of unknown class.
+func (e CompassError) Class() ErrorClass {
+	if class, exists := errorTypeClasses[strings.ToUpper(e.Extensions.ErrorType)]; exists {
+		return class
+	}
+	if e.Extensions.StatusCode != 0 {
+		return classOfStatus(e.Extensions.StatusCode)
+	}
+
+	return ErrorClassUnknown
 }
 
 func HasAlreadyExistsError(errs []CompassError) bool {
+	return hasErrorClass(errs, ErrorClassAlreadyExists)
+}
+
+func HasNotFoundError(errs []CompassError) bool {
+	return hasErrorClass(errs, ErrorClassNotFound)
+}
+
+func hasErrorClass(errs []CompassError, class ErrorClass) bool {
 	for _, err := range errs {
-		if isAlreadyExistsError(err.Message) {
+		if err.Class() == class {
 			return true
 		}
 	}
@@ -16,24 +83,132 @@ func HasAlreadyExistsError(errs []CompassError) bool {
 	return false
 }
 
-func isAlreadyExistsError(err string) bool {
-	matched, _ := regexp.MatchString("^.*already exists", err)
+// RateLimit is the rate limit state reported by Compass in the response headers.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// RetryAfter is the delay requested by Compass before the next call, zero when not given
+	RetryAfter time.Duration
+}
 
-	return matched
+// Error is returned by CompassService when a call fails, with Err describing the failure.
+// Use errors.As to branch on its class:
+//
+//	var compassErr *compassservice.Error
+//	if errors.As(err, &compassErr) && compassErr.Class == compassservice.ErrorClassPermissionDenied {
+type Error struct {
+	// Operation is the GraphQL operation or the REST endpoint called
+	Operation string
+	Class     ErrorClass
+	// StatusCode is the HTTP status code of the response, zero when no response was received
+	StatusCode int
+	// Retryable tells whether the same call may succeed later
+	Retryable bool
+	// RateLimit is set when the response reports rate limit headers
+	RateLimit *RateLimit
+	// Errors are the errors of the mutation payload, if any
+	Errors []CompassError
+	Err    error
 }
 
-func HasNotFoundError(errs []CompassError) bool {
-	for _, err := range errs {
-		if isNotFoundError(err.Message) {
-			return true
-		}
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newTransportError returns the error of a call which got no response.
+func newTransportError(operation string, err error) *Error {
+	return &Error{
+		Operation: operation,
+		Class:     ErrorClassTransport,
+		Retryable: !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded),
+		Err:       err,
 	}
+}
 
-	return false
+// newResponseError returns the error of a call answered with a non successful status code.
+func newResponseError(operation string, statusCode int, header http.Header, err error) *Error {
+	class := classOfStatus(statusCode)
+	return &Error{
+		Operation:  operation,
+		Class:      class,
+		StatusCode: statusCode,
+		Retryable:  class == ErrorClassRateLimited || class == ErrorClassServer,
+		RateLimit:  parseRateLimit(header),
+		Err:        err,
+	}
+}
+
+// newPayloadError returns the error of a mutation whose payload reports errors. It takes the class of
+// the first error, the others usually being consequences of it.
+func newPayloadError(operation string, errs []CompassError, err error) *Error {
+	compassErr := &Error{Operation: operation, Class: ErrorClassUnknown, Errors: errs, Err: err}
+	if len(errs) > 0 {
+		compassErr.Class = errs[0].Class()
+		compassErr.StatusCode = errs[0].Extensions.StatusCode
+		compassErr.Retryable = compassErr.Class == ErrorClassRateLimited || compassErr.Class == ErrorClassServer
+	}
+
+	return compassErr
 }
 
-func isNotFoundError(err string) bool {
-	matched, _ := regexp.MatchString(".*not found", err)
+func classOfStatus(statusCode int) ErrorClass {
+	switch {
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return ErrorClassInvalidInput
+	case statusCode == http.StatusUnauthorized:
+		return ErrorClassUnauthenticated
+	case statusCode == http.StatusForbidden:
+		return ErrorClassPermissionDenied
+	case statusCode == http.StatusNotFound:
+		return ErrorClassNotFound
+	case statusCode == http.StatusConflict:
+		return ErrorClassAlreadyExists
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorClassServer
+	}
+
+	return ErrorClassUnknown
+}
+
+// parseRateLimit reads the Retry-After and X-RateLimit-* headers, returning nil when none is set.
+func parseRateLimit(header http.Header) *RateLimit {
+	if header == nil {
+		return nil
+	}
+
+	retryAfter, limit, remaining, reset := header.Get("Retry-After"), header.Get("X-RateLimit-Limit"), header.Get("X-RateLimit-Remaining"), header.Get("X-RateLimit-Reset")
+	if retryAfter == "" && limit == "" && remaining == "" && reset == "" {
+		return nil
+	}
+
+	rateLimit := &RateLimit{}
+	rateLimit.Limit, _ = strconv.Atoi(limit)
+	rateLimit.Remaining, _ = strconv.Atoi(remaining)
+	rateLimit.Reset = parseTime(reset)
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		rateLimit.RetryAfter = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(retryAfter); err == nil {
+		rateLimit.RetryAfter = max(time.Until(date), 0)
+	}
+
+	return rateLimit
+}
+
+// parseTime reads a time given either as unix seconds or in RFC 3339.
+func parseTime(value string) time.Time {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0)
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
 
-	return matched
+	return time.Time{}
 }
@@ -0,0 +1,272 @@
+package compassservice_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/compassservice/dtos"
+	mocks "github.com/motain/of-catalog/internal/services/compassservice/mocks"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompassError_Class(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      compassservice.CompassError
+		expected compassservice.ErrorClass
+	}{
+		{
+			name:     "error type",
+			err:      compassError("Component with slug svc-a already exists", 0, "ALREADY_EXISTS"),
+			expected: compassservice.ErrorClassAlreadyExists,
+		},
+		{
+			name:     "error type takes precedence over the message",
+			err:      compassError("Forbidden: component not found in workspace", 0, "FORBIDDEN"),
+			expected: compassservice.ErrorClassPermissionDenied,
+		},
+		{
+			name:     "status code without error type",
+			err:      compassError("Component not found", http.StatusNotFound, ""),
+			expected: compassservice.ErrorClassNotFound,
+		},
+		{
+			name:     "server status code",
+			err:      compassError("Something went wrong", http.StatusBadGateway, ""),
+			expected: compassservice.ErrorClassServer,
+		},
+		{
+			name:     "message without extensions",
+			err:      compassError("Metric definition with name a already exists", 0, ""),
+			expected: compassservice.ErrorClassUnknown,
+		},
+		{
+			name:     "unknown",
+			err:      compassError("Something went wrong", 0, ""),
+			expected: compassservice.ErrorClassUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.Class())
+		})
+	}
+}
+
+func TestHasAlreadyExistsError(t *testing.T) {
+	assert.True(t, compassservice.HasAlreadyExistsError([]compassservice.CompassError{
+		compassError("Invalid name", 0, "BAD_REQUEST"),
+		compassError("Name taken", http.StatusConflict, ""),
+	}))
+	assert.False(t, compassservice.HasAlreadyExistsError([]compassservice.CompassError{
+		compassError("You do not have permission, the component already exists", http.StatusForbidden, "FORBIDDEN"),
+	}))
+	assert.True(t, compassservice.HasNotFoundError([]compassservice.CompassError{compassError("Link not found", 0, "NOT_FOUND")}))
+	assert.False(t, compassservice.HasNotFoundError([]compassservice.CompassError{compassError("Link not found", 0, "")}))
+}
+
+type failedOutput struct {
+	errs []compassservice.CompassError
+}
+
+func (o *failedOutput) IsSuccessful() bool { return false }
+
+func (o *failedOutput) GetErrors() []string {
+	return []string{o.errs[0].Message}
+}
+
+func (o *failedOutput) GetCompassErrors() []compassservice.CompassError { return o.errs }
+
+type input struct {
+	dtos.InputDTO
+}
+
+func (i *input) GetQuery() string                     { return "mutation createComponent ($name: String!) { }" }
+func (i *input) SetVariables() map[string]interface{} { return nil }
+
+func TestCompassService_RunWithDTOs_PayloadError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGqlClient := mocks.NewMockGraphQLClientInterface(ctrl)
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	expectRetryPolicy(mockConfigService, 0)
+	mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	service := compassservice.NewCompassService(mockConfigService, mockGqlClient, nil)
+	output := &failedOutput{errs: []compassservice.CompassError{compassError("Forbidden", http.StatusForbidden, "FORBIDDEN")}}
+
+	err := service.RunWithDTOs(context.Background(), &input{}, output)
+
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, err, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassPermissionDenied, compassErr.Class)
+	assert.Equal(t, "mutation createComponent", compassErr.Operation)
+	assert.Equal(t, http.StatusForbidden, compassErr.StatusCode)
+	assert.False(t, compassErr.Retryable)
+	assert.Equal(t, output.errs, compassErr.Errors)
+	assert.EqualError(t, err, "failed to execute mutation createComponent: [Forbidden]")
+}
+
+func TestCompassService_Run_ResponseError(t *testing.T) {
+	tests := []struct {
+		name              string
+		statusCode        int
+		header            map[string]string
+		body              string
+		expectedClass     compassservice.ErrorClass
+		expectedRetryable bool
+		expectedRateLimit *compassservice.RateLimit
+	}{
+		{
+			name:              "rate limited with a JSON body",
+			statusCode:        http.StatusTooManyRequests,
+			header:            map[string]string{"Retry-After": "3", "X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1767225600"},
+			body:              `{"message":"Too Many Requests"}`,
+			expectedClass:     compassservice.ErrorClassRateLimited,
+			expectedRetryable: true,
+			expectedRateLimit: &compassservice.RateLimit{Limit: 100, Remaining: 0, Reset: time.Unix(1767225600, 0), RetryAfter: 3 * time.Second},
+		},
+		{
+			name:              "server error",
+			statusCode:        http.StatusServiceUnavailable,
+			body:              `unavailable`,
+			expectedClass:     compassservice.ErrorClassServer,
+			expectedRetryable: true,
+		},
+		{
+			name:          "permission denied",
+			statusCode:    http.StatusForbidden,
+			body:          `{"message":"Forbidden"}`,
+			expectedClass: compassservice.ErrorClassPermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.header {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(tt.statusCode)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+			mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+			mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+			expectRetryPolicy(mockConfigService, 0)
+
+			gqlClient := compassservice.NewGraphQLClientForURI(server.URL)
+			service := compassservice.NewCompassService(mockConfigService, gqlClient, nil)
+
+			err := service.Run(context.Background(), "query getComponent { compass { component } }", nil, &struct{}{})
+
+			var compassErr *compassservice.Error
+			require.ErrorAs(t, err, &compassErr)
+			assert.Equal(t, tt.expectedClass, compassErr.Class)
+			assert.Equal(t, tt.statusCode, compassErr.StatusCode)
+			assert.Equal(t, tt.expectedRetryable, compassErr.Retryable)
+			assert.Equal(t, tt.expectedRateLimit, compassErr.RateLimit)
+			assert.Equal(t, "query getComponent", compassErr.Operation)
+		})
+	}
+}
+
+func TestCompassService_Run_GraphQLError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"data":null,"errors":[{"message":"Cannot find the component","extensions":{"statusCode":404,"errorType":"NOT_FOUND"}}]}`)
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	expectRetryPolicy(mockConfigService, 0)
+
+	gqlClient := compassservice.NewGraphQLClientForURI(server.URL)
+	service := compassservice.NewCompassService(mockConfigService, gqlClient, nil)
+
+	err := service.Run(context.Background(), "query getComponent { compass { component } }", nil, &struct{}{})
+
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, err, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassNotFound, compassErr.Class)
+	assert.Equal(t, http.StatusNotFound, compassErr.StatusCode)
+	assert.Equal(t, "Cannot find the component", compassErr.Errors[0].Message)
+}
+
+func TestCompassService_SendMetric_ResponseError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHttpClient := mocks.NewMockHTTPClientInterface(ctrl)
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	expectRetryPolicy(mockConfigService, 0)
+	mockHttpClient.EXPECT().Do(gomock.Any()).Return(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"1"}},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Too Many Requests"}`)),
+	}, nil)
+
+	service := compassservice.NewCompassService(mockConfigService, nil, mockHttpClient)
+
+	_, err := service.SendMetric(context.Background(), map[string]string{"value": "1"})
+
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, err, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassRateLimited, compassErr.Class)
+	assert.True(t, compassErr.Retryable)
+	assert.Equal(t, time.Second, compassErr.RateLimit.RetryAfter)
+	assert.Equal(t, "/gateway/api/compass/v1/metrics", compassErr.Operation)
+}
+
+func TestCompassService_SendMetric_TransportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHttpClient := mocks.NewMockHTTPClientInterface(ctrl)
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	expectRetryPolicy(mockConfigService, 0)
+	mockHttpClient.EXPECT().Do(gomock.Any()).Return(nil, context.Canceled)
+
+	service := compassservice.NewCompassService(mockConfigService, nil, mockHttpClient)
+
+	_, err := service.SendMetric(context.Background(), map[string]string{"value": "1"})
+
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, err, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassTransport, compassErr.Class)
+	assert.False(t, compassErr.Retryable, "canceled calls are not retried")
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func compassError(message string, statusCode int, errorType string) compassservice.CompassError {
+	err := compassservice.CompassError{Message: message}
+	err.Extensions.StatusCode = statusCode
+	err.Extensions.ErrorType = errorType
+
+	return err
+}
@@ -3,12 +3,19 @@ package compassservice
 //go:generate mockgen -destination=./mocks/mock_graphql_client.go -package=compassservice github.com/motain/of-catalog/internal/services/compassservice GraphQLClientInterface
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
 
 	"github.com/machinebox/graphql"
 	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 )
 
 type GraphQLClientInterface interface {
@@ -16,10 +23,73 @@ type GraphQLClientInterface interface {
 }
 
 func NewGraphQLClient(config configservice.ConfigServiceInterface) GraphQLClientInterface {
-	gqlUri := fmt.Sprintf("https://%s%s", config.GetCompassHost(), "/gateway/api/graphql")
-	client := graphql.NewClient(gqlUri)
+	scheme, host := splitCompassHost(config.GetCompassHost())
+	gqlUri := fmt.Sprintf("%s://%s%s", scheme, host, "/gateway/api/graphql")
+	return NewGraphQLClientForURI(gqlUri)
+}
+
+// NewGraphQLClientForURI creates a GraphQL client for an arbitrary endpoint. Its calls are recorded in,
+// or answered from, the cassette in use, as the graphql facts read through it.
+func NewGraphQLClientForURI(uri string) GraphQLClientInterface {
+	client := graphql.NewClient(uri, graphql.WithHTTPClient(&http.Client{
+		Transport: &responseMetaTransport{Transport: cassette.Wrap(http.DefaultTransport)},
+	}))
 
 	// Keep this until we properly implement logging
 	client.Log = func(s string) { log.Println(s) }
 	return client
 }
+
+// responseMeta holds the status code, headers and errors of the response to a GraphQL call,
+// which the graphql client does not expose. The client only keeps the message of the first error.
+type responseMeta struct {
+	StatusCode int
+	Header     http.Header
+	Errors     []CompassError
+	// Sent is set once the request is written, from then on Compass may have processed it
+	Sent atomic.Bool
+}
+
+type responseMetaKey struct{}
+
+// withResponseMeta returns a context collecting the response meta of the GraphQL call made with it.
+func withResponseMeta(ctx context.Context) (context.Context, *responseMeta) {
+	meta := &responseMeta{}
+	ctx = withSentTrace(ctx, &meta.Sent)
+	return context.WithValue(ctx, responseMetaKey{}, meta), meta
+}
+
+// withSentTrace returns a context setting sent once the headers of the request made with it are written.
+func withSentTrace(ctx context.Context, sent *atomic.Bool) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteHeaders: func() { sent.Store(true) },
+	})
+}
+
+type responseMetaTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *responseMetaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Transport.RoundTrip(req)
+	if meta, exists := req.Context().Value(responseMetaKey{}).(*responseMeta); exists && resp != nil {
+		meta.StatusCode = resp.StatusCode
+		meta.Header = resp.Header
+
+		body, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		var graphqlResponse struct {
+			Errors []CompassError `json:"errors"`
+		}
+		if json.Unmarshal(body, &graphqlResponse) == nil {
+			meta.Errors = graphqlResponse.Errors
+		}
+	}
+
+	return resp, err
+}
@@ -17,6 +17,8 @@ type HTTPClientInterface interface {
 type CompassTransport struct {
 	Transport http.RoundTripper
 	BaseURL   string
+	// Scheme of the Compass host, https when empty
+	Scheme    string
 	Host      string
 	AuthToken string
 }
@@ -28,10 +30,12 @@ func NewHTTPClient(config configservice.ConfigServiceInterface) HTTPClientInterf
 		DisableKeepAlives: false,
 	}
 
+	scheme, host := splitCompassHost(config.GetCompassHost())
 	return &http.Client{
 		Transport: &CompassTransport{
 			Transport: baseTransport,
-			Host:      config.GetCompassHost(),
+			Scheme:    scheme,
+			Host:      host,
 			AuthToken: config.GetCompassToken(),
 		},
 	}
@@ -39,7 +43,10 @@ func NewHTTPClient(config configservice.ConfigServiceInterface) HTTPClientInterf
 
 func (c *CompassTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if !strings.HasPrefix(req.URL.String(), "http") {
-		req.URL.Scheme = "https"
+		req.URL.Scheme = c.Scheme
+		if req.URL.Scheme == "" {
+			req.URL.Scheme = "https"
+		}
 		req.URL.Host = c.Host
 		req.URL.Path = c.BaseURL + req.URL.Path
 	}
@@ -49,3 +56,13 @@ func (c *CompassTransport) RoundTrip(req *http.Request) (*http.Response, error)
 
 	return c.Transport.RoundTrip(req)
 }
+
+// splitCompassHost returns the scheme and the host of the Compass host setting. The host is given without
+// protocol and served over https, except for a local emulator which can be given as http://127.0.0.1:8080.
+func splitCompassHost(compassHost string) (string, string) {
+	if scheme, host, found := strings.Cut(compassHost, "://"); found {
+		return scheme, host
+	}
+
+	return "https", compassHost
+}
@@ -0,0 +1,93 @@
+package compassservice
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+// maxRetryDelay caps the backoff between retries, a Retry-After requested by Compass is honored as is
+const maxRetryDelay = 30 * time.Second
+
+// RetryPolicy tells how failed Compass calls are retried. Only retryable errors are retried,
+// see Error.Retryable.
+type RetryPolicy struct {
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry, doubled at each retry
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func NewRetryPolicy(config configservice.ConfigServiceInterface) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: config.GetCompassMaxRetries(),
+		BaseDelay:  config.GetCompassRetryBaseDelay(),
+		MaxDelay:   maxRetryDelay,
+	}
+}
+
+// Delay returns the delay before retrying a call failed with err at the given attempt, starting at 0.
+// It is the Retry-After requested by Compass, the time until the rate limit resets when it is exhausted,
+// or an exponential backoff with jitter.
+func (p RetryPolicy) Delay(attempt int, err *Error) time.Duration {
+	if rateLimit := err.RateLimit; rateLimit != nil {
+		if rateLimit.RetryAfter > 0 {
+			return rateLimit.RetryAfter
+		}
+		if untilReset := time.Until(rateLimit.Reset); rateLimit.Remaining == 0 && untilReset > 0 {
+			return min(untilReset, p.MaxDelay)
+		}
+	}
+
+	backoff := min(p.BaseDelay<<attempt, p.MaxDelay)
+	if backoff <= 0 {
+		return 0
+	}
+
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// unprocessed tells whether a call failed with err was not processed by Compass, so that retrying a call which
+// is not idempotent, such as a mutation, cannot apply it twice: the request was not sent, or Compass rejected it
+// because of the rate limit or its unavailability. It does not change whether the call may succeed later.
+func unprocessed(err *Error, sent bool) bool {
+	if err.Class == ErrorClassTransport {
+		return !sent
+	}
+
+	return err.Class == ErrorClassRateLimited || err.StatusCode == http.StatusServiceUnavailable
+}
+
+// withRetry runs call once the token bucket allows it, retrying it on retryable errors as long as the policy allows.
+// call returns whether its request was sent: a call which is not idempotent is only retried when unprocessed.
+// A rate limited call throttles the bucket, holding back the other calls of the run too.
+func (c *CompassService) withRetry(ctx context.Context, idempotent bool, call func() (bool, error)) error {
+	for attempt := 0; ; attempt++ {
+		if waitErr := c.limiter.Wait(ctx); waitErr != nil {
+			return waitErr
+		}
+
+		sent, err := call()
+		var compassErr *Error
+		if err == nil || !errors.As(err, &compassErr) || !compassErr.Retryable || attempt >= c.retryPolicy.MaxRetries {
+			return err
+		}
+		if !idempotent && !unprocessed(compassErr, sent) {
+			return err
+		}
+
+		delay := c.retryPolicy.Delay(attempt, compassErr)
+		if compassErr.Class == ErrorClassRateLimited {
+			c.limiter.Throttle(delay)
+		}
+		log.Printf("%s failed (attempt %d/%d), retrying in %v: %v", compassErr.Operation, attempt+1, c.retryPolicy.MaxRetries+1, delay, err)
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
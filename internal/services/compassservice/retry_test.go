@@ -0,0 +1,296 @@
+package compassservice_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	mocks "github.com/motain/of-catalog/internal/services/compassservice/mocks"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// expectRetryPolicy sets the retry policy of the service built from mockConfigService, without rate limit.
+func expectRetryPolicy(mockConfigService *configservicemocks.MockConfigServiceInterface, maxRetries int) {
+	mockConfigService.EXPECT().GetCompassMaxRetries().Return(maxRetries)
+	mockConfigService.EXPECT().GetCompassRetryBaseDelay().Return(time.Millisecond)
+	mockConfigService.EXPECT().GetCompassRateLimit().Return(0.0)
+}
+
+func newMockConfigService(ctrl *gomock.Controller, maxRetries int) *configservicemocks.MockConfigServiceInterface {
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	expectRetryPolicy(mockConfigService, maxRetries)
+
+	return mockConfigService
+}
+
+func TestCompassService_Run_Retry(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCodes   []int
+		expectedCalls int32
+		expectedError bool
+	}{
+		{name: "retries server errors", statusCodes: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK}, expectedCalls: 3},
+		{name: "retries rate limited calls", statusCodes: []int{http.StatusTooManyRequests, http.StatusOK}, expectedCalls: 2},
+		{name: "does not retry permission denied", statusCodes: []int{http.StatusForbidden, http.StatusOK}, expectedCalls: 1, expectedError: true},
+		{name: "gives up after the last retry", statusCodes: []int{500, 500, 500, 500, http.StatusOK}, expectedCalls: 4, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				statusCode := tt.statusCodes[calls.Add(1)-1]
+				w.WriteHeader(statusCode)
+				if statusCode == http.StatusOK {
+					io.WriteString(w, `{"data":{"compass":{"component":{"id":"id"}}}}`)
+					return
+				}
+				io.WriteString(w, `{"message":"failed"}`)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := compassservice.NewCompassService(newMockConfigService(ctrl, 3), compassservice.NewGraphQLClientForURI(server.URL), nil)
+
+			var response struct {
+				Compass struct {
+					Component struct {
+						ID string `json:"id"`
+					} `json:"component"`
+				} `json:"compass"`
+			}
+			err := service.Run(context.Background(), "query getComponent { compass { component { id } } }", nil, &response)
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "id", response.Compass.Component.ID)
+		})
+	}
+}
+
+func TestCompassService_Run_RetryTransportError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGqlClient := mocks.NewMockGraphQLClientInterface(ctrl)
+	gomock.InOrder(
+		mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection reset by peer")),
+		mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	service := compassservice.NewCompassService(newMockConfigService(ctrl, 1), mockGqlClient, nil)
+
+	assert.NoError(t, service.Run(context.Background(), "query getComponent { compass { component } }", nil, &struct{}{}))
+}
+
+func TestCompassService_Run_RetryMutation(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCodes   []int
+		expectedCalls int32
+		expectedError bool
+	}{
+		{name: "retries unavailable", statusCodes: []int{http.StatusServiceUnavailable, http.StatusOK}, expectedCalls: 2},
+		{name: "retries rate limited calls", statusCodes: []int{http.StatusTooManyRequests, http.StatusOK}, expectedCalls: 2},
+		{name: "does not retry server errors", statusCodes: []int{http.StatusBadGateway, http.StatusOK}, expectedCalls: 1, expectedError: true},
+		{name: "does not retry a dropped connection", statusCodes: []int{0, http.StatusOK}, expectedCalls: 1, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				statusCode := tt.statusCodes[calls.Add(1)-1]
+				if statusCode == 0 {
+					// The mutation was received, but the connection drops before the response
+					conn, _, _ := w.(http.Hijacker).Hijack()
+					conn.Close()
+					return
+				}
+				w.WriteHeader(statusCode)
+				if statusCode == http.StatusOK {
+					io.WriteString(w, `{"data":{"compass":{"createComponent":{"success":true}}}}`)
+					return
+				}
+				io.WriteString(w, `{"message":"failed"}`)
+			}))
+			defer server.Close()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service := compassservice.NewCompassService(newMockConfigService(ctrl, 3), compassservice.NewGraphQLClientForURI(server.URL), nil)
+
+			err := service.Run(context.Background(), "mutation createComponent { compass { createComponent { success } } }", nil, &struct{}{})
+
+			assert.Equal(t, tt.expectedCalls, calls.Load())
+			if tt.expectedError {
+				var compassErr *compassservice.Error
+				require.ErrorAs(t, err, &compassErr)
+				assert.True(t, compassErr.Retryable, "the mutation may succeed later, although it is not retried now")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCompassService_Run_RetryUnsentMutation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGqlClient := mocks.NewMockGraphQLClientInterface(ctrl)
+	gomock.InOrder(
+		mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("dial tcp: connection refused")),
+		mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+	)
+
+	service := compassservice.NewCompassService(newMockConfigService(ctrl, 1), mockGqlClient, nil)
+
+	assert.NoError(t, service.Run(context.Background(), "mutation createComponent { compass { createComponent } }", nil, &struct{}{}))
+}
+
+func TestCompassService_SendMetric_Retry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var bodies []string
+	mockHttpClient := mocks.NewMockHTTPClientInterface(ctrl)
+	mockHttpClient.EXPECT().Do(gomock.Any()).Times(2).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"0"}},
+				Body:       io.NopCloser(strings.NewReader(`{"message":"Too Many Requests"}`)),
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`success`))}, nil
+	})
+
+	service := compassservice.NewCompassService(newMockConfigService(ctrl, 3), nil, mockHttpClient)
+
+	resp, err := service.SendMetric(context.Background(), map[string]string{"value": "1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "success", resp)
+	assert.Equal(t, []string{`{"value":"1"}`, `{"value":"1"}`}, bodies, "the body is sent again on retry")
+}
+
+func TestCompassService_SendMetric_ServerErrorNotRetried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHttpClient := mocks.NewMockHTTPClientInterface(ctrl)
+	mockHttpClient.EXPECT().Do(gomock.Any()).Times(1).Return(&http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Internal Server Error"}`)),
+	}, nil)
+
+	service := compassservice.NewCompassService(newMockConfigService(ctrl, 3), nil, mockHttpClient)
+
+	_, err := service.SendMetric(context.Background(), map[string]string{"value": "1"})
+
+	var compassErr *compassservice.Error
+	require.ErrorAs(t, err, &compassErr)
+	assert.Equal(t, compassservice.ErrorClassServer, compassErr.Class)
+	assert.True(t, compassErr.Retryable, "the value may be pushed later, although it is not retried now")
+}
+
+func TestCompassService_Run_CanceledDuringBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfigService := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfigService.EXPECT().GetCompassToken().Return("mock-token")
+	mockConfigService.EXPECT().GetCompassCloudId().Return("mock-cloud-id")
+	mockConfigService.EXPECT().GetCompassMaxRetries().Return(3)
+	mockConfigService.EXPECT().GetCompassRetryBaseDelay().Return(time.Hour)
+	mockConfigService.EXPECT().GetCompassRateLimit().Return(0.0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockGqlClient := mocks.NewMockGraphQLClientInterface(ctrl)
+	mockGqlClient.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, interface{}, interface{}) error {
+		cancel()
+		return errors.New("connection reset by peer")
+	})
+
+	service := compassservice.NewCompassService(mockConfigService, mockGqlClient, nil)
+
+	err := service.Run(ctx, "query getComponent { compass { component } }", nil, &struct{}{})
+
+	assert.EqualError(t, err, "connection reset by peer")
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	policy := compassservice.RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name        string
+		attempt     int
+		err         *compassservice.Error
+		expectedMin time.Duration
+		expectedMax time.Duration
+	}{
+		{
+			name:        "retry after",
+			err:         &compassservice.Error{RateLimit: &compassservice.RateLimit{RetryAfter: 5 * time.Second}},
+			expectedMin: 5 * time.Second,
+			expectedMax: 5 * time.Second,
+		},
+		{
+			name:        "until the exhausted rate limit resets, capped",
+			err:         &compassservice.Error{RateLimit: &compassservice.RateLimit{Remaining: 0, Reset: time.Now().Add(time.Hour)}},
+			expectedMin: time.Second,
+			expectedMax: time.Second,
+		},
+		{
+			name:        "jittered backoff of the first attempt",
+			err:         &compassservice.Error{},
+			expectedMin: 50 * time.Millisecond,
+			expectedMax: 100 * time.Millisecond,
+		},
+		{
+			name:        "jittered backoff doubled at each attempt",
+			attempt:     2,
+			err:         &compassservice.Error{RateLimit: &compassservice.RateLimit{Remaining: 10}},
+			expectedMin: 200 * time.Millisecond,
+			expectedMax: 400 * time.Millisecond,
+		},
+		{
+			name:        "backoff capped",
+			attempt:     10,
+			err:         &compassservice.Error{},
+			expectedMin: 500 * time.Millisecond,
+			expectedMax: time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay := policy.Delay(tt.attempt, tt.err)
+			assert.GreaterOrEqual(t, delay, tt.expectedMin)
+			assert.LessOrEqual(t, delay, tt.expectedMax)
+		})
+	}
+}
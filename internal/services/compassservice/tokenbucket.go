@@ -0,0 +1,97 @@
+package compassservice
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// TokenBucket limits the rate of the Compass calls of a run. It holds up to burst tokens, refilled at
+// rate tokens per second, and each call takes one. A nil TokenBucket does not limit calls.
+type TokenBucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full bucket allowing rate calls per second, in bursts of up to rate calls.
+// It returns nil when rate is not positive.
+func NewTokenBucket(rate float64) *TokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	burst := math.Max(1, math.Ceil(rate))
+	return &TokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// Wait takes a token, waiting for it to be available or ctx to be done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mutex.Lock()
+	b.refill()
+	b.tokens--
+	wait := b.durationOf(-b.tokens)
+	b.mutex.Unlock()
+
+	if err := sleep(ctx, wait); err != nil {
+		b.mutex.Lock()
+		b.tokens++
+		b.mutex.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Throttle empties the bucket so that no call is made for the next d, as requested by Compass
+// when rate limiting the client.
+func (b *TokenBucket) Throttle(d time.Duration) {
+	if b == nil || d <= 0 {
+		return
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.refill()
+	b.tokens = math.Min(b.tokens, -d.Seconds()*b.rate)
+}
+
+func (b *TokenBucket) refill() {
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// durationOf returns the time needed to refill tokens.
+func (b *TokenBucket) durationOf(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return time.Duration(tokens / b.rate * float64(time.Second))
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package compassservice_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket_Wait(t *testing.T) {
+	bucket := compassservice.NewTokenBucket(20)
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		require.NoError(t, bucket.Wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 25*time.Millisecond, "a burst of rate calls is not delayed")
+
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "the next call waits for a token")
+}
+
+func TestTokenBucket_Throttle(t *testing.T) {
+	bucket := compassservice.NewTokenBucket(1000)
+	bucket.Throttle(50 * time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, bucket.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestTokenBucket_WaitCanceled(t *testing.T) {
+	bucket := compassservice.NewTokenBucket(1)
+	require.NoError(t, bucket.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, bucket.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestTokenBucket_Unlimited(t *testing.T) {
+	bucket := compassservice.NewTokenBucket(0)
+	assert.Nil(t, bucket)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, bucket.Wait(context.Background()))
+	}
+	bucket.Throttle(time.Hour)
+	assert.NoError(t, bucket.Wait(context.Background()))
+}
@@ -5,6 +5,8 @@ package configservice
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,12 +16,21 @@ type ConfigServiceInterface interface {
 	GetGithubOrg() string
 	GetGithubToken() string
 	GetGithubUser() string
+	GetGithubAPIURL() string
 	GetCompassToken() string
 	GetCompassHost() string
 	GetCompassCloudId() string
+	GetCompassMaxRetries() int
+	GetCompassRetryBaseDelay() time.Duration
+	GetCompassRateLimit() float64
 	GetPrometheusURL() string
 	GetAWSRegion() string
 	GetAWSRole() string
+	GetCheckoutPath() string
+	GetCatalogBackend() string
+	GetCatalogPath() string
+	GetPushQueuePath() string
+	GetMetricHistoryPath() string
 }
 
 type ConfigService struct{}
@@ -53,6 +64,14 @@ func (c *ConfigService) GetGithubUser() string {
 	return os.Getenv("GITHUB_USER")
 }
 
+func (c *ConfigService) GetGithubAPIURL() string {
+	githubAPIURL := os.Getenv("GITHUB_API_URL")
+	if githubAPIURL == "" {
+		return "https://api.github.com"
+	}
+	return githubAPIURL
+}
+
 func (c *ConfigService) GetCompassToken() string {
 	return os.Getenv("COMPASS_TOKEN")
 }
@@ -65,6 +84,35 @@ func (c *ConfigService) GetCompassCloudId() string {
 	return os.Getenv("COMPASS_CLOUD_ID")
 }
 
+// GetCompassMaxRetries returns the number of retries of a failed Compass call, 3 by default.
+func (c *ConfigService) GetCompassMaxRetries() int {
+	maxRetries, err := strconv.Atoi(os.Getenv("COMPASS_MAX_RETRIES"))
+	if err != nil || maxRetries < 0 {
+		return 3
+	}
+	return maxRetries
+}
+
+// GetCompassRetryBaseDelay returns the delay before the first retry of a failed Compass call, doubled
+// at each retry, 1s by default.
+func (c *ConfigService) GetCompassRetryBaseDelay() time.Duration {
+	baseDelay, err := time.ParseDuration(os.Getenv("COMPASS_RETRY_BASE_DELAY"))
+	if err != nil || baseDelay < 0 {
+		return time.Second
+	}
+	return baseDelay
+}
+
+// GetCompassRateLimit returns the maximum number of Compass calls per second, 10 by default.
+// Zero disables the limit.
+func (c *ConfigService) GetCompassRateLimit() float64 {
+	rateLimit, err := strconv.ParseFloat(os.Getenv("COMPASS_RATE_LIMIT"), 64)
+	if err != nil || rateLimit < 0 {
+		return 10
+	}
+	return rateLimit
+}
+
 func (c *ConfigService) GetPrometheusURL() string {
 	return os.Getenv("PROMETHEUS_URL")
 }
@@ -78,3 +126,45 @@ func (c *ConfigService) GetAWSRegion() string {
 }
 
 func (c *ConfigService) GetAWSRole() string { return os.Getenv("AWS_ROLE") }
+
+func (c *ConfigService) GetCheckoutPath() string {
+	checkoutPath := os.Getenv("CHECKOUT_PATH")
+	if checkoutPath == "" {
+		return "."
+	}
+	return checkoutPath
+}
+
+func (c *ConfigService) GetCatalogBackend() string {
+	catalogBackend := os.Getenv("CATALOG_BACKEND")
+	if catalogBackend == "" {
+		return "compass"
+	}
+	return catalogBackend
+}
+
+func (c *ConfigService) GetCatalogPath() string {
+	catalogPath := os.Getenv("CATALOG_PATH")
+	if catalogPath == "" {
+		return ".catalog"
+	}
+	return catalogPath
+}
+
+// GetPushQueuePath returns the directory of the metric values whose push failed, waiting for push-pending.
+func (c *ConfigService) GetPushQueuePath() string {
+	pushQueuePath := os.Getenv("PUSH_QUEUE_PATH")
+	if pushQueuePath == "" {
+		return ".push-queue"
+	}
+	return pushQueuePath
+}
+
+// GetMetricHistoryPath returns the file keeping the values computed for the metrics of the components.
+func (c *ConfigService) GetMetricHistoryPath() string {
+	metricHistoryPath := os.Getenv("METRIC_HISTORY_PATH")
+	if metricHistoryPath == "" {
+		return ".metric-history.jsonl"
+	}
+	return metricHistoryPath
+}
@@ -3,6 +3,7 @@ package configservice_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/stretchr/testify/assert"
@@ -54,3 +55,71 @@ func TestGetGithubToken(t *testing.T) {
 	cfg := configservice.NewConfigService()
 	assert.Equal(t, "123456", cfg.GetGithubToken())
 }
+
+func TestGetDefaultCheckoutPath(t *testing.T) {
+	os.Unsetenv("CHECKOUT_PATH")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, ".", cfg.GetCheckoutPath())
+}
+
+func TestGetCheckoutPath(t *testing.T) {
+	os.Setenv("CHECKOUT_PATH", "/workspace/my-service")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, "/workspace/my-service", cfg.GetCheckoutPath())
+}
+
+func TestGetDefaultCatalogBackend(t *testing.T) {
+	os.Unsetenv("CATALOG_BACKEND")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, "compass", cfg.GetCatalogBackend())
+}
+
+func TestGetCatalogBackend(t *testing.T) {
+	os.Setenv("CATALOG_BACKEND", "file")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, "file", cfg.GetCatalogBackend())
+}
+
+func TestGetDefaultCatalogPath(t *testing.T) {
+	os.Unsetenv("CATALOG_PATH")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, ".catalog", cfg.GetCatalogPath())
+}
+
+func TestGetDefaultGithubAPIURL(t *testing.T) {
+	os.Unsetenv("GITHUB_API_URL")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, "https://api.github.com", cfg.GetGithubAPIURL())
+}
+
+func TestGetCompassRetryPolicy(t *testing.T) {
+	os.Setenv("COMPASS_MAX_RETRIES", "5")
+	os.Setenv("COMPASS_RETRY_BASE_DELAY", "250ms")
+	os.Setenv("COMPASS_RATE_LIMIT", "2.5")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, 5, cfg.GetCompassMaxRetries())
+	assert.Equal(t, 250*time.Millisecond, cfg.GetCompassRetryBaseDelay())
+	assert.Equal(t, 2.5, cfg.GetCompassRateLimit())
+}
+
+func TestGetDefaultCompassRetryPolicy(t *testing.T) {
+	os.Unsetenv("COMPASS_MAX_RETRIES")
+	os.Setenv("COMPASS_RETRY_BASE_DELAY", "soon")
+	os.Unsetenv("COMPASS_RATE_LIMIT")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, 3, cfg.GetCompassMaxRetries())
+	assert.Equal(t, time.Second, cfg.GetCompassRetryBaseDelay())
+	assert.Equal(t, 10.0, cfg.GetCompassRateLimit())
+}
+
+func TestGetDefaultPushQueuePath(t *testing.T) {
+	os.Unsetenv("PUSH_QUEUE_PATH")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, ".push-queue", cfg.GetPushQueuePath())
+}
+
+func TestGetDefaultMetricHistoryPath(t *testing.T) {
+	os.Unsetenv("METRIC_HISTORY_PATH")
+	cfg := configservice.NewConfigService()
+	assert.Equal(t, ".metric-history.jsonl", cfg.GetMetricHistoryPath())
+}
@@ -6,6 +6,7 @@ package configservice
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -75,6 +76,48 @@ func (mr *MockConfigServiceInterfaceMockRecorder) GetAWSRole() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAWSRole", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetAWSRole))
 }
 
+// GetCatalogBackend mocks base method.
+func (m *MockConfigServiceInterface) GetCatalogBackend() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogBackend")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCatalogBackend indicates an expected call of GetCatalogBackend.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCatalogBackend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogBackend", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCatalogBackend))
+}
+
+// GetCatalogPath mocks base method.
+func (m *MockConfigServiceInterface) GetCatalogPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCatalogPath indicates an expected call of GetCatalogPath.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCatalogPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogPath", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCatalogPath))
+}
+
+// GetCheckoutPath mocks base method.
+func (m *MockConfigServiceInterface) GetCheckoutPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckoutPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCheckoutPath indicates an expected call of GetCheckoutPath.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCheckoutPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckoutPath", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCheckoutPath))
+}
+
 // GetCompassCloudId mocks base method.
 func (m *MockConfigServiceInterface) GetCompassCloudId() string {
 	m.ctrl.T.Helper()
@@ -103,6 +146,48 @@ func (mr *MockConfigServiceInterfaceMockRecorder) GetCompassHost() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompassHost", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCompassHost))
 }
 
+// GetCompassMaxRetries mocks base method.
+func (m *MockConfigServiceInterface) GetCompassMaxRetries() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompassMaxRetries")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetCompassMaxRetries indicates an expected call of GetCompassMaxRetries.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCompassMaxRetries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompassMaxRetries", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCompassMaxRetries))
+}
+
+// GetCompassRateLimit mocks base method.
+func (m *MockConfigServiceInterface) GetCompassRateLimit() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompassRateLimit")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetCompassRateLimit indicates an expected call of GetCompassRateLimit.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCompassRateLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompassRateLimit", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCompassRateLimit))
+}
+
+// GetCompassRetryBaseDelay mocks base method.
+func (m *MockConfigServiceInterface) GetCompassRetryBaseDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompassRetryBaseDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetCompassRetryBaseDelay indicates an expected call of GetCompassRetryBaseDelay.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetCompassRetryBaseDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompassRetryBaseDelay", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCompassRetryBaseDelay))
+}
+
 // GetCompassToken mocks base method.
 func (m *MockConfigServiceInterface) GetCompassToken() string {
 	m.ctrl.T.Helper()
@@ -117,6 +202,20 @@ func (mr *MockConfigServiceInterfaceMockRecorder) GetCompassToken() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompassToken", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetCompassToken))
 }
 
+// GetGithubAPIURL mocks base method.
+func (m *MockConfigServiceInterface) GetGithubAPIURL() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGithubAPIURL")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetGithubAPIURL indicates an expected call of GetGithubAPIURL.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetGithubAPIURL() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubAPIURL", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetGithubAPIURL))
+}
+
 // GetGithubOrg mocks base method.
 func (m *MockConfigServiceInterface) GetGithubOrg() string {
 	m.ctrl.T.Helper()
@@ -159,6 +258,20 @@ func (mr *MockConfigServiceInterfaceMockRecorder) GetGithubUser() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGithubUser", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetGithubUser))
 }
 
+// GetMetricHistoryPath mocks base method.
+func (m *MockConfigServiceInterface) GetMetricHistoryPath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricHistoryPath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetMetricHistoryPath indicates an expected call of GetMetricHistoryPath.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetMetricHistoryPath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricHistoryPath", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetMetricHistoryPath))
+}
+
 // GetPrometheusURL mocks base method.
 func (m *MockConfigServiceInterface) GetPrometheusURL() string {
 	m.ctrl.T.Helper()
@@ -172,3 +285,17 @@ func (mr *MockConfigServiceInterfaceMockRecorder) GetPrometheusURL() *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusURL", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetPrometheusURL))
 }
+
+// GetPushQueuePath mocks base method.
+func (m *MockConfigServiceInterface) GetPushQueuePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPushQueuePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetPushQueuePath indicates an expected call of GetPushQueuePath.
+func (mr *MockConfigServiceInterfaceMockRecorder) GetPushQueuePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPushQueuePath", reflect.TypeOf((*MockConfigServiceInterface)(nil).GetPushQueuePath))
+}
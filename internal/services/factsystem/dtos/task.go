@@ -26,9 +26,13 @@ const (
 type TaskSource string
 
 const (
-	GitHubTaskSource     TaskSource = "github"
-	JSONAPITaskSource    TaskSource = "jsonapi"
-	PrometheusTaskSource TaskSource = "prometheus"
+	GitHubTaskSource       TaskSource = "github"
+	JSONAPITaskSource      TaskSource = "jsonapi"
+	PrometheusTaskSource   TaskSource = "prometheus"
+	GraphQLTaskSource      TaskSource = "graphql"
+	FileTaskSource         TaskSource = "file"
+	KubernetesTaskSource   TaskSource = "kubernetes"
+	DependenciesTaskSource TaskSource = "dependencies"
 )
 
 type TaskMethod string
@@ -64,6 +68,10 @@ type Task struct {
 	Auth            *TaskAuth `yaml:"auth,omitempty" json:"auth,omitempty"`
 	PrometheusQuery string    `yaml:"prometheusQuery,omitempty" json:"prometheusQuery,omitempty"`
 
+	// Extract related fields for GraphQL API calls
+	Query     string            `yaml:"query,omitempty" json:"query,omitempty"`
+	Variables map[string]string `yaml:"variables,omitempty" json:"variables,omitempty"`
+
 	// Extract related fields for GitHub API calls
 	Repo         string `yaml:"repo,omitempty" json:"repo,omitempty"`
 	FilePath     string `yaml:"filePath,omitempty"`
@@ -102,6 +110,8 @@ func (t1 *Task) IsEqual(t2 *Task) bool {
 		t1.Result == t2.Result &&
 		t1.SearchString == t2.SearchString &&
 		t1.PrometheusQuery == t2.PrometheusQuery &&
+		t1.Query == t2.Query &&
+		t1.IsVariablesEquals(t2.Variables) &&
 		t1.IsDependsOnEquals(t2.DependsOn)
 }
 
@@ -117,3 +127,16 @@ func (t1 *Task) IsDependsOnEquals(dependsOn []string) bool {
 
 	return true
 }
+
+func (t1 *Task) IsVariablesEquals(variables map[string]string) bool {
+	if len(t1.Variables) != len(variables) {
+		return false
+	}
+	for key, value := range t1.Variables {
+		if otherValue, exists := variables[key]; !exists || otherValue != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -4,22 +4,13 @@ package extractors
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"path/filepath"
-	"regexp"
 	"strconv"
 
-	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
 	"github.com/motain/of-catalog/internal/services/factsystem/utils"
-	"github.com/motain/of-catalog/internal/services/githubservice"
-	"github.com/motain/of-catalog/internal/services/jsonservice"
-	"github.com/motain/of-catalog/internal/services/prometheusservice"
-	"github.com/motain/of-catalog/internal/utils/transformers"
 )
 
 type ExtractorInterface interface {
@@ -27,19 +18,11 @@ type ExtractorInterface interface {
 }
 
 type Extractor struct {
-	config            configservice.ConfigServiceInterface
-	jsonService       jsonservice.JSONServiceInterface
-	github            githubservice.GitHubServiceInterface
-	prometheusService prometheusservice.PrometheusServiceInterface
+	registry sources.RegistryInterface
 }
 
-func NewExtractor(
-	config configservice.ConfigServiceInterface,
-	jsonService jsonservice.JSONServiceInterface,
-	github githubservice.GitHubServiceInterface,
-	prometheusService prometheusservice.PrometheusServiceInterface,
-) *Extractor {
-	return &Extractor{config: config, jsonService: jsonService, github: github, prometheusService: prometheusService}
+func NewExtractor(registry sources.RegistryInterface) *Extractor {
+	return &Extractor{registry: registry}
 }
 
 func (ex *Extractor) Extract(ctx context.Context, task *dtos.Task, deps []*dtos.Task) error {
@@ -118,25 +101,16 @@ func (ex *Extractor) handleMultipleResults(ctx context.Context, task *dtos.Task,
 }
 
 func (ex *Extractor) processData(ctx context.Context, task *dtos.Task, dependencyResult string) (interface{}, error) {
-	var jsonData []byte
-	var dataErr error
-	switch dtos.TaskSource(task.Source) {
-	case dtos.GitHubTaskSource:
-		if task.Rule == string(dtos.SearchRule) {
-			searchListResult, searchErr := ex.github.Search(task.Repo, task.SearchString)
-			if searchErr != nil {
-				return nil, fmt.Errorf("failed to process github Search request for source for string %s %s: %v", task.SearchString, task.Source, searchErr)
-			}
-			return len(searchListResult) != 0, nil
-		}
-		jsonData, dataErr = ex.processGithub(task, unquoted(dependencyResult))
-	case dtos.JSONAPITaskSource:
-		jsonData, dataErr = ex.processJSONAPI(ctx, task, unquoted(dependencyResult))
-	case dtos.PrometheusTaskSource:
-		jsonData, dataErr = ex.queryPrometheus(task, unquoted(dependencyResult))
-	default:
+	source, exists := ex.registry.Get(task.Source)
+	if !exists {
 		return nil, fmt.Errorf("no data extracted, unknown source %s", task.Source)
 	}
+
+	if searchable, isSearchable := source.(sources.SearchableFactSource); isSearchable && task.Rule == string(dtos.SearchRule) {
+		return searchable.Search(ctx, task)
+	}
+
+	jsonData, dataErr := source.Fetch(ctx, task, unquoted(dependencyResult))
 	if dataErr != nil {
 		return nil, fmt.Errorf("failed to process request for source %s: %v", task.Source, dataErr)
 	}
@@ -151,68 +125,6 @@ func (ex *Extractor) processData(ctx context.Context, task *dtos.Task, dependenc
 	}
 }
 
-func (ex *Extractor) processGithub(task *dtos.Task, result string) ([]byte, error) {
-	extractFilePath := utils.ReplacePlaceholder(task.FilePath, result)
-	fileContent, fileErr := ex.github.GetFileContent(task.Repo, extractFilePath)
-	if fileErr != nil {
-		re := regexp.MustCompile(`404 Not Found`)
-		if re.MatchString(fileErr.Error()) {
-			return nil, nil
-		}
-		return nil, fileErr
-	}
-
-	if dtos.TaskRule(task.Rule) != dtos.JSONPathRule {
-		return []byte(fileContent), nil
-	}
-
-	fileExtension := filepath.Ext(task.FilePath)
-	if fileExtension != ".json" && fileExtension != ".toml" {
-		return nil, fmt.Errorf("unsupported file extension: %s", fileExtension)
-	}
-
-	if fileExtension == ".toml" {
-		jsonData, transformErr := transformers.Toml2json(fileContent)
-		if transformErr != nil {
-			return nil, fmt.Errorf("failed to transform toml file to json: %v", transformErr)
-		}
-		return jsonData, nil
-	}
-
-	return []byte(fileContent), nil
-}
-
-func (ex *Extractor) processJSONAPI(ctx context.Context, task *dtos.Task, result string) ([]byte, error) {
-	extractURI := utils.ReplacePlaceholder(task.URI, result)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, extractURI, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-
-	if task.Auth != nil {
-		token := ex.config.Get(task.Auth.TokenVar)
-		req.Header.Set(task.Auth.Header, token)
-	}
-
-	resp, fileErr := ex.jsonService.Do(req)
-	if fileErr != nil {
-		return nil, fileErr
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			fmt.Printf("failed to close response body: %v", err)
-		}
-	}(resp.Body)
-	jsonData, readErr := io.ReadAll(resp.Body)
-	if readErr != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", readErr)
-	}
-
-	return jsonData, nil
-}
-
 func unquoted(toUnquote string) string {
 	unquoted, unQuoteErr := strconv.Unquote(toUnquote) //nolint: errcheck
 	if unQuoteErr != nil {
@@ -221,13 +133,3 @@ func unquoted(toUnquote string) string {
 
 	return unquoted
 }
-
-func (ex *Extractor) queryPrometheus(task *dtos.Task, result string) ([]byte, error) {
-	prometheusQuery := utils.ReplacePlaceholder(task.PrometheusQuery, result)
-	response, err := ex.prometheusService.InstantQuery(prometheusQuery)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query prometheus: %v", err)
-	}
-
-	return json.Marshal(response)
-}
@@ -0,0 +1,370 @@
+package extractors_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	compassservice "github.com/motain/of-catalog/internal/services/compassservice/mocks"
+	configservice "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/extractors"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/dependenciessource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/filesource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/graphqlsource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/kubernetessource"
+	sourcesmocks "github.com/motain/of-catalog/internal/services/factsystem/sources/mocks"
+	githubservice "github.com/motain/of-catalog/internal/services/githubservice/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractor_Extract_UnknownSource(t *testing.T) {
+	extractor := extractors.NewExtractor(sources.NewRegistry(nil))
+	task := &dtos.Task{ID: "unknown", Type: string(dtos.ExtractType), Source: "unknown"}
+
+	err := extractor.Extract(context.Background(), task, nil)
+
+	assert.ErrorContains(t, err, "unknown source unknown")
+}
+
+func TestExtractor_Extract_MultipleDependencyResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSource := sourcesmocks.NewMockFactSource(ctrl)
+	mockSource.EXPECT().Name().Return("custom")
+	mockSource.EXPECT().Fetch(gomock.Any(), gomock.Any(), "first").Return([]byte(`{"value": "a"}`), nil)
+	mockSource.EXPECT().Fetch(gomock.Any(), gomock.Any(), "second").Return([]byte(`{"value": "b"}`), nil)
+
+	extractor := extractors.NewExtractor(sources.NewRegistry([]sources.FactSource{mockSource}))
+	task := &dtos.Task{ID: "custom", Type: string(dtos.ExtractType), Source: "custom", Rule: string(dtos.JSONPathRule), JSONPath: ".value"}
+	dependency := &dtos.Task{ID: "dependency", Result: []string{"first", "second"}}
+
+	err := extractor.Extract(context.Background(), task, []*dtos.Task{dependency})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, task.Result)
+}
+
+func TestExtractor_Extract_GraphQL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		task           *dtos.Task
+		mockSetup      func(config *configservice.MockConfigServiceInterface, compass *compassservice.MockCompassServiceInterface)
+		expectedResult interface{}
+		expectedError  bool
+	}{
+		{
+			name: "queries compass and applies jsonpath",
+			task: &dtos.Task{
+				ID:        "has-api-spec",
+				Type:      string(dtos.ExtractType),
+				Source:    string(dtos.GraphQLTaskSource),
+				Rule:      string(dtos.JSONPathRule),
+				Query:     "query component($slug: String!) { component(slug: $slug) { apiSpecs } }",
+				Variables: map[string]string{"slug": "svc-my-service"},
+				JSONPath:  ".component.apiSpecs | length",
+			},
+			mockSetup: func(config *configservice.MockConfigServiceInterface, compass *compassservice.MockCompassServiceInterface) {
+				compass.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compass.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, query string, variables map[string]interface{}, resp interface{}) error {
+						assert.Equal(t, map[string]interface{}{"cloudId": "cloud-id", "slug": "svc-my-service"}, variables)
+						*(resp.(*map[string]interface{})) = map[string]interface{}{
+							"component": map[string]interface{}{"apiSpecs": []interface{}{"a", "b"}},
+						}
+						return nil
+					},
+				)
+			},
+			expectedResult: []interface{}{2},
+		},
+		{
+			name: "fails when the query errors",
+			task: &dtos.Task{
+				ID:     "has-api-spec",
+				Type:   string(dtos.ExtractType),
+				Source: string(dtos.GraphQLTaskSource),
+				Query:  "query { test }",
+			},
+			mockSetup: func(config *configservice.MockConfigServiceInterface, compass *compassservice.MockCompassServiceInterface) {
+				compass.EXPECT().GetCompassCloudId().Return("cloud-id")
+				compass.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+			},
+			expectedError: true,
+		},
+		{
+			name: "fails when no query is provided",
+			task: &dtos.Task{
+				ID:     "has-api-spec",
+				Type:   string(dtos.ExtractType),
+				Source: string(dtos.GraphQLTaskSource),
+			},
+			mockSetup:     func(config *configservice.MockConfigServiceInterface, compass *compassservice.MockCompassServiceInterface) {},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockCompass := compassservice.NewMockCompassServiceInterface(ctrl)
+			tt.mockSetup(mockConfig, mockCompass)
+
+			extractor := extractors.NewExtractor(sources.NewRegistry([]sources.FactSource{graphqlsource.NewGraphQLSource(mockConfig, mockCompass)}))
+			err := extractor.Extract(context.Background(), tt.task, nil)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, tt.task.Result)
+		})
+	}
+}
+
+func TestExtractor_Extract_File(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checkoutPath := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(checkoutPath, "app.toml"), []byte("[envs]\nOTEL_SERVICE_NAME = \"my-service\"\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(checkoutPath, "main.go"), []byte("package main // uses otel"), 0644))
+
+	tests := []struct {
+		name           string
+		task           *dtos.Task
+		expectedResult interface{}
+		expectedError  bool
+	}{
+		{
+			name: "applies jsonpath to a toml file",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.FileTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				FilePath: "app.toml",
+				JSONPath: ".envs.OTEL_SERVICE_NAME",
+			},
+			expectedResult: []interface{}{"my-service"},
+		},
+		{
+			name: "reports an existing file as not empty",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.FileTaskSource),
+				Rule:     string(dtos.NotEmptyRule),
+				FilePath: "app.toml",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "reports a missing file as empty",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.FileTaskSource),
+				Rule:     string(dtos.NotEmptyRule),
+				FilePath: "missing.yaml",
+			},
+			expectedResult: false,
+		},
+		{
+			name: "searches the checkout",
+			task: &dtos.Task{
+				Type:         string(dtos.ExtractType),
+				Source:       string(dtos.FileTaskSource),
+				Rule:         string(dtos.SearchRule),
+				SearchString: "otel",
+			},
+			expectedResult: true,
+		},
+		{
+			name: "fails on unsupported extensions with jsonpath",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.FileTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				FilePath: "main.go",
+				JSONPath: ".",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().GetCheckoutPath().Return(checkoutPath).AnyTimes()
+
+			extractor := extractors.NewExtractor(sources.NewRegistry([]sources.FactSource{filesource.NewFileSource(mockConfig)}))
+			err := extractor.Extract(context.Background(), tt.task, nil)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, tt.task.Result)
+		})
+	}
+}
+
+func TestExtractor_Extract_Kubernetes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checkoutPath := t.TempDir()
+	manifestsPath := filepath.Join(checkoutPath, "deploy", "production")
+	assert.NoError(t, os.MkdirAll(manifestsPath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(manifestsPath, "rendered.yaml"), []byte(deploymentManifest), 0644))
+
+	tests := []struct {
+		name           string
+		task           *dtos.Task
+		mockSetup      func(github *githubservice.MockGitHubServiceInterface)
+		expectedResult interface{}
+		expectedError  bool
+	}{
+		{
+			name: "reads manifests from the local checkout",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.KubernetesTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				FilePath: "deploy",
+				JSONPath: ".Deployment[].spec.replicas",
+			},
+			mockSetup:      func(github *githubservice.MockGitHubServiceInterface) {},
+			expectedResult: []interface{}{float64(3)},
+		},
+		{
+			name: "reads manifests from github",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.KubernetesTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				Repo:     "my-service",
+				FilePath: "deploy",
+				JSONPath: ".PodDisruptionBudget | length",
+			},
+			mockSetup: func(github *githubservice.MockGitHubServiceInterface) {
+				github.EXPECT().GetDirectoryContent("my-service", "deploy").Return(map[string]string{
+					"deploy/production/rendered.yaml": deploymentManifest,
+				}, nil)
+			},
+			expectedResult: []interface{}{1},
+		},
+		{
+			name: "treats a missing github directory as empty",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.KubernetesTaskSource),
+				Rule:     string(dtos.NotEmptyRule),
+				Repo:     "my-service",
+				FilePath: "deploy",
+			},
+			mockSetup: func(github *githubservice.MockGitHubServiceInterface) {
+				github.EXPECT().GetDirectoryContent("my-service", "deploy").Return(nil, errors.New("GET https://api.github.com: 404 Not Found []"))
+			},
+			expectedResult: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().GetCheckoutPath().Return(checkoutPath).AnyTimes()
+			mockGitHub := githubservice.NewMockGitHubServiceInterface(ctrl)
+			tt.mockSetup(mockGitHub)
+
+			extractor := extractors.NewExtractor(sources.NewRegistry([]sources.FactSource{kubernetessource.NewKubernetesSource(mockConfig, mockGitHub)}))
+			err := extractor.Extract(context.Background(), tt.task, nil)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, tt.task.Result)
+		})
+	}
+}
+
+func TestExtractor_Extract_Dependencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checkoutPath := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(checkoutPath, "go.mod"), []byte("module x\n\nrequire github.com/pkg/errors v0.9.1\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(checkoutPath, "requirements.txt"), []byte("requests==2.31.0\n"), 0644))
+
+	tests := []struct {
+		name           string
+		task           *dtos.Task
+		mockSetup      func(github *githubservice.MockGitHubServiceInterface)
+		expectedResult interface{}
+	}{
+		{
+			name: "parses every known manifest in a checkout directory",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.DependenciesTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				JSONPath: "[.[].ecosystem] | unique",
+			},
+			mockSetup:      func(github *githubservice.MockGitHubServiceInterface) {},
+			expectedResult: []interface{}{[]interface{}{"go", "pypi"}},
+		},
+		{
+			name: "parses a single manifest from github",
+			task: &dtos.Task{
+				Type:     string(dtos.ExtractType),
+				Source:   string(dtos.DependenciesTaskSource),
+				Rule:     string(dtos.JSONPathRule),
+				Repo:     "my-service",
+				FilePath: "go.mod",
+				JSONPath: ".[] | select(.name == \"github.com/pkg/errors\") | .version",
+			},
+			mockSetup: func(github *githubservice.MockGitHubServiceInterface) {
+				github.EXPECT().GetFileContent("my-service", "go.mod").Return("module x\n\nrequire github.com/pkg/errors v0.9.1\n", nil)
+			},
+			expectedResult: []interface{}{"v0.9.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().GetCheckoutPath().Return(checkoutPath).AnyTimes()
+			mockGitHub := githubservice.NewMockGitHubServiceInterface(ctrl)
+			tt.mockSetup(mockGitHub)
+
+			extractor := extractors.NewExtractor(sources.NewRegistry([]sources.FactSource{dependenciessource.NewDependenciesSource(mockConfig, mockGitHub)}))
+			err := extractor.Extract(context.Background(), tt.task, nil)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, tt.task.Result)
+		})
+	}
+}
+
+const deploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-service
+spec:
+  replicas: 3
+---
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: my-service
+`
@@ -0,0 +1,94 @@
+package builtin
+
+import (
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/dependenciessource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/filesource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/githubsource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/graphqlsource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/jsonapisource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/kubernetessource"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/prometheussource"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/services/jsonservice"
+	"github.com/motain/of-catalog/internal/services/prometheusservice"
+)
+
+// clients holds the services the builtin fact sources fetch their data with.
+type clients struct {
+	Config     configservice.ConfigServiceInterface
+	GitHub     githubservice.GitHubServiceInterface
+	JSON       jsonservice.JSONServiceInterface
+	Prometheus prometheusservice.PrometheusServiceInterface
+	Compass    compassservice.CompassServiceInterface
+}
+
+type builtinSource struct {
+	spec sources.Spec
+	new  func(c clients) sources.FactSource
+}
+
+// builtinSources lists the fact sources shipped with the catalog. Register new sources here.
+var builtinSources = []builtinSource{
+	{
+		spec: githubsource.GitHubSpec{},
+		new:  func(c clients) sources.FactSource { return githubsource.NewGitHubSource(c.GitHub) },
+	},
+	{
+		spec: jsonapisource.JSONAPISpec{},
+		new:  func(c clients) sources.FactSource { return jsonapisource.NewJSONAPISource(c.Config, c.JSON) },
+	},
+	{
+		spec: prometheussource.PrometheusSpec{},
+		new:  func(c clients) sources.FactSource { return prometheussource.NewPrometheusSource(c.Prometheus) },
+	},
+	{
+		spec: graphqlsource.GraphQLSpec{},
+		new:  func(c clients) sources.FactSource { return graphqlsource.NewGraphQLSource(c.Config, c.Compass) },
+	},
+	{
+		spec: filesource.FileSpec{},
+		new:  func(c clients) sources.FactSource { return filesource.NewFileSource(c.Config) },
+	},
+	{
+		spec: kubernetessource.KubernetesSpec{},
+		new:  func(c clients) sources.FactSource { return kubernetessource.NewKubernetesSource(c.Config, c.GitHub) },
+	},
+	{
+		spec: dependenciessource.DependenciesSpec{},
+		new: func(c clients) sources.FactSource {
+			return dependenciessource.NewDependenciesSource(c.Config, c.GitHub)
+		},
+	},
+}
+
+// NewBuiltinSources builds the fact sources shipped with the catalog from the given clients.
+func NewBuiltinSources(
+	config configservice.ConfigServiceInterface,
+	github githubservice.GitHubServiceInterface,
+	jsonService jsonservice.JSONServiceInterface,
+	prometheus prometheusservice.PrometheusServiceInterface,
+	compass compassservice.CompassServiceInterface,
+) []sources.FactSource {
+	c := clients{Config: config, GitHub: github, JSON: jsonService, Prometheus: prometheus, Compass: compass}
+
+	factSources := make([]sources.FactSource, len(builtinSources))
+	for i, source := range builtinSources {
+		factSources[i] = source.new(c)
+	}
+
+	return factSources
+}
+
+// NewBuiltinSpecs lists the specs of the fact sources shipped with the catalog,
+// for commands that validate definitions without fetching data.
+func NewBuiltinSpecs() []sources.Spec {
+	specs := make([]sources.Spec, len(builtinSources))
+	for i, source := range builtinSources {
+		specs[i] = source.spec
+	}
+
+	return specs
+}
@@ -0,0 +1,16 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuiltinSources_MatchSpecs(t *testing.T) {
+	factSources := sources.NewRegistry(builtin.NewBuiltinSources(nil, nil, nil, nil, nil))
+	specs := sources.NewSpecRegistry(builtin.NewBuiltinSpecs())
+
+	assert.Equal(t, specs.Names(), factSources.Names())
+}
@@ -0,0 +1,68 @@
+package dependenciessource
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+)
+
+// DependenciesSpec describes the dependencies source.
+type DependenciesSpec struct{}
+
+func (DependenciesSpec) Name() string {
+	return string(dtos.DependenciesTaskSource)
+}
+
+func (DependenciesSpec) RequiredFields(task *dtos.Task) []string {
+	return []string{}
+}
+
+// DependenciesSource parses dependency manifests and SBOMs into a normalized dependency list.
+type DependenciesSource struct {
+	DependenciesSpec
+	config configservice.ConfigServiceInterface
+	github githubservice.GitHubServiceInterface
+}
+
+func NewDependenciesSource(config configservice.ConfigServiceInterface, github githubservice.GitHubServiceInterface) *DependenciesSource {
+	return &DependenciesSource{config: config, github: github}
+}
+
+// Fetch parses the manifest or SBOM at task.FilePath. Any other path, e.g. `.`, is a directory
+// in which every known manifest is parsed, unless it names an unsupported file such as package.json.
+// Files are read from GitHub when task.Repo is set and from the local checkout otherwise.
+func (s *DependenciesSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	dependenciesPath := filepath.Clean(utils.ReplacePlaceholder(task.FilePath, dependencyResult))
+
+	candidates := []string{dependenciesPath}
+	if !utils.IsDependencyFile(dependenciesPath) {
+		if utils.HasDependencyFileExtension(dependenciesPath) {
+			return nil, fmt.Errorf("unsupported dependency file %s", filepath.Base(dependenciesPath))
+		}
+		candidates = make([]string, len(utils.DependencyManifestFiles))
+		for i, fileName := range utils.DependencyManifestFiles {
+			candidates[i] = filepath.Join(dependenciesPath, fileName)
+		}
+	}
+
+	files := make(map[string]string)
+	for _, candidate := range candidates {
+		content, found, readErr := utils.ReadSourceFile(s.github, s.config.GetCheckoutPath(), task.Repo, candidate)
+		if readErr != nil {
+			return nil, readErr
+		}
+		if found {
+			files[candidate] = content
+		}
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	return utils.ParseDependencies(files)
+}
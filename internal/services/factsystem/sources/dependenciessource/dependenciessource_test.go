@@ -0,0 +1,89 @@
+package dependenciessource_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configservicemocks "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/dependenciessource"
+	githubservicemocks "github.com/motain/of-catalog/internal/services/githubservice/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const goMod = "module github.com/motain/bookmarks\n\nrequire github.com/spf13/cobra v1.8.0\n"
+
+func TestFetch_Checkout(t *testing.T) {
+	checkout := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(checkout, "config.d"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(checkout, "go.mod"), []byte(goMod), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(checkout, "config.d", "go.mod"), []byte(goMod), 0644))
+
+	expected := `[{"name":"github.com/spf13/cobra","version":"v1.8.0","ecosystem":"go"}]`
+	tests := []struct {
+		name     string
+		filePath string
+	}{
+		{name: "manifest", filePath: "go.mod"},
+		{name: "working directory", filePath: "."},
+		{name: "no path", filePath: ""},
+		{name: "directory with an extension", filePath: "config.d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().GetCheckoutPath().Return(checkout).AnyTimes()
+			source := dependenciessource.NewDependenciesSource(mockConfig, githubservicemocks.NewMockGitHubServiceInterface(ctrl))
+
+			result, err := source.Fetch(context.Background(), &dtos.Task{Source: "dependencies", FilePath: tt.filePath}, "")
+
+			require.NoError(t, err)
+			assert.JSONEq(t, expected, string(result))
+		})
+	}
+}
+
+func TestFetch_GitHubDirectory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetCheckoutPath().Return("").AnyTimes()
+	mockGitHub := githubservicemocks.NewMockGitHubServiceInterface(ctrl)
+	mockGitHub.EXPECT().GetFileContent("bookmarks", gomock.Any()).DoAndReturn(func(repo, path string) (string, error) {
+		if path == "go.mod" {
+			return goMod, nil
+		}
+		return "", errors.New("failed to fetch file: GET https://api.github.com/repos/motain/bookmarks/contents/" + path + ": 404 Not Found []")
+	}).AnyTimes()
+	source := dependenciessource.NewDependenciesSource(mockConfig, mockGitHub)
+
+	result, err := source.Fetch(context.Background(), &dtos.Task{Source: "dependencies", Repo: "bookmarks", FilePath: "."}, "")
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"github.com/spf13/cobra","version":"v1.8.0","ecosystem":"go"}]`, string(result))
+}
+
+func TestFetch_UnsupportedFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	checkout := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(checkout, "package.json"), []byte(`{"name": "bookmarks"}`), 0644))
+	mockConfig := configservicemocks.NewMockConfigServiceInterface(ctrl)
+	mockConfig.EXPECT().GetCheckoutPath().Return(checkout).AnyTimes()
+	source := dependenciessource.NewDependenciesSource(mockConfig, githubservicemocks.NewMockGitHubServiceInterface(ctrl))
+
+	_, err := source.Fetch(context.Background(), &dtos.Task{Source: "dependencies", FilePath: "package.json"}, "")
+
+	assert.EqualError(t, err, "unsupported dependency file package.json")
+}
@@ -0,0 +1,119 @@
+package fakesource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+)
+
+// searchableSources handle the search rule themselves, as their real counterparts do
+var searchableSources = []dtos.TaskSource{dtos.GitHubTaskSource, dtos.FileTaskSource}
+
+// Mock is the data returned for an extract task in place of the remote service.
+type Mock struct {
+	// Content is the file content, for the github, file, kubernetes and dependencies sources,
+	// or the response body, for the jsonapi and graphql sources.
+	Content *string `yaml:"content,omitempty"`
+	// Files are the files of the directory read by the kubernetes and dependencies sources, keyed by path.
+	Files map[string]string `yaml:"files,omitempty"`
+	// Value is the result of the query of the prometheus source.
+	Value *float64 `yaml:"value,omitempty"`
+	// Found is the result of the search rule, which otherwise looks for the search string in Content.
+	Found *bool `yaml:"found,omitempty"`
+	// ByDependency replaces the mock when the task is evaluated for a result of its dependency.
+	ByDependency map[string]*Mock `yaml:"byDependency,omitempty"`
+}
+
+// FakeSource answers extract tasks with the mocks given for their id. Tasks without mock get
+// no data, as for a missing file. The content is parsed as the real source parses it.
+type FakeSource struct {
+	sources.Spec
+	mocks map[string]*Mock
+}
+
+// SearchableFakeSource is the fake of a source handling the search rule.
+type SearchableFakeSource struct {
+	*FakeSource
+}
+
+// NewFakeSources returns a fake for each source spec, sharing mocks keyed by task id.
+func NewFakeSources(specs []sources.Spec, mocks map[string]*Mock) []sources.FactSource {
+	fakeSources := make([]sources.FactSource, len(specs))
+	for i, spec := range specs {
+		fake := &FakeSource{Spec: spec, mocks: mocks}
+		fakeSources[i] = fake
+		if slices.Contains(searchableSources, dtos.TaskSource(spec.Name())) {
+			fakeSources[i] = &SearchableFakeSource{FakeSource: fake}
+		}
+	}
+
+	return fakeSources
+}
+
+func (s *FakeSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	mock := s.mock(task, dependencyResult)
+	if mock == nil {
+		return nil, nil
+	}
+
+	filePath := utils.ReplacePlaceholder(task.FilePath, dependencyResult)
+	switch {
+	case mock.Value != nil:
+		return json.Marshal(*mock.Value)
+	case mock.Files != nil:
+		return s.parseFiles(task, mock.Files)
+	case mock.Content == nil:
+		return nil, nil
+	}
+
+	switch dtos.TaskSource(task.Source) {
+	case dtos.GitHubTaskSource, dtos.FileTaskSource:
+		return utils.ParseFileContent(task, *mock.Content)
+	case dtos.KubernetesTaskSource, dtos.DependenciesTaskSource:
+		return s.parseFiles(task, map[string]string{filePath: *mock.Content})
+	default:
+		return []byte(*mock.Content), nil
+	}
+}
+
+func (s *SearchableFakeSource) Search(ctx context.Context, task *dtos.Task) (bool, error) {
+	mock := s.mock(task, "")
+	switch {
+	case mock == nil:
+		return false, nil
+	case mock.Found != nil:
+		return *mock.Found, nil
+	case mock.Content != nil:
+		return strings.Contains(*mock.Content, task.SearchString), nil
+	}
+
+	return false, nil
+}
+
+func (s *FakeSource) mock(task *dtos.Task, dependencyResult string) *Mock {
+	mock := s.mocks[task.ID]
+	if mock != nil && dependencyResult != "" {
+		if dependencyMock, exists := mock.ByDependency[dependencyResult]; exists {
+			return dependencyMock
+		}
+	}
+
+	return mock
+}
+
+func (s *FakeSource) parseFiles(task *dtos.Task, files map[string]string) ([]byte, error) {
+	switch dtos.TaskSource(task.Source) {
+	case dtos.KubernetesTaskSource:
+		return utils.GroupManifestsByKind(files)
+	case dtos.DependenciesTaskSource:
+		return utils.ParseDependencies(files)
+	default:
+		return nil, fmt.Errorf("files are not supported by the %s source, use content", task.Source)
+	}
+}
@@ -0,0 +1,144 @@
+package fakesource_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/fakesource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+func TestFetch(t *testing.T) {
+	mocks := map[string]*fakesource.Mock{
+		"toml":       {Content: pointer("[envs]\nOTEL_SERVICE_NAME = \"bookmarks\"\n")},
+		"response":   {Content: pointer(`{"status":"ok"}`)},
+		"prometheus": {Value: pointer(3.5)},
+		"manifests": {Files: map[string]string{
+			"deploy/deployment.yaml": "kind: Deployment\nmetadata:\n  name: bookmarks\n",
+		}},
+		"per-file": {
+			Content:      pointer("default"),
+			ByDependency: map[string]*fakesource.Mock{"docs/a.md": {Content: pointer("a")}},
+		},
+	}
+	registry := sources.NewRegistry(fakesource.NewFakeSources(builtin.NewBuiltinSpecs(), mocks))
+
+	tests := []struct {
+		name             string
+		task             *dtos.Task
+		dependencyResult string
+		expected         string
+		expectedError    string
+	}{
+		{
+			name:     "file content parsed as by the github source",
+			task:     &dtos.Task{ID: "toml", Source: "github", Rule: "jsonpath", FilePath: "app.toml"},
+			expected: `{"envs":{"OTEL_SERVICE_NAME":"bookmarks"}}`,
+		},
+		{
+			name:     "response body",
+			task:     &dtos.Task{ID: "response", Source: "jsonapi"},
+			expected: `{"status":"ok"}`,
+		},
+		{
+			name:     "prometheus value",
+			task:     &dtos.Task{ID: "prometheus", Source: "prometheus"},
+			expected: `3.5`,
+		},
+		{
+			name:     "kubernetes manifests grouped by kind",
+			task:     &dtos.Task{ID: "manifests", Source: "kubernetes", FilePath: "deploy"},
+			expected: `{"Deployment":[{"kind":"Deployment","metadata":{"name":"bookmarks"}}]}`,
+		},
+		{
+			name:          "files not supported by the source",
+			task:          &dtos.Task{ID: "manifests", Source: "jsonapi"},
+			expectedError: "files are not supported by the jsonapi source",
+		},
+		{
+			name:             "mock of the dependency result",
+			task:             &dtos.Task{ID: "per-file", Source: "github", FilePath: "${result}"},
+			dependencyResult: "docs/a.md",
+			expected:         "a",
+		},
+		{
+			name:             "mock of the task for other dependency results",
+			task:             &dtos.Task{ID: "per-file", Source: "github", FilePath: "${result}"},
+			dependencyResult: "docs/b.md",
+			expected:         "default",
+		},
+		{
+			name: "no data without mock",
+			task: &dtos.Task{ID: "missing", Source: "github", FilePath: "app.toml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, exists := registry.Get(tt.task.Source)
+			require.True(t, exists)
+
+			data, err := source.Fetch(context.Background(), tt.task, tt.dependencyResult)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, data)
+				return
+			}
+			if tt.task.Source == "github" && tt.task.Rule != "jsonpath" {
+				assert.Equal(t, tt.expected, string(data))
+				return
+			}
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	mocks := map[string]*fakesource.Mock{
+		"found":     {Found: pointer(true)},
+		"not-found": {Found: pointer(false)},
+		"content":   {Content: pointer("uses: motain/onefootball-actions/security@v1")},
+	}
+	fakeSources := fakesource.NewFakeSources(builtin.NewBuiltinSpecs(), mocks)
+	registry := sources.NewRegistry(fakeSources)
+
+	github, _ := registry.Get("github")
+	searchable, isSearchable := github.(sources.SearchableFactSource)
+	require.True(t, isSearchable)
+
+	tests := []struct {
+		name     string
+		task     *dtos.Task
+		expected bool
+	}{
+		{name: "found", task: &dtos.Task{ID: "found"}, expected: true},
+		{name: "not found", task: &dtos.Task{ID: "not-found"}, expected: false},
+		{name: "search string in content", task: &dtos.Task{ID: "content", SearchString: "onefootball-actions/security"}, expected: true},
+		{name: "search string not in content", task: &dtos.Task{ID: "content", SearchString: "trivy"}, expected: false},
+		{name: "no mock", task: &dtos.Task{ID: "missing"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := searchable.Search(context.Background(), tt.task)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, found)
+		})
+	}
+
+	jsonAPI, _ := registry.Get("jsonapi")
+	_, isSearchable = jsonAPI.(sources.SearchableFactSource)
+	assert.False(t, isSearchable)
+}
@@ -0,0 +1,79 @@
+package filesource
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+)
+
+// FileSpec describes the file source.
+type FileSpec struct{}
+
+func (FileSpec) Name() string {
+	return string(dtos.FileTaskSource)
+}
+
+func (FileSpec) RequiredFields(task *dtos.Task) []string {
+	if dtos.TaskRule(task.Rule) == dtos.SearchRule {
+		return []string{"searchString"}
+	}
+	return []string{"filePath"}
+}
+
+// FileSource reads files from a local checkout instead of the GitHub API.
+type FileSource struct {
+	FileSpec
+	config configservice.ConfigServiceInterface
+}
+
+func NewFileSource(config configservice.ConfigServiceInterface) *FileSource {
+	return &FileSource{config: config}
+}
+
+// Fetch reads task.FilePath from the local checkout. A missing file yields no data, mirroring a 404 from GitHub.
+func (s *FileSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	extractFilePath := utils.ReplacePlaceholder(task.FilePath, dependencyResult)
+	fileContent, found, readErr := utils.ReadSourceFile(nil, s.config.GetCheckoutPath(), "", extractFilePath)
+	if readErr != nil || !found {
+		return nil, readErr
+	}
+
+	return utils.ParseFileContent(task, fileContent)
+}
+
+// Search reports whether any file in the local checkout contains task.SearchString.
+func (s *FileSource) Search(ctx context.Context, task *dtos.Task) (bool, error) {
+	found := false
+	walkErr := filepath.WalkDir(s.config.GetCheckoutPath(), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+		if strings.Contains(string(content), task.SearchString) {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return false, walkErr
+	}
+
+	return found, nil
+}
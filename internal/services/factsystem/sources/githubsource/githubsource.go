@@ -0,0 +1,55 @@
+package githubsource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+)
+
+// GitHubSpec describes the github source.
+type GitHubSpec struct{}
+
+func (GitHubSpec) Name() string {
+	return string(dtos.GitHubTaskSource)
+}
+
+func (GitHubSpec) RequiredFields(task *dtos.Task) []string {
+	if dtos.TaskRule(task.Rule) == dtos.SearchRule {
+		return []string{"repo", "searchString"}
+	}
+	return []string{"repo", "filePath"}
+}
+
+// GitHubSource reads files from GitHub repositories.
+type GitHubSource struct {
+	GitHubSpec
+	github githubservice.GitHubServiceInterface
+}
+
+func NewGitHubSource(github githubservice.GitHubServiceInterface) *GitHubSource {
+	return &GitHubSource{github: github}
+}
+
+func (s *GitHubSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	extractFilePath := utils.ReplacePlaceholder(task.FilePath, dependencyResult)
+	fileContent, fileErr := s.github.GetFileContent(task.Repo, extractFilePath)
+	if fileErr != nil {
+		if utils.IsNotFoundError(fileErr) {
+			return nil, nil
+		}
+		return nil, fileErr
+	}
+
+	return utils.ParseFileContent(task, fileContent)
+}
+
+func (s *GitHubSource) Search(ctx context.Context, task *dtos.Task) (bool, error) {
+	searchListResult, searchErr := s.github.Search(task.Repo, task.SearchString)
+	if searchErr != nil {
+		return false, fmt.Errorf("failed to process github Search request for source for string %s %s: %v", task.SearchString, task.Source, searchErr)
+	}
+	return len(searchListResult) != 0, nil
+}
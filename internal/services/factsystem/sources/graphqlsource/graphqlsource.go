@@ -0,0 +1,90 @@
+package graphqlsource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/machinebox/graphql"
+	"github.com/motain/of-catalog/internal/services/compassservice"
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+)
+
+// GraphQLSpec describes the graphql source.
+type GraphQLSpec struct{}
+
+func (GraphQLSpec) Name() string {
+	return string(dtos.GraphQLTaskSource)
+}
+
+func (GraphQLSpec) RequiredFields(task *dtos.Task) []string {
+	return []string{"query"}
+}
+
+// GraphQLSource runs GraphQL queries, against Compass unless the task sets a URI.
+type GraphQLSource struct {
+	GraphQLSpec
+	config           configservice.ConfigServiceInterface
+	compass          compassservice.CompassServiceInterface
+	newGraphQLClient func(uri string) compassservice.GraphQLClientInterface
+}
+
+func NewGraphQLSource(config configservice.ConfigServiceInterface, compass compassservice.CompassServiceInterface) *GraphQLSource {
+	return &GraphQLSource{
+		config:           config,
+		compass:          compass,
+		newGraphQLClient: compassservice.NewGraphQLClientForURI,
+	}
+}
+
+// Fetch runs task.Query against task.URI, or against Compass when no URI is given.
+// Compass queries go through the Compass service, sharing its authentication, rate limit and retries,
+// and receive the cloudId variable by default.
+func (s *GraphQLSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	if task.Query == "" {
+		return nil, errors.New("graphql query not provided")
+	}
+
+	var response map[string]interface{}
+	if task.URI == "" {
+		variables := map[string]interface{}{"cloudId": s.compass.GetCompassCloudId()}
+		for key, value := range task.Variables {
+			variables[key] = replaceDependencyPlaceholder(value, dependencyResult)
+		}
+
+		if runErr := s.compass.Run(ctx, task.Query, variables, &response); runErr != nil {
+			return nil, fmt.Errorf("failed to run graphql query: %w", runErr)
+		}
+
+		return json.Marshal(response)
+	}
+
+	client := s.newGraphQLClient(replaceDependencyPlaceholder(task.URI, dependencyResult))
+	req := graphql.NewRequest(task.Query)
+	for key, value := range task.Variables {
+		req.Var(key, replaceDependencyPlaceholder(value, dependencyResult))
+	}
+
+	if task.Auth != nil {
+		req.Header.Set(task.Auth.Header, s.config.Get(task.Auth.TokenVar))
+	}
+
+	if runErr := client.Run(ctx, req, &response); runErr != nil {
+		return nil, fmt.Errorf("failed to run graphql query: %v", runErr)
+	}
+
+	return json.Marshal(response)
+}
+
+// replaceDependencyPlaceholder only substitutes placeholders when a dependency result exists,
+// so values like Compass ARIs (ari:cloud:compass:...) are left untouched.
+func replaceDependencyPlaceholder(target, result string) string {
+	if result == "" {
+		return target
+	}
+
+	return utils.ReplacePlaceholder(target, result)
+}
@@ -0,0 +1,66 @@
+package jsonapisource
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/motain/of-catalog/internal/services/jsonservice"
+)
+
+// JSONAPISpec describes the jsonapi source.
+type JSONAPISpec struct{}
+
+func (JSONAPISpec) Name() string {
+	return string(dtos.JSONAPITaskSource)
+}
+
+func (JSONAPISpec) RequiredFields(task *dtos.Task) []string {
+	return []string{"uri"}
+}
+
+// JSONAPISource queries generic hosts returning JSON responses.
+type JSONAPISource struct {
+	JSONAPISpec
+	config      configservice.ConfigServiceInterface
+	jsonService jsonservice.JSONServiceInterface
+}
+
+func NewJSONAPISource(config configservice.ConfigServiceInterface, jsonService jsonservice.JSONServiceInterface) *JSONAPISource {
+	return &JSONAPISource{config: config, jsonService: jsonService}
+}
+
+func (s *JSONAPISource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	extractURI := utils.ReplacePlaceholder(task.URI, dependencyResult)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, extractURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+
+	if task.Auth != nil {
+		token := s.config.Get(task.Auth.TokenVar)
+		req.Header.Set(task.Auth.Header, token)
+	}
+
+	resp, fileErr := s.jsonService.Do(req)
+	if fileErr != nil {
+		return nil, fileErr
+	}
+
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			fmt.Printf("failed to close response body: %v", err)
+		}
+	}(resp.Body)
+	jsonData, readErr := io.ReadAll(resp.Body)
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read response body: %v", readErr)
+	}
+
+	return jsonData, nil
+}
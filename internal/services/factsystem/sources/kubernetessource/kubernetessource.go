@@ -0,0 +1,58 @@
+package kubernetessource
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+)
+
+// KubernetesSpec describes the kubernetes source.
+type KubernetesSpec struct{}
+
+func (KubernetesSpec) Name() string {
+	return string(dtos.KubernetesTaskSource)
+}
+
+func (KubernetesSpec) RequiredFields(task *dtos.Task) []string {
+	return []string{"filePath"}
+}
+
+// KubernetesSource reads rendered Kubernetes manifests and groups them by kind.
+type KubernetesSource struct {
+	KubernetesSpec
+	config configservice.ConfigServiceInterface
+	github githubservice.GitHubServiceInterface
+}
+
+func NewKubernetesSource(config configservice.ConfigServiceInterface, github githubservice.GitHubServiceInterface) *KubernetesSource {
+	return &KubernetesSource{config: config, github: github}
+}
+
+// Fetch reads the manifests at task.FilePath, a file or a directory,
+// from GitHub when task.Repo is set and from the local checkout otherwise.
+func (s *KubernetesSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	manifestsPath := utils.ReplacePlaceholder(task.FilePath, dependencyResult)
+
+	var manifests map[string]string
+	var readErr error
+	if task.Repo != "" {
+		manifests, readErr = s.github.GetDirectoryContent(task.Repo, manifestsPath)
+		if readErr != nil && utils.IsNotFoundError(readErr) {
+			return nil, nil
+		}
+	} else {
+		manifests, readErr = utils.ReadCheckoutTree(filepath.Join(s.config.GetCheckoutPath(), manifestsPath))
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	if manifests == nil {
+		return nil, nil
+	}
+
+	return utils.GroupManifestsByKind(manifests)
+}
@@ -0,0 +1,42 @@
+package prometheussource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/motain/of-catalog/internal/services/prometheusservice"
+)
+
+// PrometheusSpec describes the prometheus source.
+type PrometheusSpec struct{}
+
+func (PrometheusSpec) Name() string {
+	return string(dtos.PrometheusTaskSource)
+}
+
+func (PrometheusSpec) RequiredFields(task *dtos.Task) []string {
+	return []string{"prometheusQuery"}
+}
+
+// PrometheusSource runs instant queries against AWS AMP, at the evaluation time of the context.
+type PrometheusSource struct {
+	PrometheusSpec
+	prometheusService prometheusservice.PrometheusServiceInterface
+}
+
+func NewPrometheusSource(prometheusService prometheusservice.PrometheusServiceInterface) *PrometheusSource {
+	return &PrometheusSource{prometheusService: prometheusService}
+}
+
+func (s *PrometheusSource) Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error) {
+	prometheusQuery := utils.ReplacePlaceholder(task.PrometheusQuery, dependencyResult)
+	response, err := s.prometheusService.InstantQueryAt(prometheusQuery, utils.EvaluationTime(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prometheus: %v", err)
+	}
+
+	return json.Marshal(response)
+}
@@ -0,0 +1,145 @@
+package sources
+
+//go:generate mockgen -destination=./mocks/mock_sources.go -package=sources github.com/motain/of-catalog/internal/services/factsystem/sources FactSource,RegistryInterface
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+)
+
+// Spec describes a fact source independently of the clients it needs at runtime,
+// so definitions can be validated without credentials.
+type Spec interface {
+	// Name is the value used in the task `source` property.
+	Name() string
+	// RequiredFields returns the task properties (as written in YAML) the source needs for the given task.
+	RequiredFields(task *dtos.Task) []string
+}
+
+// FactSource fetches the raw data of extract tasks. The extractor applies the task rule to the result.
+type FactSource interface {
+	Spec
+	// Fetch returns the data for task. A nil result means no data was found.
+	// dependencyResult is the value of the dependency the task is evaluated for, if any.
+	Fetch(ctx context.Context, task *dtos.Task, dependencyResult string) ([]byte, error)
+}
+
+// SearchableFactSource is implemented by sources that handle the search rule natively.
+type SearchableFactSource interface {
+	FactSource
+	Search(ctx context.Context, task *dtos.Task) (bool, error)
+}
+
+type RegistryInterface interface {
+	Get(name string) (FactSource, bool)
+	Names() []string
+}
+
+type SpecRegistryInterface interface {
+	GetSpec(name string) (Spec, bool)
+	Names() []string
+}
+
+// Registry resolves fact sources by name.
+type Registry struct {
+	sources map[string]FactSource
+}
+
+func NewRegistry(factSources []FactSource) *Registry {
+	registry := &Registry{sources: make(map[string]FactSource, len(factSources))}
+	for _, source := range factSources {
+		registry.sources[source.Name()] = source
+	}
+
+	return registry
+}
+
+func (r *Registry) Get(name string) (FactSource, bool) {
+	source, exists := r.sources[name]
+	return source, exists
+}
+
+func (r *Registry) GetSpec(name string) (Spec, bool) {
+	return r.Get(name)
+}
+
+func (r *Registry) Names() []string {
+	return sortedKeys(r.sources)
+}
+
+// SpecRegistry resolves fact source specs by name, without instantiating the sources.
+type SpecRegistry struct {
+	specs map[string]Spec
+}
+
+func NewSpecRegistry(specs []Spec) *SpecRegistry {
+	registry := &SpecRegistry{specs: make(map[string]Spec, len(specs))}
+	for _, spec := range specs {
+		registry.specs[spec.Name()] = spec
+	}
+
+	return registry
+}
+
+func (r *SpecRegistry) GetSpec(name string) (Spec, bool) {
+	spec, exists := r.specs[name]
+	return spec, exists
+}
+
+func (r *SpecRegistry) Names() []string {
+	return sortedKeys(r.specs)
+}
+
+// Validate checks that an extract task references a registered source and sets every field it requires.
+func Validate(registry SpecRegistryInterface, task *dtos.Task) []error {
+	spec, exists := registry.GetSpec(task.Source)
+	if !exists {
+		return []error{fmt.Errorf("unknown source %q, expected one of %s", task.Source, strings.Join(registry.Names(), ", "))}
+	}
+
+	var errs []error
+	for _, field := range MissingFields(task, spec.RequiredFields(task)) {
+		errs = append(errs, fmt.Errorf("source %s requires field %q", task.Source, field))
+	}
+
+	return errs
+}
+
+// MissingFields returns the fields, identified by their YAML name, that are empty on task.
+func MissingFields(task *dtos.Task, fields []string) []string {
+	taskValue := reflect.ValueOf(task).Elem()
+	taskType := taskValue.Type()
+
+	missing := make([]string, 0)
+	for _, field := range fields {
+		found := false
+		for i := 0; i < taskType.NumField(); i++ {
+			yamlName := strings.Split(taskType.Field(i).Tag.Get("yaml"), ",")[0]
+			if yamlName != field {
+				continue
+			}
+			found = !taskValue.Field(i).IsZero()
+			break
+		}
+		if !found {
+			missing = append(missing, field)
+		}
+	}
+
+	return missing
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
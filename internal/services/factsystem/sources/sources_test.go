@@ -0,0 +1,66 @@
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources"
+	"github.com/motain/of-catalog/internal/services/factsystem/sources/builtin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	registry := sources.NewSpecRegistry(builtin.NewBuiltinSpecs())
+
+	tests := []struct {
+		name           string
+		task           *dtos.Task
+		expectedErrors []string
+	}{
+		{
+			name:           "valid github task",
+			task:           &dtos.Task{Source: "github", Repo: "repo", FilePath: "README.md"},
+			expectedErrors: nil,
+		},
+		{
+			name:           "github search task does not need a file path",
+			task:           &dtos.Task{Source: "github", Repo: "repo", Rule: string(dtos.SearchRule), SearchString: "needle"},
+			expectedErrors: nil,
+		},
+		{
+			name:           "missing required fields",
+			task:           &dtos.Task{Source: "github"},
+			expectedErrors: []string{`source github requires field "repo"`, `source github requires field "filePath"`},
+		},
+		{
+			name:           "missing prometheus query",
+			task:           &dtos.Task{Source: "prometheus"},
+			expectedErrors: []string{`source prometheus requires field "prometheusQuery"`},
+		},
+		{
+			name:           "unknown source",
+			task:           &dtos.Task{Source: "ftp"},
+			expectedErrors: []string{`unknown source "ftp", expected one of dependencies, file, github, graphql, jsonapi, kubernetes, prometheus`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := sources.Validate(registry, tt.task)
+
+			messages := make([]string, 0, len(errs))
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.ElementsMatch(t, tt.expectedErrors, messages)
+		})
+	}
+}
+
+func TestMissingFields(t *testing.T) {
+	task := &dtos.Task{URI: "https://example.com", Variables: map[string]string{"key": "value"}}
+
+	missing := sources.MissingFields(task, []string{"uri", "variables", "query", "unknown"})
+
+	assert.Equal(t, []string{"query", "unknown"}, missing)
+}
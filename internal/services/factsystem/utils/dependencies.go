@@ -0,0 +1,345 @@
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	GoEcosystem    = "go"
+	NpmEcosystem   = "npm"
+	PyPIEcosystem  = "pypi"
+	MavenEcosystem = "maven"
+)
+
+// DependencyManifestFiles lists the file names recognised when looking up dependencies in a directory.
+var DependencyManifestFiles = []string{
+	"go.mod",
+	"package-lock.json",
+	"requirements.txt",
+	"pom.xml",
+	"bom.json",
+	"sbom.json",
+	"sbom.cdx.json",
+	"sbom.spdx.json",
+}
+
+// Dependency is the normalized representation of a package a component depends on.
+type Dependency struct {
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+// ParseDependencies parses dependency manifests and SBOMs keyed by file path and returns
+// a sorted JSON list of the dependencies they declare.
+func ParseDependencies(files map[string]string) ([]byte, error) {
+	dependencies := make([]Dependency, 0)
+	for filePath, content := range files {
+		parsed, parseErr := parseDependencyFile(filePath, content)
+		if parseErr != nil {
+			return nil, fmt.Errorf("failed to parse dependencies from %s: %v", filePath, parseErr)
+		}
+		dependencies = append(dependencies, parsed...)
+	}
+
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].Ecosystem != dependencies[j].Ecosystem {
+			return dependencies[i].Ecosystem < dependencies[j].Ecosystem
+		}
+		if dependencies[i].Name != dependencies[j].Name {
+			return dependencies[i].Name < dependencies[j].Name
+		}
+		return dependencies[i].Version < dependencies[j].Version
+	})
+
+	return json.Marshal(dependencies)
+}
+
+// IsDependencyFile tells whether path names a dependency manifest or SBOM that can be parsed,
+// as opposed to a directory holding them.
+func IsDependencyFile(path string) bool {
+	_, supported := dependencyFileParser(filepath.Base(path))
+	return supported
+}
+
+// HasDependencyFileExtension tells whether path has the extension of a dependency manifest or SBOM,
+// e.g. package.json, and therefore names a file rather than a directory.
+func HasDependencyFileExtension(path string) bool {
+	switch filepath.Ext(path) {
+	case ".json", ".mod", ".txt", ".xml":
+		return true
+	default:
+		return false
+	}
+}
+
+func parseDependencyFile(filePath, content string) ([]Dependency, error) {
+	fileName := filepath.Base(filePath)
+	parse, supported := dependencyFileParser(fileName)
+	if !supported {
+		return nil, fmt.Errorf("unsupported dependency file %s", fileName)
+	}
+
+	return parse(content)
+}
+
+func dependencyFileParser(fileName string) (func(content string) ([]Dependency, error), bool) {
+	switch {
+	case fileName == "go.mod":
+		return func(content string) ([]Dependency, error) { return parseGoMod(content), nil }, true
+	case fileName == "package-lock.json":
+		return parsePackageLock, true
+	case strings.HasPrefix(fileName, "requirements") && filepath.Ext(fileName) == ".txt":
+		return func(content string) ([]Dependency, error) { return parseRequirements(content), nil }, true
+	case fileName == "pom.xml":
+		return parsePom, true
+	case isSBOMFile(fileName):
+		return parseSBOM, true
+	default:
+		return nil, false
+	}
+}
+
+// isSBOMFile matches the conventional names of CycloneDX and SPDX JSON documents.
+func isSBOMFile(fileName string) bool {
+	return fileName == "bom.json" ||
+		fileName == "sbom.json" ||
+		strings.HasSuffix(fileName, ".cdx.json") ||
+		strings.HasSuffix(fileName, ".spdx.json")
+}
+
+func parseGoMod(content string) []Dependency {
+	dependencies := make([]Dependency, 0)
+	inRequireBlock := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if commentIndex := strings.Index(line, "//"); commentIndex >= 0 {
+			line = strings.TrimSpace(line[:commentIndex])
+		}
+
+		switch {
+		case line == "require (":
+			inRequireBlock = true
+			continue
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inRequireBlock:
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		dependencies = append(dependencies, Dependency{Name: fields[0], Version: fields[1], Ecosystem: GoEcosystem})
+	}
+
+	return dependencies
+}
+
+type packageLock struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+	} `json:"packages"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+func parsePackageLock(content string) ([]Dependency, error) {
+	var lock packageLock
+	if err := json.Unmarshal([]byte(content), &lock); err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]Dependency, 0)
+	// lockfileVersion 2 and 3 list every installed package under "packages"
+	if len(lock.Packages) > 0 {
+		for packagePath, pkg := range lock.Packages {
+			if packagePath == "" {
+				continue
+			}
+			name := packagePath[strings.LastIndex(packagePath, "node_modules/")+len("node_modules/"):]
+			dependencies = append(dependencies, Dependency{Name: name, Version: pkg.Version, Ecosystem: NpmEcosystem})
+		}
+		return dependencies, nil
+	}
+
+	// lockfileVersion 1 nests transitive dependencies
+	var collect func(map[string]packageLockDependency)
+	collect = func(nested map[string]packageLockDependency) {
+		for name, dependency := range nested {
+			dependencies = append(dependencies, Dependency{Name: name, Version: dependency.Version, Ecosystem: NpmEcosystem})
+			collect(dependency.Dependencies)
+		}
+	}
+	collect(lock.Dependencies)
+
+	return dependencies, nil
+}
+
+var requirementPattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*(===|==|~=|!=|>=|<=|>|<)?\s*([^;\s]*)`)
+
+func parseRequirements(content string) []Dependency {
+	dependencies := make([]Dependency, 0)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if commentIndex := strings.Index(line, "#"); commentIndex >= 0 {
+			line = strings.TrimSpace(line[:commentIndex])
+		}
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue
+		}
+
+		matches := requirementPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		version := matches[4]
+		if operator := matches[3]; operator != "==" && operator != "===" && operator != "" {
+			version = operator + version
+		}
+		dependencies = append(dependencies, Dependency{Name: strings.ToLower(matches[1]), Version: version, Ecosystem: PyPIEcosystem})
+	}
+
+	return dependencies
+}
+
+type pomProject struct {
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Dependencies []pomDependency `xml:"dependencies>dependency"`
+	Management   []pomDependency `xml:"dependencyManagement>dependencies>dependency"`
+}
+
+type pomDependency struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+}
+
+var pomPropertyPattern = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+func parsePom(content string) ([]Dependency, error) {
+	var project pomProject
+	if err := xml.Unmarshal([]byte(content), &project); err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]string, len(project.Properties.Entries))
+	for _, entry := range project.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+	resolve := func(value string) string {
+		return pomPropertyPattern.ReplaceAllStringFunc(value, func(match string) string {
+			if resolved, exists := properties[pomPropertyPattern.FindStringSubmatch(match)[1]]; exists {
+				return resolved
+			}
+			return match
+		})
+	}
+
+	dependencies := make([]Dependency, 0, len(project.Dependencies)+len(project.Management))
+	for _, dependency := range append(project.Dependencies, project.Management...) {
+		dependencies = append(dependencies, Dependency{
+			Name:      fmt.Sprintf("%s:%s", resolve(dependency.GroupID), resolve(dependency.ArtifactID)),
+			Version:   resolve(dependency.Version),
+			Ecosystem: MavenEcosystem,
+		})
+	}
+
+	return dependencies, nil
+}
+
+type sbomDocument struct {
+	// CycloneDX
+	BOMFormat  string `json:"bomFormat"`
+	Components []struct {
+		Group   string `json:"group"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		PURL    string `json:"purl"`
+	} `json:"components"`
+
+	// SPDX
+	SPDXVersion string `json:"spdxVersion"`
+	Packages    []struct {
+		Name         string `json:"name"`
+		VersionInfo  string `json:"versionInfo"`
+		ExternalRefs []struct {
+			ReferenceType    string `json:"referenceType"`
+			ReferenceLocator string `json:"referenceLocator"`
+		} `json:"externalRefs"`
+	} `json:"packages"`
+}
+
+func parseSBOM(content string) ([]Dependency, error) {
+	var document sbomDocument
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return nil, err
+	}
+
+	dependencies := make([]Dependency, 0)
+	switch {
+	case strings.EqualFold(document.BOMFormat, "CycloneDX"):
+		for _, component := range document.Components {
+			name := component.Name
+			if component.Group != "" {
+				name = fmt.Sprintf("%s/%s", component.Group, component.Name)
+			}
+			dependencies = append(dependencies, Dependency{Name: name, Version: component.Version, Ecosystem: purlEcosystem(component.PURL)})
+		}
+	case document.SPDXVersion != "":
+		for _, pkg := range document.Packages {
+			ecosystem := ""
+			for _, ref := range pkg.ExternalRefs {
+				if ref.ReferenceType == "purl" {
+					ecosystem = purlEcosystem(ref.ReferenceLocator)
+					break
+				}
+			}
+			dependencies = append(dependencies, Dependency{Name: pkg.Name, Version: pkg.VersionInfo, Ecosystem: ecosystem})
+		}
+	default:
+		return nil, fmt.Errorf("unknown SBOM format, expected CycloneDX or SPDX JSON")
+	}
+
+	return dependencies, nil
+}
+
+// purlEcosystem maps a package URL type (pkg:<type>/...) onto the ecosystems used by the manifest parsers.
+func purlEcosystem(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+
+	purlType := strings.SplitN(strings.TrimPrefix(purl, "pkg:"), "/", 2)[0]
+	switch purlType {
+	case "golang":
+		return GoEcosystem
+	default:
+		return purlType
+	}
+}
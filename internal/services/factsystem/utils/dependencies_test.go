@@ -0,0 +1,159 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDependencies(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedJSON  string
+		expectedError bool
+	}{
+		{
+			name: "parses go.mod require directives",
+			files: map[string]string{
+				"go.mod": `module github.com/motain/my-service
+
+go 1.23
+
+require github.com/spf13/cobra v1.9.1
+
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/sys v0.31.0 // indirect
+)
+`,
+			},
+			expectedJSON: `[
+				{"name": "github.com/spf13/cobra", "version": "v1.9.1", "ecosystem": "go"},
+				{"name": "github.com/stretchr/testify", "version": "v1.10.0", "ecosystem": "go"},
+				{"name": "golang.org/x/sys", "version": "v0.31.0", "ecosystem": "go"}
+			]`,
+		},
+		{
+			name: "parses package-lock.json v3 packages",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 3, "packages": {
+					"": {"name": "web"},
+					"node_modules/lodash": {"version": "4.17.21"},
+					"node_modules/a/node_modules/@scope/b": {"version": "1.0.0"}
+				}}`,
+			},
+			expectedJSON: `[
+				{"name": "@scope/b", "version": "1.0.0", "ecosystem": "npm"},
+				{"name": "lodash", "version": "4.17.21", "ecosystem": "npm"}
+			]`,
+		},
+		{
+			name: "parses package-lock.json v1 nested dependencies",
+			files: map[string]string{
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {
+					"a": {"version": "1.0.0", "dependencies": {"b": {"version": "2.0.0"}}}
+				}}`,
+			},
+			expectedJSON: `[
+				{"name": "a", "version": "1.0.0", "ecosystem": "npm"},
+				{"name": "b", "version": "2.0.0", "ecosystem": "npm"}
+			]`,
+		},
+		{
+			name: "parses requirements.txt",
+			files: map[string]string{
+				"requirements.txt": "# deps\n-r base.txt\nDjango==4.2.1\nrequests[socks]>=2.31 ; python_version > '3.8'\nflask\n",
+			},
+			expectedJSON: `[
+				{"name": "django", "version": "4.2.1", "ecosystem": "pypi"},
+				{"name": "flask", "version": "", "ecosystem": "pypi"},
+				{"name": "requests", "version": ">=2.31", "ecosystem": "pypi"}
+			]`,
+		},
+		{
+			name: "parses pom.xml resolving properties",
+			files: map[string]string{
+				"pom.xml": `<project>
+					<properties><jackson.version>2.17.0</jackson.version></properties>
+					<dependencies>
+						<dependency><groupId>com.fasterxml.jackson.core</groupId><artifactId>jackson-databind</artifactId><version>${jackson.version}</version></dependency>
+					</dependencies>
+				</project>`,
+			},
+			expectedJSON: `[{"name": "com.fasterxml.jackson.core:jackson-databind", "version": "2.17.0", "ecosystem": "maven"}]`,
+		},
+		{
+			name: "parses CycloneDX and SPDX SBOMs",
+			files: map[string]string{
+				"bom.json": `{"bomFormat": "CycloneDX", "components": [
+					{"name": "gin", "group": "github.com/gin-gonic", "version": "v1.9.1", "purl": "pkg:golang/github.com/gin-gonic/gin@v1.9.1"}
+				]}`,
+				"sbom.spdx.json": `{"spdxVersion": "SPDX-2.3", "packages": [
+					{"name": "left-pad", "versionInfo": "1.3.0", "externalRefs": [{"referenceType": "purl", "referenceLocator": "pkg:npm/left-pad@1.3.0"}]}
+				]}`,
+			},
+			expectedJSON: `[
+				{"name": "github.com/gin-gonic/gin", "version": "v1.9.1", "ecosystem": "go"},
+				{"name": "left-pad", "version": "1.3.0", "ecosystem": "npm"}
+			]`,
+		},
+		{
+			name: "fails on json files that are not SBOMs",
+			files: map[string]string{
+				"package.json": `{"name": "bookmarks", "dependencies": {"left-pad": "1.3.0"}}`,
+			},
+			expectedError: true,
+		},
+		{
+			name: "fails on unknown json documents",
+			files: map[string]string{
+				"bom.json": `{"foo": "bar"}`,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := utils.ParseDependencies(tt.files)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expectedJSON, string(result))
+		})
+	}
+}
+
+func TestIsDependencyFile(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{"go.mod", true},
+		{"services/api/go.mod", true},
+		{"package-lock.json", true},
+		{"requirements-dev.txt", true},
+		{"pom.xml", true},
+		{"sbom.cdx.json", true},
+		{"bookmarks.spdx.json", true},
+		{"bom.json", true},
+		{"package.json", false},
+		{"tsconfig.json", false},
+		{".", false},
+		{"", false},
+		{"services/api", false},
+		{".github", false},
+		{"config.d", false},
+		{"notes.txt", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, utils.IsDependencyFile(tt.path))
+		})
+	}
+}
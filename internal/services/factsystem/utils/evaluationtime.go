@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+type evaluationTimeKey struct{}
+
+// WithEvaluationTime returns a context in which the facts are evaluated as of at instead of now,
+// to compute the past values of a metric.
+func WithEvaluationTime(ctx context.Context, at time.Time) context.Context {
+	return context.WithValue(ctx, evaluationTimeKey{}, at)
+}
+
+// EvaluationTime returns the time the facts are evaluated as of in ctx, now by default.
+func EvaluationTime(ctx context.Context) time.Time {
+	if at, ok := ctx.Value(evaluationTimeKey{}).(time.Time); ok {
+		return at
+	}
+
+	return time.Now()
+}
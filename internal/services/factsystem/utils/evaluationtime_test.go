@@ -0,0 +1,18 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluationTime(t *testing.T) {
+	before := time.Now()
+	assert.False(t, utils.EvaluationTime(context.Background()).Before(before), "now by default")
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, at, utils.EvaluationTime(utils.WithEvaluationTime(context.Background(), at)))
+}
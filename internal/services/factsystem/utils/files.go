@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/dtos"
+	"github.com/motain/of-catalog/internal/services/githubservice"
+	"github.com/motain/of-catalog/internal/utils/transformers"
+)
+
+// IsNotFoundError reports whether err is a 404 returned by the GitHub API.
+func IsNotFoundError(err error) bool {
+	return regexp.MustCompile(`404 Not Found`).MatchString(err.Error())
+}
+
+// ParseFileContent returns the file content as JSON when the task applies a JSON path,
+// converting TOML files, and as raw bytes otherwise.
+func ParseFileContent(task *dtos.Task, fileContent string) ([]byte, error) {
+	if dtos.TaskRule(task.Rule) != dtos.JSONPathRule {
+		return []byte(fileContent), nil
+	}
+
+	fileExtension := filepath.Ext(task.FilePath)
+	if fileExtension != ".json" && fileExtension != ".toml" {
+		return nil, fmt.Errorf("unsupported file extension: %s", fileExtension)
+	}
+
+	if fileExtension == ".toml" {
+		jsonData, transformErr := transformers.Toml2json(fileContent)
+		if transformErr != nil {
+			return nil, fmt.Errorf("failed to transform toml file to json: %v", transformErr)
+		}
+		return jsonData, nil
+	}
+
+	return []byte(fileContent), nil
+}
+
+// ReadSourceFile reads path from GitHub when repo is set and from the local checkout otherwise.
+// Missing files are reported through the found flag rather than as errors.
+func ReadSourceFile(github githubservice.GitHubServiceInterface, checkoutPath, repo, path string) (string, bool, error) {
+	if repo != "" {
+		content, fileErr := github.GetFileContent(repo, path)
+		if fileErr != nil {
+			if IsNotFoundError(fileErr) {
+				return "", false, nil
+			}
+			return "", false, fileErr
+		}
+		return content, true, nil
+	}
+
+	content, readErr := os.ReadFile(filepath.Join(checkoutPath, path))
+	if readErr != nil {
+		if errors.Is(readErr, fs.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, readErr
+	}
+	return string(content), true, nil
+}
+
+// ReadCheckoutTree returns the content of root, or of every file under it when root is a directory.
+// A missing root yields a nil map.
+func ReadCheckoutTree(root string) (map[string]string, error) {
+	if _, statErr := os.Stat(root); statErr != nil {
+		if errors.Is(statErr, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, statErr
+	}
+
+	contents := make(map[string]string)
+	walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		content, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+		contents[path] = string(content)
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	return contents, nil
+}
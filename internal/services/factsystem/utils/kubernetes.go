@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GroupManifestsByKind decodes rendered Kubernetes manifests (multi-document YAML or JSON)
+// and returns them as a JSON object mapping each kind to the list of its objects.
+// Objects of kind List are flattened into their items.
+func GroupManifestsByKind(manifests map[string]string) ([]byte, error) {
+	fileNames := make([]string, 0, len(manifests))
+	for fileName := range manifests {
+		if isManifestFile(fileName) {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+
+	grouped := make(map[string][]interface{})
+	for _, fileName := range fileNames {
+		decoder := yaml.NewDecoder(bytes.NewReader([]byte(manifests[fileName])))
+		for {
+			var object map[string]interface{}
+			decodeErr := decoder.Decode(&object)
+			if errors.Is(decodeErr, io.EOF) {
+				break
+			}
+			if decodeErr != nil {
+				return nil, fmt.Errorf("failed to decode manifest %s: %v", fileName, decodeErr)
+			}
+
+			groupManifest(grouped, object)
+		}
+	}
+
+	return json.Marshal(grouped)
+}
+
+func groupManifest(grouped map[string][]interface{}, object map[string]interface{}) {
+	kind, hasKind := object["kind"].(string)
+	if !hasKind || kind == "" {
+		return
+	}
+
+	if kind == "List" {
+		items, _ := object["items"].([]interface{})
+		for _, item := range items {
+			if itemObject, isObject := item.(map[string]interface{}); isObject {
+				groupManifest(grouped, itemObject)
+			}
+		}
+		return
+	}
+
+	grouped[kind] = append(grouped[kind], object)
+}
+
+func isManifestFile(fileName string) bool {
+	switch filepath.Ext(fileName) {
+	case ".yaml", ".yml", ".json":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,73 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/motain/of-catalog/internal/services/factsystem/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupManifestsByKind(t *testing.T) {
+	tests := []struct {
+		name          string
+		manifests     map[string]string
+		expectedJSON  string
+		expectedError bool
+	}{
+		{
+			name: "groups multi-document yaml by kind",
+			manifests: map[string]string{
+				"rendered.yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  replicas: 3
+---
+apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: api
+---
+`,
+			},
+			expectedJSON: `{
+				"Deployment": [{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "api"}, "spec": {"replicas": 3}}],
+				"PodDisruptionBudget": [{"apiVersion": "policy/v1", "kind": "PodDisruptionBudget", "metadata": {"name": "api"}}]
+			}`,
+		},
+		{
+			name: "flattens lists and ignores non manifest files",
+			manifests: map[string]string{
+				"list.json": `{"kind": "List", "items": [{"kind": "Service", "metadata": {"name": "api"}}]}`,
+				"README.md": "kind: Deployment",
+			},
+			expectedJSON: `{"Service": [{"kind": "Service", "metadata": {"name": "api"}}]}`,
+		},
+		{
+			name:         "returns an empty object when there are no manifests",
+			manifests:    map[string]string{},
+			expectedJSON: `{}`,
+		},
+		{
+			name: "fails on invalid yaml",
+			manifests: map[string]string{
+				"broken.yaml": "kind: [",
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := utils.GroupManifestsByKind(tt.manifests)
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expectedJSON, string(result))
+		})
+	}
+}
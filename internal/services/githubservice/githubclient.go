@@ -6,11 +6,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v58/github"
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/services/keyringservice"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 	"golang.org/x/oauth2"
 )
 
@@ -35,7 +38,7 @@ func NewGitHubClient(
 	serviceName := "gh:github.com"
 
 	token := cfg.GetGithubToken()
-	if token == "" {
+	if token == "" && !cassette.Replaying() {
 		var tokenErr error
 		token, tokenErr = kr.Get(serviceName, cfg.GetGithubUser())
 		if tokenErr != nil {
@@ -46,8 +49,16 @@ func NewGitHubClient(
 	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = cassette.Wrap(tc.Transport)
 
-	return &GitHubClient{client: github.NewClient(tc)}
+	client := github.NewClient(tc)
+	baseURL, urlErr := url.Parse(strings.TrimSuffix(cfg.GetGithubAPIURL(), "/") + "/")
+	if urlErr != nil {
+		panic(fmt.Errorf("invalid GitHub API URL: %w", urlErr))
+	}
+	client.BaseURL = baseURL
+
+	return &GitHubClient{client: client}
 }
 
 func (gh *GitHubClient) GetRepo() GitHubRepositoriesInterface {
@@ -15,6 +15,7 @@ type GitHubServiceInterface interface {
 	GetRepo(repo string) (*github.Repository, error)
 	GetFileExists(repo, path string) (bool, error)
 	GetFileContent(repo, path string) (string, error)
+	GetDirectoryContent(repo, path string) (map[string]string, error)
 	GetRepoProperties(repo string) (map[string]string, error)
 	GetRepoDescription(repo string) (string, error)
 	Search(repo, query string) ([]string, error)
@@ -63,6 +64,10 @@ func (gh *GitHubService) GetFileContent(repo, path string) (string, error) {
 	if fetchErr != nil {
 		return "", fmt.Errorf("failed to fetch file: %w", fetchErr)
 	}
+	if fileContent == nil {
+		// GitHub lists the content of directories instead
+		return "", fmt.Errorf("failed to fetch file: %s is not a file", path)
+	}
 
 	content, decodeErr := fileContent.GetContent()
 	if decodeErr != nil {
@@ -72,6 +77,47 @@ func (gh *GitHubService) GetFileContent(repo, path string) (string, error) {
 	return content, nil
 }
 
+// GetDirectoryContent returns the content of every file under path, keyed by file path.
+// When path points to a file, the result holds that single file.
+func (gh *GitHubService) GetDirectoryContent(repo, path string) (map[string]string, error) {
+	ctx := context.Background()
+	fileContent, directoryContent, _, fetchErr := gh.client.GetRepo().GetContents(ctx, gh.owner, repo, path, nil)
+	if fetchErr != nil {
+		return nil, fmt.Errorf("failed to fetch directory: %w", fetchErr)
+	}
+
+	contents := make(map[string]string)
+	if fileContent != nil {
+		content, decodeErr := fileContent.GetContent()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to decode file content: %w", decodeErr)
+		}
+		contents[fileContent.GetPath()] = content
+		return contents, nil
+	}
+
+	for _, entry := range directoryContent {
+		switch entry.GetType() {
+		case "dir":
+			nested, nestedErr := gh.GetDirectoryContent(repo, entry.GetPath())
+			if nestedErr != nil {
+				return nil, nestedErr
+			}
+			for nestedPath, content := range nested {
+				contents[nestedPath] = content
+			}
+		case "file":
+			content, contentErr := gh.GetFileContent(repo, entry.GetPath())
+			if contentErr != nil {
+				return nil, contentErr
+			}
+			contents[entry.GetPath()] = content
+		}
+	}
+
+	return contents, nil
+}
+
 func (gh *GitHubService) GetRepoProperties(repo string) (map[string]string, error) {
 	ctx := context.Background()
 
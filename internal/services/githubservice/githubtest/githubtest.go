@@ -0,0 +1,213 @@
+// Package githubtest provides an in-memory stand-in for the GitHub REST API,
+// implementing the subset used by the GitHub client: repositories, contents and code search.
+package githubtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Repository struct {
+	Name        string
+	Description string
+	Files       map[string]string
+}
+
+type Server struct {
+	*httptest.Server
+
+	mutex        sync.Mutex
+	repositories map[string]*Repository
+}
+
+// NewServer starts a stand-in server, the GitHub client uses it when GITHUB_API_URL is set to its URL.
+func NewServer() *Server {
+	server := &Server{repositories: make(map[string]*Repository)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/{owner}/{repo}", server.handleRepository)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/contents/{path...}", server.handleContents)
+	mux.HandleFunc("GET /search/code", server.handleSearchCode)
+	server.Server = httptest.NewServer(mux)
+
+	return server
+}
+
+// AddRepository creates an empty repository, whatever its owner.
+func (s *Server) AddRepository(name, description string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.repositories[name] = &Repository{Name: name, Description: description, Files: make(map[string]string)}
+}
+
+// AddFile stores a file in a repository, creating the repository when needed.
+func (s *Server) AddFile(repo, filePath, content string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	repository, exists := s.repositories[repo]
+	if !exists {
+		repository = &Repository{Name: repo, Files: make(map[string]string)}
+		s.repositories[repo] = repository
+	}
+	repository.Files[strings.Trim(filePath, "/")] = content
+}
+
+type content struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Encoding string `json:"encoding,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+func (s *Server) handleRepository(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	repository, exists := s.repositories[r.PathValue("repo")]
+	if !exists {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"name":              repository.Name,
+		"full_name":         r.PathValue("owner") + "/" + repository.Name,
+		"description":       repository.Description,
+		"default_branch":    "main",
+		"visibility":        "private",
+		"open_issues_count": 0,
+	})
+}
+
+func (s *Server) handleContents(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	repository, exists := s.repositories[r.PathValue("repo")]
+	if !exists {
+		writeNotFound(w)
+		return
+	}
+
+	filePath := strings.Trim(r.PathValue("path"), "/")
+	if file, isFile := repository.Files[filePath]; isFile {
+		writeJSON(w, http.StatusOK, content{
+			Type:     "file",
+			Name:     path.Base(filePath),
+			Path:     filePath,
+			Encoding: "base64",
+			Content:  base64.StdEncoding.EncodeToString([]byte(file)),
+		})
+		return
+	}
+
+	listing := directoryListing(repository, filePath)
+	if len(listing) == 0 {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, listing)
+}
+
+// handleSearchCode matches the terms of the query, other than the repo: qualifier, against the content of the files.
+func (s *Server) handleSearchCode(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var repository *Repository
+	terms := make([]string, 0)
+	for _, field := range strings.Fields(r.URL.Query().Get("q")) {
+		if fullName, isRepo := strings.CutPrefix(field, "repo:"); isRepo {
+			repository = s.repositories[path.Base(fullName)]
+			continue
+		}
+		terms = append(terms, strings.Trim(field, `"`))
+	}
+
+	items := make([]map[string]interface{}, 0)
+	if repository != nil {
+		for _, filePath := range sortedPaths(repository) {
+			if containsAll(repository.Files[filePath], terms) {
+				items = append(items, map[string]interface{}{"name": path.Base(filePath), "path": filePath})
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count":        len(items),
+		"incomplete_results": false,
+		"items":              items,
+	})
+}
+
+func directoryListing(repository *Repository, directory string) []content {
+	prefix := ""
+	if directory != "" {
+		prefix = directory + "/"
+	}
+
+	seen := make(map[string]bool)
+	listing := make([]content, 0)
+	for _, filePath := range sortedPaths(repository) {
+		rest, inDirectory := strings.CutPrefix(filePath, prefix)
+		if !inDirectory {
+			continue
+		}
+
+		name, _, isSubdirectory := strings.Cut(rest, "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		entry := content{Type: "file", Name: name, Path: prefix + name}
+		if isSubdirectory {
+			entry.Type = "dir"
+		}
+		listing = append(listing, entry)
+	}
+
+	return listing
+}
+
+func sortedPaths(repository *Repository) []string {
+	paths := make([]string, 0, len(repository.Files))
+	for filePath := range repository.Files {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func containsAll(content string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(content, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]interface{}{
+		"message":           "Not Found",
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -34,6 +34,21 @@ func (m *MockGitHubServiceInterface) EXPECT() *MockGitHubServiceInterfaceMockRec
 	return m.recorder
 }
 
+// GetDirectoryContent mocks base method.
+func (m *MockGitHubServiceInterface) GetDirectoryContent(arg0, arg1 string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDirectoryContent", arg0, arg1)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDirectoryContent indicates an expected call of GetDirectoryContent.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetDirectoryContent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDirectoryContent", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetDirectoryContent), arg0, arg1)
+}
+
 // GetFileContent mocks base method.
 func (m *MockGitHubServiceInterface) GetFileContent(arg0, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +94,21 @@ func (mr *MockGitHubServiceInterfaceMockRecorder) GetRepo(arg0 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepo", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetRepo), arg0)
 }
 
+// GetRepoDescription mocks base method.
+func (m *MockGitHubServiceInterface) GetRepoDescription(arg0 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepoDescription", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepoDescription indicates an expected call of GetRepoDescription.
+func (mr *MockGitHubServiceInterfaceMockRecorder) GetRepoDescription(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepoDescription", reflect.TypeOf((*MockGitHubServiceInterface)(nil).GetRepoDescription), arg0)
+}
+
 // GetRepoProperties mocks base method.
 func (m *MockGitHubServiceInterface) GetRepoProperties(arg0 string) (map[string]string, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,51 @@
+package historyservice
+
+import (
+	"sort"
+	"time"
+)
+
+// Change is the change of the value of the metric of a component between two records.
+type Change struct {
+	Component string
+	Metric    string
+	Before    Record
+	After     Record
+}
+
+// Decreased tells whether the value decreased. Whether it is a regression depends on the metric:
+// a decreasing coverage is, a decreasing number of vulnerabilities is not.
+func (c Change) Decreased() bool {
+	return c.After.Value < c.Before.Value
+}
+
+// Diff returns the changes of the values of the metrics of the components since the given time,
+// sorted by component and metric. The value before is the last one recorded at since, or the first
+// one recorded after it for the metrics recorded since then. records must be sorted oldest first.
+func Diff(records []Record, since time.Time) []Change {
+	type key struct{ component, metric string }
+	before := make(map[key]Record)
+	after := make(map[key]Record)
+	for _, record := range records {
+		k := key{record.Component, record.Metric}
+		if _, exists := before[k]; !exists || !record.RecordedAt.After(since) {
+			before[k] = record
+		}
+		after[k] = record
+	}
+
+	var changes []Change
+	for k, last := range after {
+		if first := before[k]; first.Value != last.Value {
+			changes = append(changes, Change{Component: k.component, Metric: k.metric, Before: first, After: last})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Component != changes[j].Component {
+			return changes[i].Component < changes[j].Component
+		}
+		return changes[i].Metric < changes[j].Metric
+	})
+
+	return changes
+}
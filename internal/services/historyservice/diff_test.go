@@ -0,0 +1,89 @@
+package historyservice_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	record := func(component, metric string, value float64, recordedAt time.Time) historyservice.Record {
+		return historyservice.Record{Component: component, Metric: metric, Value: value, RecordedAt: recordedAt}
+	}
+
+	tests := []struct {
+		name     string
+		records  []historyservice.Record
+		expected []historyservice.Change
+	}{
+		{
+			name: "compares the last value at since with the last value",
+			records: []historyservice.Record{
+				record("bookmarks", "coverage", 0.6, since.Add(-2*time.Hour)),
+				record("bookmarks", "coverage", 0.8, since),
+				record("bookmarks", "coverage", 0.9, since.Add(time.Hour)),
+				record("bookmarks", "coverage", 0.7, since.Add(2*time.Hour)),
+			},
+			expected: []historyservice.Change{
+				{
+					Component: "bookmarks", Metric: "coverage",
+					Before: record("bookmarks", "coverage", 0.8, since),
+					After:  record("bookmarks", "coverage", 0.7, since.Add(2*time.Hour)),
+				},
+			},
+		},
+		{
+			name: "compares with the first value of the metrics recorded since",
+			records: []historyservice.Record{
+				record("bookmarks", "instrumented", 0, since.Add(time.Hour)),
+				record("bookmarks", "instrumented", 1, since.Add(2*time.Hour)),
+			},
+			expected: []historyservice.Change{
+				{
+					Component: "bookmarks", Metric: "instrumented",
+					Before: record("bookmarks", "instrumented", 0, since.Add(time.Hour)),
+					After:  record("bookmarks", "instrumented", 1, since.Add(2*time.Hour)),
+				},
+			},
+		},
+		{
+			name: "ignores unchanged values",
+			records: []historyservice.Record{
+				record("bookmarks", "coverage", 0.8, since.Add(-time.Hour)),
+				record("bookmarks", "coverage", 0.5, since.Add(time.Hour)),
+				record("bookmarks", "coverage", 0.8, since.Add(2*time.Hour)),
+				record("profiles", "coverage", 0.5, since.Add(-time.Hour)),
+			},
+		},
+		{
+			name: "sorted by component and metric",
+			records: []historyservice.Record{
+				record("profiles", "coverage", 1, since.Add(-time.Hour)),
+				record("bookmarks", "instrumented", 1, since.Add(-time.Hour)),
+				record("bookmarks", "coverage", 1, since.Add(-time.Hour)),
+				record("profiles", "coverage", 0, since.Add(time.Hour)),
+				record("bookmarks", "instrumented", 0, since.Add(time.Hour)),
+				record("bookmarks", "coverage", 0, since.Add(time.Hour)),
+			},
+			expected: []historyservice.Change{
+				{Component: "bookmarks", Metric: "coverage", Before: record("bookmarks", "coverage", 1, since.Add(-time.Hour)), After: record("bookmarks", "coverage", 0, since.Add(time.Hour))},
+				{Component: "bookmarks", Metric: "instrumented", Before: record("bookmarks", "instrumented", 1, since.Add(-time.Hour)), After: record("bookmarks", "instrumented", 0, since.Add(time.Hour))},
+				{Component: "profiles", Metric: "coverage", Before: record("profiles", "coverage", 1, since.Add(-time.Hour)), After: record("profiles", "coverage", 0, since.Add(time.Hour))},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, historyservice.Diff(tt.records, since))
+		})
+	}
+}
+
+func TestChange_Decreased(t *testing.T) {
+	assert.True(t, historyservice.Change{Before: historyservice.Record{Value: 1}, After: historyservice.Record{Value: 0.5}}.Decreased())
+	assert.False(t, historyservice.Change{Before: historyservice.Record{Value: 0.5}, After: historyservice.Record{Value: 1}}.Decreased())
+}
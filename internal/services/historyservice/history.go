@@ -0,0 +1,119 @@
+package historyservice
+
+//go:generate mockgen -destination=./mocks/mock_history_service.go -package=historyservice github.com/motain/of-catalog/internal/services/historyservice HistoryServiceInterface
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/configservice"
+)
+
+const filePermission = 0644
+
+// Record is a value computed for the metric of a component.
+type Record struct {
+	Component  string    `json:"component"`
+	Metric     string    `json:"metric"`
+	Value      float64   `json:"value"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// Filter selects records, its zero fields select every record.
+type Filter struct {
+	Component string
+	Metric    string
+	// Since excludes the records recorded before it
+	Since time.Time
+}
+
+func (f Filter) matches(record Record) bool {
+	return (f.Component == "" || f.Component == record.Component) &&
+		(f.Metric == "" || f.Metric == record.Metric) &&
+		!record.RecordedAt.Before(f.Since)
+}
+
+// HistoryServiceInterface keeps the values computed for the metrics of the components.
+type HistoryServiceInterface interface {
+	Append(record Record) error
+	// Records returns the records matching filter, oldest recorded first.
+	Records(filter Filter) ([]Record, error)
+}
+
+// HistoryService keeps the records in a local append-only JSON Lines file, one record per line.
+type HistoryService struct {
+	path string
+}
+
+func NewHistoryService(config configservice.ConfigServiceInterface) *HistoryService {
+	return NewHistoryServiceAt(config.GetMetricHistoryPath())
+}
+
+// NewHistoryServiceAt returns a history stored in the file at path.
+func NewHistoryServiceAt(path string) *HistoryService {
+	return &HistoryService{path: path}
+}
+
+func (s *HistoryService) Append(record Record) error {
+	record.RecordedAt = record.RecordedAt.UTC()
+	line, marshalErr := json.Marshal(record)
+	if marshalErr != nil {
+		return marshalErr
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	file, openErr := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePermission)
+	if openErr != nil {
+		return openErr
+	}
+	defer file.Close()
+
+	// A single write per record, so that the records appended by concurrent runs are not interleaved
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append to the metric history: %w", err)
+	}
+
+	return file.Close()
+}
+
+func (s *HistoryService) Records(filter Filter) ([]Record, error) {
+	file, openErr := os.Open(s.path)
+	if openErr != nil {
+		if errors.Is(openErr, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, openErr
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("failed to decode line %d of the metric history %s: %w", lineNumber, s.path, err)
+		}
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].RecordedAt.Before(records[j].RecordedAt) })
+
+	return records, nil
+}
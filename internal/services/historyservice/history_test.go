@@ -0,0 +1,75 @@
+package historyservice_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryService(t *testing.T) {
+	history := historyservice.NewHistoryServiceAt(filepath.Join(t.TempDir(), "history", "metric-history.jsonl"))
+
+	records, recordsErr := history.Records(historyservice.Filter{})
+	require.NoError(t, recordsErr)
+	assert.Empty(t, records, "no history yet")
+
+	recordedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, history.Append(historyservice.Record{Component: "bookmarks", Metric: "coverage", Value: 0.8, RecordedAt: recordedAt.Add(time.Hour)}))
+	require.NoError(t, history.Append(historyservice.Record{Component: "bookmarks", Metric: "coverage", Value: 0.7, RecordedAt: recordedAt}))
+	require.NoError(t, history.Append(historyservice.Record{Component: "bookmarks", Metric: "instrumented", Value: 1, RecordedAt: recordedAt}))
+	require.NoError(t, history.Append(historyservice.Record{Component: "profiles", Metric: "coverage", Value: 0.5, RecordedAt: recordedAt.In(time.FixedZone("CEST", 2*60*60))}))
+
+	tests := []struct {
+		name     string
+		filter   historyservice.Filter
+		expected []historyservice.Record
+	}{
+		{
+			name:   "every record, oldest first",
+			filter: historyservice.Filter{},
+			expected: []historyservice.Record{
+				{Component: "bookmarks", Metric: "coverage", Value: 0.7, RecordedAt: recordedAt},
+				{Component: "bookmarks", Metric: "instrumented", Value: 1, RecordedAt: recordedAt},
+				{Component: "profiles", Metric: "coverage", Value: 0.5, RecordedAt: recordedAt},
+				{Component: "bookmarks", Metric: "coverage", Value: 0.8, RecordedAt: recordedAt.Add(time.Hour)},
+			},
+		},
+		{
+			name:   "by component and metric",
+			filter: historyservice.Filter{Component: "bookmarks", Metric: "coverage"},
+			expected: []historyservice.Record{
+				{Component: "bookmarks", Metric: "coverage", Value: 0.7, RecordedAt: recordedAt},
+				{Component: "bookmarks", Metric: "coverage", Value: 0.8, RecordedAt: recordedAt.Add(time.Hour)},
+			},
+		},
+		{
+			name:   "since a time",
+			filter: historyservice.Filter{Since: recordedAt.Add(time.Minute)},
+			expected: []historyservice.Record{
+				{Component: "bookmarks", Metric: "coverage", Value: 0.8, RecordedAt: recordedAt.Add(time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := history.Records(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, records)
+		})
+	}
+}
+
+func TestHistoryService_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metric-history.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"component\":\"bookmarks\"}\n\nnot json\n"), 0644))
+
+	_, err := historyservice.NewHistoryServiceAt(path).Records(historyservice.Filter{})
+
+	assert.ErrorContains(t, err, "failed to decode line 3 of the metric history")
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/motain/of-catalog/internal/services/configservice"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 )
 
 type JSONServiceInterface interface {
@@ -23,9 +24,9 @@ func NewJSONService(config configservice.ConfigServiceInterface) JSONServiceInte
 	}
 
 	return &http.Client{
-		Transport: &JSONTransport{
+		Transport: cassette.Wrap(&JSONTransport{
 			Transport: baseTransport,
-		},
+		}),
 	}
 }
 
@@ -37,10 +37,10 @@ func (m *MockPrometheusClientInterface) EXPECT() *MockPrometheusClientInterfaceM
 }
 
 // Query mocks base method.
-func (m *MockPrometheusClientInterface) Query(arg0 string, arg1 time.Time) (model.Value, error) {
+func (m *MockPrometheusClientInterface) Query(arg0 string, arg1 time.Time) (float64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Query", arg0, arg1)
-	ret0, _ := ret[0].(model.Value)
+	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -36,10 +36,10 @@ func (m *MockPrometheusServiceInterface) EXPECT() *MockPrometheusServiceInterfac
 }
 
 // InstantQuery mocks base method.
-func (m *MockPrometheusServiceInterface) InstantQuery(arg0 string) (model.Value, error) {
+func (m *MockPrometheusServiceInterface) InstantQuery(arg0 string) (float64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InstantQuery", arg0)
-	ret0, _ := ret[0].(model.Value)
+	ret0, _ := ret[0].(float64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -50,6 +50,21 @@ func (mr *MockPrometheusServiceInterfaceMockRecorder) InstantQuery(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantQuery", reflect.TypeOf((*MockPrometheusServiceInterface)(nil).InstantQuery), arg0)
 }
 
+// InstantQueryAt mocks base method.
+func (m *MockPrometheusServiceInterface) InstantQueryAt(arg0 string, arg1 time.Time) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantQueryAt", arg0, arg1)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantQueryAt indicates an expected call of InstantQueryAt.
+func (mr *MockPrometheusServiceInterfaceMockRecorder) InstantQueryAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantQueryAt", reflect.TypeOf((*MockPrometheusServiceInterface)(nil).InstantQueryAt), arg0, arg1)
+}
+
 // RangeQuery mocks base method.
 func (m *MockPrometheusServiceInterface) RangeQuery(arg0 string, arg1, arg2 time.Time, arg3 time.Duration) (model.Value, error) {
 	m.ctrl.T.Helper()
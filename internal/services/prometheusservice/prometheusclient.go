@@ -18,6 +18,7 @@ import (
 
 	"github.com/motain/of-catalog/internal/services/configservice"
 	"github.com/motain/of-catalog/internal/utils/awsutils"
+	"github.com/motain/of-catalog/internal/utils/cassette"
 )
 
 // PrometheusClientInterface defines the contract for Prometheus client operations.
@@ -60,17 +61,20 @@ func NewPrometheusClient(cfg configservice.ConfigServiceInterface) PrometheusCli
 		panic(fmt.Errorf("failed to load AWS config: %w", err))
 	}
 
-	// Set up credentials provider
-	credProvider := getCredentialsProvider(ctx, awsCfg, cfg.GetAWSRole())
+	// Set up credentials provider, requests replayed from a cassette are not signed
+	var credProvider aws.CredentialsProvider = aws.AnonymousCredentials{}
+	if !cassette.Replaying() {
+		credProvider = getCredentialsProvider(ctx, awsCfg, cfg.GetAWSRole())
+	}
 
 	// Create authenticated HTTP client
 	httpClient := &http.Client{
-		Transport: &awsutils.SigV4RoundTripper{
+		Transport: cassette.Wrap(&awsutils.SigV4RoundTripper{
 			Transport:   http.DefaultTransport,
 			Region:      region,
 			Service:     "aps",
 			Credentials: credProvider,
-		},
+		}),
 	}
 
 	// Initialize Prometheus client
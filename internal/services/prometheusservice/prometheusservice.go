@@ -16,6 +16,9 @@ type PrometheusServiceInterface interface {
 	// Returns the query result as a Prometheus model.Value.
 	InstantQuery(queryString string) (float64, error)
 
+	// InstantQueryAt executes a PromQL query at the given time.
+	InstantQueryAt(queryString string, at time.Time) (float64, error)
+
 	// RangeQuery executes a PromQL query over a specified time range.
 	// Returns the query result as a Prometheus model.Value.
 	RangeQuery(queryString string, start, end time.Time, step time.Duration) (model.Value, error)
@@ -55,6 +58,19 @@ func (ps *PrometheusService) InstantQuery(queryString string) (float64, error) {
 	return ps.client.Query(queryString, time.Now())
 }
 
+// InstantQueryAt executes a PromQL query at the given time, to get a past value.
+//
+// Parameters:
+//   - queryString: The PromQL query to execute
+//   - at: The evaluation time of the query
+//
+// Returns:
+//   - float64: The value of the last sample of the result vector
+//   - error: Any error that occurred during query execution
+func (ps *PrometheusService) InstantQueryAt(queryString string, at time.Time) (float64, error) {
+	return ps.client.Query(queryString, at)
+}
+
 // RangeQuery executes a PromQL query over a specified time range.
 // This method provides a simplified interface for executing range queries
 // by accepting start time, end time, and step duration as separate parameters.
@@ -0,0 +1,297 @@
+// Package cassette records the HTTP interactions of the clients used by the fact system and replays them,
+// so that a computation can be reproduced without access to the services it reads from.
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FileName is the name of the cassette file in the cassette directory
+const FileName = "cassette.json"
+
+type Mode string
+
+const (
+	Off    Mode = ""
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+// volatileParameters change between two runs of the same query, such as the evaluation time of
+// Prometheus queries, and are ignored when matching form encoded requests
+var volatileParameters = []string{"time", "start", "end"}
+
+// Interaction is a recorded request and the response it received.
+// Request headers are not recorded as they hold credentials.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode   int         `json:"statusCode"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+// Cassette holds the interactions of a cassette directory
+type Cassette struct {
+	mutex        sync.Mutex
+	path         string
+	Interactions []*Interaction `json:"interactions"`
+	// replayed counts the interactions replayed per request key
+	replayed map[string]int
+}
+
+// New returns an empty cassette, saved in dir as interactions are recorded
+func New(dir string) *Cassette {
+	return &Cassette{path: filepath.Join(dir, FileName), Interactions: make([]*Interaction, 0)}
+}
+
+// Load reads the cassette saved in dir
+func Load(dir string) (*Cassette, error) {
+	cassette := New(dir)
+	content, readErr := os.ReadFile(cassette.path)
+	if readErr != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", readErr)
+	}
+	if unmarshalErr := json.Unmarshal(content, cassette); unmarshalErr != nil {
+		return nil, fmt.Errorf("failed to parse cassette %s: %w", cassette.path, unmarshalErr)
+	}
+
+	return cassette, nil
+}
+
+// Recorder returns a transport sending requests through transport and recording them in the cassette
+func (c *Cassette) Recorder(transport http.RoundTripper) http.RoundTripper {
+	return &recorder{cassette: c, transport: transport}
+}
+
+// Replayer returns a transport answering requests with the responses recorded in the cassette.
+// Requests recorded several times are answered in the order they were recorded, the last response
+// being repeated. A request that was not recorded fails.
+func (c *Cassette) Replayer() http.RoundTripper {
+	return &replayer{cassette: c}
+}
+
+func (c *Cassette) record(interaction *Interaction) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.Interactions = append(c.Interactions, interaction)
+
+	content, marshalErr := json.MarshalIndent(c, "", "  ")
+	if marshalErr != nil {
+		return marshalErr
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(c.path), 0755); mkdirErr != nil {
+		return mkdirErr
+	}
+
+	return os.WriteFile(c.path, content, 0644)
+}
+
+func (c *Cassette) replay(request Request) (*Interaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := request.key()
+	matches := make([]*Interaction, 0)
+	for _, interaction := range c.Interactions {
+		if interaction.Request.key() == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cassette: no recorded interaction for %s %s", request.Method, request.URL)
+	}
+
+	if c.replayed == nil {
+		c.replayed = make(map[string]int)
+	}
+	index := min(c.replayed[key], len(matches)-1)
+	c.replayed[key]++
+
+	return matches[index], nil
+}
+
+type recorder struct {
+	cassette  *Cassette
+	transport http.RoundTripper
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, requestErr := newRequest(req)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	res, roundTripErr := r.transport.RoundTrip(req)
+	if roundTripErr != nil {
+		return nil, roundTripErr
+	}
+
+	body, readErr := io.ReadAll(res.Body)
+	res.Body.Close()
+	if readErr != nil {
+		return nil, readErr
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := res.Header.Clone()
+	header.Del("Set-Cookie")
+	response := Response{StatusCode: res.StatusCode, Header: header, Body: string(body)}
+	if !utf8.Valid(body) {
+		response.Body = base64.StdEncoding.EncodeToString(body)
+		response.BodyEncoding = "base64"
+	}
+
+	if recordErr := r.cassette.record(&Interaction{Request: request, Response: response}); recordErr != nil {
+		return nil, fmt.Errorf("failed to record interaction: %w", recordErr)
+	}
+
+	return res, nil
+}
+
+type replayer struct {
+	cassette *Cassette
+}
+
+func (r *replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	request, requestErr := newRequest(req)
+	if requestErr != nil {
+		return nil, requestErr
+	}
+
+	interaction, replayErr := r.cassette.replay(request)
+	if replayErr != nil {
+		return nil, replayErr
+	}
+
+	body := []byte(interaction.Response.Body)
+	if interaction.Response.BodyEncoding == "base64" {
+		decoded, decodeErr := base64.StdEncoding.DecodeString(interaction.Response.Body)
+		if decodeErr != nil {
+			return nil, fmt.Errorf("cassette: invalid response body for %s %s: %w", request.Method, request.URL, decodeErr)
+		}
+		body = decoded
+	}
+
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		StatusCode:    interaction.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// newRequest reads the request body, leaving it in place to be sent
+func newRequest(req *http.Request) (Request, error) {
+	request := Request{Method: req.Method, URL: req.URL.String(), ContentType: req.Header.Get("Content-Type")}
+	if req.Body == nil || req.Body == http.NoBody {
+		return request, nil
+	}
+
+	body, readErr := io.ReadAll(req.Body)
+	req.Body.Close()
+	if readErr != nil {
+		return request, fmt.Errorf("failed to read request body: %w", readErr)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	request.Body = string(body)
+
+	return request, nil
+}
+
+// key identifies the requests answered by the same recorded interaction
+func (r Request) key() string {
+	body := r.Body
+	if strings.HasPrefix(r.ContentType, "application/x-www-form-urlencoded") {
+		if values, parseErr := url.ParseQuery(body); parseErr == nil {
+			for _, parameter := range volatileParameters {
+				values.Del(parameter)
+			}
+			body = values.Encode()
+		}
+	}
+
+	return r.Method + " " + r.URL + "\n" + body
+}
+
+// active is the cassette used by the clients built with Wrap
+var active struct {
+	mode     Mode
+	cassette *Cassette
+}
+
+// Use sets the cassette used by the HTTP clients built afterwards: interactions are recorded in dir
+// in the record mode and answered from dir in the replay mode.
+func Use(mode Mode, dir string) error {
+	switch mode {
+	case Off:
+		active.mode, active.cassette = Off, nil
+	case Record:
+		if dir == "" {
+			return errors.New("cassette: a directory is required to record")
+		}
+		active.mode, active.cassette = Record, New(dir)
+	case Replay:
+		cassette, loadErr := Load(dir)
+		if loadErr != nil {
+			return loadErr
+		}
+		active.mode, active.cassette = Replay, cassette
+	default:
+		return fmt.Errorf("cassette: unknown mode %s", mode)
+	}
+
+	return nil
+}
+
+// Wrap returns the transport the HTTP clients should use: transport itself unless a cassette is in use
+func Wrap(transport http.RoundTripper) http.RoundTripper {
+	switch active.mode {
+	case Record:
+		return active.cassette.Recorder(transport)
+	case Replay:
+		return active.cassette.Replayer()
+	}
+
+	return transport
+}
+
+// Replaying tells whether requests are answered from a cassette, so clients can skip
+// looking up credentials they will not use, and replayed values are told from computed ones
+func Replaying() bool {
+	return active.mode == Replay
+}
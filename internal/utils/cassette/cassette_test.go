@@ -0,0 +1,143 @@
+package cassette_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/utils/cassette"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func get(t *testing.T, client *http.Client, target string) (int, string) {
+	res, err := client.Get(target)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res.StatusCode, string(body)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/binary":
+			w.Write([]byte{0xff, 0xfe, 0x00})
+		default:
+			fmt.Fprintf(w, `{"path":%q,"call":%d}`, r.URL.Path, calls)
+		}
+	}))
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: cassette.New(dir).Recorder(http.DefaultTransport)}
+	get(t, recorder, server.URL+"/repos/bookmarks")
+	get(t, recorder, server.URL+"/repos/bookmarks")
+	get(t, recorder, server.URL+"/missing")
+	get(t, recorder, server.URL+"/binary")
+	server.Close()
+
+	loaded, err := cassette.Load(dir)
+	require.NoError(t, err)
+	require.Len(t, loaded.Interactions, 4)
+	assert.Equal(t, "base64", loaded.Interactions[3].Response.BodyEncoding)
+
+	replayer := &http.Client{Transport: loaded.Replayer()}
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "first recorded response", path: "/repos/bookmarks", expectedStatus: http.StatusOK, expectedBody: `{"path":"/repos/bookmarks","call":1}`},
+		{name: "responses replayed in order", path: "/repos/bookmarks", expectedStatus: http.StatusOK, expectedBody: `{"path":"/repos/bookmarks","call":2}`},
+		{name: "last response repeated", path: "/repos/bookmarks", expectedStatus: http.StatusOK, expectedBody: `{"path":"/repos/bookmarks","call":2}`},
+		{name: "error status", path: "/missing", expectedStatus: http.StatusNotFound, expectedBody: "404 page not found\n"},
+		{name: "binary body", path: "/binary", expectedStatus: http.StatusOK, expectedBody: string([]byte{0xff, 0xfe, 0x00})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, replayer, server.URL+tt.path)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
+
+func TestReplayFailsForUnrecordedRequest(t *testing.T) {
+	replayer := &http.Client{Transport: cassette.New(t.TempDir()).Replayer()}
+
+	_, err := replayer.Get("http://localhost/repos/bookmarks")
+
+	assert.ErrorContains(t, err, "cassette: no recorded interaction for GET http://localhost/repos/bookmarks")
+}
+
+func TestReplayIgnoresVolatileFormParameters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		fmt.Fprintf(w, "%s at %s", r.PostForm.Get("query"), r.PostForm.Get("time"))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+
+	recorder := &http.Client{Transport: cassette.New(dir).Recorder(http.DefaultTransport)}
+	_, err := recorder.PostForm(server.URL+"/api/v1/query", url.Values{"query": {"up"}, "time": {"1700000000"}})
+	require.NoError(t, err)
+
+	loaded, err := cassette.Load(dir)
+	require.NoError(t, err)
+	replayer := &http.Client{Transport: loaded.Replayer()}
+
+	res, err := replayer.PostForm(server.URL+"/api/v1/query", url.Values{"query": {"up"}, "time": {"1800000000"}})
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "up at 1700000000", string(body))
+
+	_, err = replayer.PostForm(server.URL+"/api/v1/query", url.Values{"query": {"down"}, "time": {"1700000000"}})
+	assert.Error(t, err)
+}
+
+func TestUse(t *testing.T) {
+	t.Cleanup(func() { cassette.Use(cassette.Off, "") })
+	transport := http.DefaultTransport
+
+	require.NoError(t, cassette.Use(cassette.Off, ""))
+	assert.Same(t, transport, cassette.Wrap(transport))
+	assert.False(t, cassette.Replaying())
+
+	assert.ErrorContains(t, cassette.Use(cassette.Record, ""), "a directory is required")
+	require.NoError(t, cassette.Use(cassette.Record, t.TempDir()))
+	assert.NotSame(t, transport, cassette.Wrap(transport))
+	assert.False(t, cassette.Replaying())
+
+	assert.ErrorContains(t, cassette.Use(cassette.Replay, t.TempDir()), "failed to read cassette")
+
+	dir := t.TempDir()
+	recorder := &http.Client{Transport: cassette.New(dir).Recorder(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Header: http.Header{}}, nil
+	}))}
+	_, err := recorder.Get("http://localhost/")
+	require.NoError(t, err)
+	require.NoError(t, cassette.Use(cassette.Replay, dir))
+	assert.True(t, cassette.Replaying())
+
+	assert.ErrorContains(t, cassette.Use("rewind", ""), "unknown mode rewind")
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,59 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lifecycle customises how apply handles a definition.
+type Lifecycle struct {
+	// PreventDestroy makes apply refuse to delete the definition, even with --allow-delete.
+	PreventDestroy bool `yaml:"preventDestroy,omitempty"`
+}
+
+// DeletePolicy limits the deletions performed by an apply.
+type DeletePolicy struct {
+	// AllowDelete must be set for apply to delete anything.
+	AllowDelete bool
+	// MaxDelete is the maximum number of deletions per kind, 0 for no limit.
+	MaxDelete int
+}
+
+// CheckDeletions returns an error when the policy does not allow deleting the items of kind, or when
+// one of them is protected by its lifecycle.
+func CheckDeletions[T any](kind string, deleted map[string]*T, policy DeletePolicy, getLifecycle func(*T) Lifecycle) error {
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	names := sortedKeys(deleted)
+	protected := make([]string, 0)
+	for _, name := range names {
+		if getLifecycle(deleted[name]).PreventDestroy {
+			protected = append(protected, name)
+		}
+	}
+
+	if len(protected) > 0 {
+		return fmt.Errorf(
+			"refusing to delete %s %s: protected by lifecycle.preventDestroy",
+			kind, strings.Join(protected, ", "),
+		)
+	}
+
+	if !policy.AllowDelete {
+		return fmt.Errorf(
+			"apply would delete %d %s(s) missing from the configuration: %s; rerun with --allow-delete to delete them",
+			len(names), kind, strings.Join(names, ", "),
+		)
+	}
+
+	if policy.MaxDelete > 0 && len(names) > policy.MaxDelete {
+		return fmt.Errorf(
+			"apply would delete %d %s(s), more than the limit of %d set by --max-delete: %s",
+			len(names), kind, policy.MaxDelete, strings.Join(names, ", "),
+		)
+	}
+
+	return nil
+}
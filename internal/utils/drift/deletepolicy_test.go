@@ -0,0 +1,72 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type protectedStruct struct {
+	Lifecycle Lifecycle
+}
+
+func getLifecycle(p *protectedStruct) Lifecycle {
+	return p.Lifecycle
+}
+
+func TestCheckDeletions(t *testing.T) {
+	protected := &protectedStruct{Lifecycle: Lifecycle{PreventDestroy: true}}
+
+	tests := []struct {
+		name          string
+		deleted       map[string]*protectedStruct
+		policy        DeletePolicy
+		expectedError string
+	}{
+		{
+			name:    "nothing deleted",
+			deleted: map[string]*protectedStruct{},
+			policy:  DeletePolicy{},
+		},
+		{
+			name:          "deletions not allowed",
+			deleted:       map[string]*protectedStruct{"b": {}, "a": {}},
+			policy:        DeletePolicy{},
+			expectedError: "apply would delete 2 component(s) missing from the configuration: a, b; rerun with --allow-delete to delete them",
+		},
+		{
+			name:    "deletions allowed",
+			deleted: map[string]*protectedStruct{"a": {}, "b": {}},
+			policy:  DeletePolicy{AllowDelete: true},
+		},
+		{
+			name:    "deletions within the limit",
+			deleted: map[string]*protectedStruct{"a": {}, "b": {}},
+			policy:  DeletePolicy{AllowDelete: true, MaxDelete: 2},
+		},
+		{
+			name:          "deletions over the limit",
+			deleted:       map[string]*protectedStruct{"a": {}, "b": {}, "c": {}},
+			policy:        DeletePolicy{AllowDelete: true, MaxDelete: 2},
+			expectedError: "apply would delete 3 component(s), more than the limit of 2 set by --max-delete: a, b, c",
+		},
+		{
+			name:          "protected definition",
+			deleted:       map[string]*protectedStruct{"a": {}, "b": protected},
+			policy:        DeletePolicy{AllowDelete: true},
+			expectedError: "refusing to delete component b: protected by lifecycle.preventDestroy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckDeletions("component", tt.deleted, tt.policy, getLifecycle)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
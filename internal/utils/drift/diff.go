@@ -0,0 +1,237 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Absent is how a value missing from one side of a Change is displayed.
+const Absent = "<absent>"
+
+// Change is a difference between two versions of a value at Path, such as "spec.links[0].url".
+// Old or New is nil when the value is missing from that version.
+type Change struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s => %s", c.Path, FormatValue(c.Old), FormatValue(c.New))
+}
+
+// FormatValue displays a changed value, quoting strings and showing missing values as Absent.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return Absent
+	case string:
+		if v == Absent {
+			return v
+		}
+		return fmt.Sprintf("%q", v)
+	case []string:
+		return "[" + strings.Join(v, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Diff returns the structural differences between old and new, with paths below path. Structs are walked
+// field by field, named after their yaml tags, maps key by key and slices index by index. Other values,
+// and structs without exported fields, are compared as a whole.
+func Diff(path string, old, new interface{}) []Change {
+	return diffValues(path, reflect.ValueOf(old), reflect.ValueOf(new))
+}
+
+// DiffUnlessEqual returns the differences between old and new unless equal, the result of a domain specific
+// comparison, holds. When the comparison finds a difference the structural diff does not, for instance because
+// of ordering, the whole value is reported as changed.
+func DiffUnlessEqual(path string, old, new interface{}, equal bool) []Change {
+	if equal {
+		return nil
+	}
+
+	if changes := Diff(path, old, new); len(changes) > 0 {
+		return changes
+	}
+
+	return []Change{{Path: path, Old: old, New: new}}
+}
+
+// HasChange reports whether one of changes is at path or below it.
+func HasChange(changes []Change, path string) bool {
+	for _, change := range changes {
+		if isBelow(change.Path, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Without returns the changes that are neither at nor below any of paths.
+func Without(changes []Change, paths ...string) []Change {
+	var kept []Change
+	for _, change := range changes {
+		excluded := false
+		for _, path := range paths {
+			if isBelow(change.Path, path) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, change)
+		}
+	}
+
+	return kept
+}
+
+func isBelow(changePath, path string) bool {
+	if changePath == path {
+		return true
+	}
+
+	return strings.HasPrefix(changePath, path) && strings.ContainsRune(".[", rune(changePath[len(path)]))
+}
+
+func diffValues(path string, old, new reflect.Value) []Change {
+	old, new = indirect(old), indirect(new)
+	if !old.IsValid() || !new.IsValid() {
+		if !old.IsValid() && !new.IsValid() {
+			return nil
+		}
+		return []Change{{Path: path, Old: valueOf(old), New: valueOf(new)}}
+	}
+
+	if old.Type() != new.Type() {
+		return diffLeaves(path, old, new)
+	}
+
+	switch old.Kind() {
+	case reflect.Struct:
+		return diffStructs(path, old, new)
+	case reflect.Map:
+		return diffMaps(path, old, new)
+	case reflect.Slice, reflect.Array:
+		return diffSlices(path, old, new)
+	default:
+		return diffLeaves(path, old, new)
+	}
+}
+
+func diffStructs(path string, old, new reflect.Value) []Change {
+	var changes []Change
+	walked := false
+	for i := 0; i < old.NumField(); i++ {
+		field := old.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, inline := fieldName(field)
+		if name == "-" {
+			continue
+		}
+		walked = true
+
+		fieldPath := joinPath(path, name)
+		if inline {
+			fieldPath = path
+		}
+		changes = append(changes, diffValues(fieldPath, old.Field(i), new.Field(i))...)
+	}
+
+	if !walked {
+		return diffLeaves(path, old, new)
+	}
+
+	return changes
+}
+
+func diffMaps(path string, old, new reflect.Value) []Change {
+	keys := make(map[string]reflect.Value, old.Len()+new.Len())
+	for _, key := range append(old.MapKeys(), new.MapKeys()...) {
+		keys[fmt.Sprint(key.Interface())] = key
+	}
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []Change
+	for _, name := range names {
+		key := keys[name]
+		changes = append(changes, diffValues(fmt.Sprintf("%s[%s]", path, name), old.MapIndex(key), new.MapIndex(key))...)
+	}
+
+	return changes
+}
+
+func diffSlices(path string, old, new reflect.Value) []Change {
+	var changes []Change
+	for i := 0; i < max(old.Len(), new.Len()); i++ {
+		var oldItem, newItem reflect.Value
+		if i < old.Len() {
+			oldItem = old.Index(i)
+		}
+		if i < new.Len() {
+			newItem = new.Index(i)
+		}
+		changes = append(changes, diffValues(fmt.Sprintf("%s[%d]", path, i), oldItem, newItem)...)
+	}
+
+	return changes
+}
+
+func diffLeaves(path string, old, new reflect.Value) []Change {
+	if reflect.DeepEqual(old.Interface(), new.Interface()) {
+		return nil
+	}
+
+	return []Change{{Path: path, Old: old.Interface(), New: new.Interface()}}
+}
+
+// indirect follows pointers and interfaces, returning an invalid value for nil ones.
+func indirect(value reflect.Value) reflect.Value {
+	for value.IsValid() && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+
+	return value
+}
+
+func valueOf(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	return value.Interface()
+}
+
+func fieldName(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	inline := strings.Contains(options, "inline")
+	if name == "" {
+		name = strings.ToLower(field.Name[:1]) + field.Name[1:]
+	}
+
+	return name, inline
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
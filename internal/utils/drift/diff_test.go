@@ -0,0 +1,138 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type diffLink struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+}
+
+type diffSpec struct {
+	Description string                 `yaml:"description"`
+	Owner       *string                `yaml:"ownerId"`
+	Links       []diffLink             `yaml:"links"`
+	Fields      map[string]interface{} `yaml:"fields"`
+	Internal    string                 `yaml:"-"`
+}
+
+func TestDiff(t *testing.T) {
+	owner := "team-a"
+	otherOwner := "team-b"
+
+	tests := []struct {
+		name     string
+		old, new diffSpec
+		expected []Change
+	}{
+		{
+			name:     "equal values",
+			old:      diffSpec{Description: "a", Owner: &owner, Links: []diffLink{{Name: "docs", URL: "u"}}},
+			new:      diffSpec{Description: "a", Owner: &owner, Links: []diffLink{{Name: "docs", URL: "u"}}},
+			expected: nil,
+		},
+		{
+			name:     "nil and empty collections are equal",
+			old:      diffSpec{Links: nil, Fields: nil},
+			new:      diffSpec{Links: []diffLink{}, Fields: map[string]interface{}{}},
+			expected: nil,
+		},
+		{
+			name: "changed fields are named after their yaml tags",
+			old:  diffSpec{Description: "a", Owner: &owner, Internal: "x"},
+			new:  diffSpec{Description: "b", Owner: &otherOwner, Internal: "y"},
+			expected: []Change{
+				{Path: "spec.description", Old: "a", New: "b"},
+				{Path: "spec.ownerId", Old: "team-a", New: "team-b"},
+			},
+		},
+		{
+			name: "nil pointers are absent",
+			old:  diffSpec{Owner: &owner},
+			new:  diffSpec{},
+			expected: []Change{
+				{Path: "spec.ownerId", Old: "team-a", New: nil},
+			},
+		},
+		{
+			name: "slices are compared by index",
+			old:  diffSpec{Links: []diffLink{{Name: "docs", URL: "u1"}}},
+			new:  diffSpec{Links: []diffLink{{Name: "docs", URL: "u2"}, {Name: "chat", URL: "u3"}}},
+			expected: []Change{
+				{Path: "spec.links[0].url", Old: "u1", New: "u2"},
+				{Path: "spec.links[1]", Old: nil, New: diffLink{Name: "chat", URL: "u3"}},
+			},
+		},
+		{
+			name: "maps are compared by key",
+			old:  diffSpec{Fields: map[string]interface{}{"tier": 1, "lifecycle": "Active"}},
+			new:  diffSpec{Fields: map[string]interface{}{"tier": 2, "language": "go"}},
+			expected: []Change{
+				{Path: "spec.fields[language]", Old: nil, New: "go"},
+				{Path: "spec.fields[lifecycle]", Old: "Active", New: nil},
+				{Path: "spec.fields[tier]", Old: 1, New: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Diff("spec", tt.old, tt.new))
+		})
+	}
+}
+
+func TestDiffUnlessEqual(t *testing.T) {
+	assert.Nil(t, DiffUnlessEqual("labels", []string{"a", "b"}, []string{"b", "a"}, true))
+	assert.Equal(t,
+		[]Change{{Path: "labels[0]", Old: "a", New: "b"}, {Path: "labels[1]", Old: "b", New: "a"}},
+		DiffUnlessEqual("labels", []string{"a", "b"}, []string{"b", "a"}, false),
+	)
+	assert.Equal(t,
+		[]Change{{Path: "labels", Old: []string{"a"}, New: []string{"a"}}},
+		DiffUnlessEqual("labels", []string{"a"}, []string{"a"}, false),
+		"a difference the structural diff misses is reported on the whole value",
+	)
+}
+
+func TestHasChangeAndWithout(t *testing.T) {
+	changes := []Change{
+		{Path: "spec.links[0].url"},
+		{Path: "spec.linksCount"},
+		{Path: "spec.description"},
+	}
+
+	assert.True(t, HasChange(changes, "spec.links"))
+	assert.True(t, HasChange(changes, "spec"))
+	assert.False(t, HasChange(changes, "spec.name"))
+	assert.Equal(t, []Change{{Path: "spec.linksCount"}}, Without(changes, "spec.links", "spec.description"))
+}
+
+func TestChangeString(t *testing.T) {
+	assert.Equal(t, `spec.description: "a" => "b"`, Change{Path: "spec.description", Old: "a", New: "b"}.String())
+	assert.Equal(t, `spec.fields[tier]: <absent> => 2`, Change{Path: "spec.fields[tier]", New: 2}.String())
+	assert.Equal(t, `spec.labels: [a, b] => []`, Change{Path: "spec.labels", Old: []string{"a", "b"}, New: []string{}}.String())
+}
+
+func TestDetectChanges(t *testing.T) {
+	stateMap := map[string]*testStruct{
+		"kept":    {ID: "1", Value: "a"},
+		"changed": {ID: "2", Value: "b"},
+	}
+	configMap := map[string]*testStruct{
+		"kept":    {Value: "a"},
+		"changed": {Value: "c"},
+	}
+
+	diff := func(state, conf *testStruct) []Change {
+		return Diff("value", state.Value, conf.Value)
+	}
+	_, updated, _, unchanged, changes := DetectChanges(stateMap, configMap, func(state, conf *testStruct) { conf.ID = state.ID }, diff)
+
+	assert.Equal(t, map[string]*testStruct{"changed": {ID: "2", Value: "c"}}, updated)
+	assert.Equal(t, map[string]*testStruct{"kept": {ID: "1", Value: "a"}}, unchanged)
+	assert.Equal(t, map[string][]Change{"changed": {{Path: "value", Old: "b", New: "c"}}}, changes)
+}
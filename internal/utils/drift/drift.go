@@ -1,26 +1,50 @@
 package drift
 
+import (
+	"fmt"
+	"sort"
+)
+
 func Detect[T any](
 	stateMap, configMap map[string]*T,
 	fromStateToConfig func(state *T, conf *T),
 	isEqual func(*T, *T) bool,
 ) (created, updated, deleted, unchanged map[string]*T) {
+	created, updated, deleted, unchanged, _ = DetectChanges(stateMap, configMap, fromStateToConfig, func(state, conf *T) []Change {
+		if isEqual(state, conf) {
+			return nil
+		}
+		return []Change{{Old: state, New: conf}}
+	})
+
+	return created, updated, deleted, unchanged
+}
+
+// DetectChanges is Detect reporting the changes diff finds between the state and the configuration of
+// each updated item, indexed by key. Items without changes are unchanged.
+func DetectChanges[T any](
+	stateMap, configMap map[string]*T,
+	fromStateToConfig func(state *T, conf *T),
+	diff func(state, conf *T) []Change,
+) (created, updated, deleted, unchanged map[string]*T, changes map[string][]Change) {
 	createdList := make(map[string]*T)
 	updatedList := make(map[string]*T)
 	deletedList := make(map[string]*T)
 	unchangedList := make(map[string]*T)
+	changesList := make(map[string][]Change)
 
-	processStateItems(stateMap, configMap, fromStateToConfig, isEqual, updatedList, deletedList, unchangedList)
+	processStateItems(stateMap, configMap, fromStateToConfig, diff, updatedList, deletedList, unchangedList, changesList)
 	processConfigItems(stateMap, configMap, createdList)
 
-	return createdList, updatedList, deletedList, unchangedList
+	return createdList, updatedList, deletedList, unchangedList, changesList
 }
 
 func processStateItems[T any](
 	stateMap, configMap map[string]*T,
 	fromStateToConfig func(state *T, conf *T),
-	isEqual func(*T, *T) bool,
+	diff func(state, conf *T) []Change,
 	updatedList, deletedList, unchangedList map[string]*T,
+	changesList map[string][]Change,
 ) {
 	for key, stateItem := range stateMap {
 		configItem, found := configMap[key]
@@ -30,12 +54,14 @@ func processStateItems[T any](
 		}
 
 		fromStateToConfig(stateItem, configItem)
-		if isEqual(stateItem, configItem) {
+		changes := diff(stateItem, configItem)
+		if len(changes) == 0 {
 			unchangedList[key] = configItem
 			continue
 		}
 
 		updatedList[key] = configItem
+		changesList[key] = changes
 	}
 }
 
@@ -46,3 +72,80 @@ func processConfigItems[T any](stateMap, configMap map[string]*T, createdList ma
 		}
 	}
 }
+
+// Rename re-keys the state items that configuration items declare as renamed, so that Detect reports
+// an update of the existing object instead of a deletion and a creation. A configuration item missing
+// from the state matches the state item named after one of its previous names, or holding its ID when
+// the configuration pins one. getID may be nil. It returns the new keys indexed by previous key.
+func Rename[T any](
+	stateMap, configMap map[string]*T,
+	previousNames func(*T) []string,
+	getID func(*T) string,
+) (map[string]string, error) {
+	moved := make(map[string]string)
+	for _, key := range sortedKeys(configMap) {
+		if _, found := stateMap[key]; found {
+			continue
+		}
+
+		previousKey, found := findPreviousKey(stateMap, configMap[key], previousNames, getID)
+		if !found {
+			continue
+		}
+		if _, configured := configMap[previousKey]; configured {
+			return nil, fmt.Errorf("%s is renamed to %s but is still part of the configuration", previousKey, key)
+		}
+		if other, claimed := moved[previousKey]; claimed {
+			return nil, fmt.Errorf("%s is renamed to both %s and %s", previousKey, other, key)
+		}
+
+		moved[previousKey] = key
+	}
+
+	for previousKey, key := range moved {
+		stateMap[key] = stateMap[previousKey]
+		delete(stateMap, previousKey)
+	}
+
+	return moved, nil
+}
+
+func findPreviousKey[T any](
+	stateMap map[string]*T,
+	configItem *T,
+	previousNames func(*T) []string,
+	getID func(*T) string,
+) (string, bool) {
+	for _, previousName := range previousNames(configItem) {
+		if _, found := stateMap[previousName]; found {
+			return previousName, true
+		}
+	}
+
+	if getID == nil {
+		return "", false
+	}
+
+	id := getID(configItem)
+	if id == "" {
+		return "", false
+	}
+
+	for _, key := range sortedKeys(stateMap) {
+		if getID(stateMap[key]) == id {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+func sortedKeys[T any](m map[string]*T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -119,3 +119,89 @@ func matchElements(t *testing.T, mapName string, expected, actual map[string]*te
 		assert.Equal(t, expectedValue, actualValue, "expected value for key %s to match in %s", key, mapName)
 	}
 }
+
+type renamedStruct struct {
+	ID            string
+	PreviousNames []string
+}
+
+func previousNames(r *renamedStruct) []string {
+	return r.PreviousNames
+}
+
+func getID(r *renamedStruct) string {
+	return r.ID
+}
+
+func TestRename(t *testing.T) {
+	tests := []struct {
+		name          string
+		stateMap      map[string]*renamedStruct
+		configMap     map[string]*renamedStruct
+		expectedMoved map[string]string
+		expectedState []string
+		expectedError string
+	}{
+		{
+			name:          "no renames",
+			stateMap:      map[string]*renamedStruct{"a": {ID: "1"}},
+			configMap:     map[string]*renamedStruct{"a": {}},
+			expectedMoved: map[string]string{},
+			expectedState: []string{"a"},
+		},
+		{
+			name:          "renamed by previous name",
+			stateMap:      map[string]*renamedStruct{"a": {ID: "1"}, "c": {ID: "3"}},
+			configMap:     map[string]*renamedStruct{"b": {PreviousNames: []string{"x", "a"}}, "c": {}},
+			expectedMoved: map[string]string{"a": "b"},
+			expectedState: []string{"b", "c"},
+		},
+		{
+			name:          "renamed by pinned id",
+			stateMap:      map[string]*renamedStruct{"a": {ID: "1"}},
+			configMap:     map[string]*renamedStruct{"b": {ID: "1"}},
+			expectedMoved: map[string]string{"a": "b"},
+			expectedState: []string{"b"},
+		},
+		{
+			name:          "previous name not in state is a creation",
+			stateMap:      map[string]*renamedStruct{},
+			configMap:     map[string]*renamedStruct{"b": {PreviousNames: []string{"a"}}},
+			expectedMoved: map[string]string{},
+			expectedState: []string{},
+		},
+		{
+			name:          "already renamed",
+			stateMap:      map[string]*renamedStruct{"b": {ID: "1"}},
+			configMap:     map[string]*renamedStruct{"b": {PreviousNames: []string{"a"}}},
+			expectedMoved: map[string]string{},
+			expectedState: []string{"b"},
+		},
+		{
+			name:          "previous name still configured",
+			stateMap:      map[string]*renamedStruct{"a": {ID: "1"}},
+			configMap:     map[string]*renamedStruct{"a": {}, "b": {PreviousNames: []string{"a"}}},
+			expectedError: "a is renamed to b but is still part of the configuration",
+		},
+		{
+			name:          "previous name claimed twice",
+			stateMap:      map[string]*renamedStruct{"a": {ID: "1"}},
+			configMap:     map[string]*renamedStruct{"b": {PreviousNames: []string{"a"}}, "c": {PreviousNames: []string{"a"}}},
+			expectedError: "a is renamed to both b and c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moved, err := Rename(tt.stateMap, tt.configMap, previousNames, getID)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMoved, moved)
+			assert.ElementsMatch(t, tt.expectedState, sortedKeys(tt.stateMap))
+		})
+	}
+}
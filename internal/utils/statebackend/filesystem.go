@@ -0,0 +1,78 @@
+package statebackend
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+const filePermission = 0644
+
+// FilesystemBackend stores state files relative to the working directory.
+type FilesystemBackend struct{}
+
+func NewFilesystemBackend() *FilesystemBackend {
+	return &FilesystemBackend{}
+}
+
+func (b *FilesystemBackend) List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.FromSlash(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			paths = append(paths, path.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (b *FilesystemBackend) Read(filePath string) ([]byte, error) {
+	return os.ReadFile(filepath.FromSlash(filePath))
+}
+
+func (b *FilesystemBackend) Write(filePath string, data []byte) error {
+	localPath := filepath.FromSlash(filePath)
+	if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	return os.WriteFile(localPath, data, filePermission)
+}
+
+func (b *FilesystemBackend) WriteIfNotExists(filePath string, data []byte) error {
+	localPath := filepath.FromSlash(filePath)
+	if err := os.MkdirAll(filepath.Dir(localPath), os.ModePerm); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(localPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, filePermission)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(localPath)
+		return err
+	}
+
+	return file.Close()
+}
+
+func (b *FilesystemBackend) Delete(filePath string) error {
+	if err := os.Remove(filepath.FromSlash(filePath)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
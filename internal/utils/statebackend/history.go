@@ -0,0 +1,267 @@
+package statebackend
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotInfoSuffix  = ".json"
+	snapshotFilesSuffix = ".state.gz"
+)
+
+// SnapshotInfo describes a numbered snapshot of the state.
+type SnapshotInfo struct {
+	Version   int       `json:"version"`
+	Operation string    `json:"operation"`
+	Command   string    `json:"command,omitempty"`
+	Who       string    `json:"who"`
+	Created   time.Time `json:"created"`
+	GitSHA    string    `json:"gitSha,omitempty"`
+}
+
+// SaveSnapshot stores files, keyed by their path, as the next snapshot under historyDir. It returns nil
+// without storing anything when files are identical to the latest snapshot. When limit is positive, the
+// oldest snapshots are deleted so that at most limit are kept.
+func SaveSnapshot(backend Backend, historyDir, operation string, files map[string][]byte, limit int) (*SnapshotInfo, error) {
+	versions, listErr := listSnapshotVersions(backend, historyDir)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	version := 1
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		_, latestFiles, readErr := ReadSnapshot(backend, historyDir, latest)
+		if readErr != nil {
+			return nil, readErr
+		}
+		if sameFiles(latestFiles, files) {
+			return nil, nil
+		}
+		version = latest + 1
+	}
+
+	info := &SnapshotInfo{
+		Version:   version,
+		Operation: operation,
+		Command:   strings.Join(os.Args[1:], " "),
+		Who:       currentWho(),
+		Created:   time.Now().UTC(),
+		GitSHA:    currentGitSHA(),
+	}
+
+	filesData, encodeErr := encodeSnapshotFiles(files)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+	if err := backend.Write(snapshotPath(historyDir, version, snapshotFilesSuffix), filesData); err != nil {
+		return nil, err
+	}
+
+	// The metadata is written last: a snapshot without it is incomplete and ignored.
+	infoData, marshalErr := json.Marshal(info)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	if err := backend.WriteIfNotExists(snapshotPath(historyDir, version, snapshotInfoSuffix), infoData); err != nil {
+		return nil, fmt.Errorf("failed to record state version %d: %w", version, err)
+	}
+
+	if limit > 0 {
+		if err := pruneSnapshots(backend, historyDir, append(versions, version), limit); err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
+}
+
+// pruneSnapshots deletes the oldest of versions until at most limit are left. The metadata is deleted
+// first, so that a snapshot whose files could not be deleted is ignored rather than listed incomplete.
+func pruneSnapshots(backend Backend, historyDir string, versions []int, limit int) error {
+	if len(versions) <= limit {
+		return nil
+	}
+
+	for _, version := range versions[:len(versions)-limit] {
+		if err := backend.Delete(snapshotPath(historyDir, version, snapshotInfoSuffix)); err != nil {
+			return fmt.Errorf("failed to delete state version %d: %w", version, err)
+		}
+		if err := backend.Delete(snapshotPath(historyDir, version, snapshotFilesSuffix)); err != nil {
+			return fmt.Errorf("failed to delete state version %d: %w", version, err)
+		}
+	}
+
+	return nil
+}
+
+// listSnapshotVersions returns the versions of the snapshots stored under historyDir, oldest first,
+// from their paths only.
+func listSnapshotVersions(backend Backend, historyDir string) ([]int, error) {
+	paths, listErr := backend.List(historyDir)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	versions := make([]int, 0, len(paths))
+	for _, filePath := range paths {
+		if version, isSnapshot := parseSnapshotVersion(filePath); isSnapshot {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+// ListSnapshots returns the snapshots stored under historyDir, oldest first.
+func ListSnapshots(backend Backend, historyDir string) ([]SnapshotInfo, error) {
+	versions, listErr := listSnapshotVersions(backend, historyDir)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	snapshots := make([]SnapshotInfo, 0, len(versions))
+	for _, version := range versions {
+		data, readErr := backend.Read(snapshotPath(historyDir, version, snapshotInfoSuffix))
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		var info SnapshotInfo
+		if err := json.Unmarshal(data, &info); err != nil {
+			return nil, fmt.Errorf("failed to decode state version %d: %w", version, err)
+		}
+		snapshots = append(snapshots, info)
+	}
+
+	return snapshots, nil
+}
+
+// ReadSnapshot returns the metadata and the files, keyed by their path, of the snapshot version.
+func ReadSnapshot(backend Backend, historyDir string, version int) (*SnapshotInfo, map[string][]byte, error) {
+	infoData, infoErr := backend.Read(snapshotPath(historyDir, version, snapshotInfoSuffix))
+	if infoErr != nil {
+		if isNotExist(infoErr) {
+			return nil, nil, fmt.Errorf("state version %d not found", version)
+		}
+		return nil, nil, infoErr
+	}
+
+	var info SnapshotInfo
+	if err := json.Unmarshal(infoData, &info); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode state version %d: %w", version, err)
+	}
+
+	filesData, filesErr := backend.Read(snapshotPath(historyDir, version, snapshotFilesSuffix))
+	if filesErr != nil {
+		return nil, nil, filesErr
+	}
+
+	files, decodeErr := decodeSnapshotFiles(filesData)
+	if decodeErr != nil {
+		return nil, nil, fmt.Errorf("failed to decode state version %d: %w", version, decodeErr)
+	}
+
+	return &info, files, nil
+}
+
+func snapshotPath(historyDir string, version int, suffix string) string {
+	return path.Join(historyDir, fmt.Sprintf("%06d%s", version, suffix))
+}
+
+func parseSnapshotVersion(filePath string) (int, bool) {
+	name := path.Base(filePath)
+	if !strings.HasSuffix(name, snapshotInfoSuffix) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(strings.TrimSuffix(name, snapshotInfoSuffix))
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
+
+func encodeSnapshotFiles(files map[string][]byte) ([]byte, error) {
+	contents := make(map[string]string, len(files))
+	for filePath, data := range files {
+		contents[filePath] = string(data)
+	}
+
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if err := json.NewEncoder(writer).Encode(contents); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func decodeSnapshotFiles(data []byte) (map[string][]byte, error) {
+	reader, readerErr := gzip.NewReader(bytes.NewReader(data))
+	if readerErr != nil {
+		return nil, readerErr
+	}
+	defer reader.Close()
+
+	decompressed, readErr := io.ReadAll(reader)
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	var contents map[string]string
+	if err := json.Unmarshal(decompressed, &contents); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(contents))
+	for filePath, content := range contents {
+		files[filePath] = []byte(content)
+	}
+
+	return files, nil
+}
+
+func sameFiles(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for filePath, data := range a {
+		other, exists := b[filePath]
+		if !exists || !bytes.Equal(data, other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// currentGitSHA returns the commit checked out in the working directory, preferring the commit
+// reported by GitHub Actions, or an empty string outside of a git repository.
+func currentGitSHA() string {
+	if sha := os.Getenv("GITHUB_SHA"); sha != "" {
+		return sha
+	}
+
+	output, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(output))
+}
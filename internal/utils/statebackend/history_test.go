@@ -0,0 +1,83 @@
+package statebackend_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/statebackend/s3test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshots(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backends := map[string]statebackend.Backend{
+		"filesystem": statebackend.NewFilesystemBackend(),
+		"s3":         newS3Backend(t, server, ""),
+	}
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			historyDir := filepath.ToSlash(filepath.Join(t.TempDir(), ".history"))
+			first := map[string][]byte{".state/metric/a.yaml": []byte("a")}
+			second := map[string][]byte{".state/metric/a.yaml": []byte("a"), ".state/metric/b.yaml": []byte("b")}
+
+			snapshots, err := statebackend.ListSnapshots(backend, historyDir)
+			require.NoError(t, err)
+			assert.Empty(t, snapshots)
+
+			info, err := statebackend.SaveSnapshot(backend, historyDir, "metric apply", first, 0)
+			require.NoError(t, err)
+			assert.Equal(t, 1, info.Version)
+			assert.Equal(t, "metric apply", info.Operation)
+			assert.NotEmpty(t, info.Who)
+
+			info, err = statebackend.SaveSnapshot(backend, historyDir, "metric apply", first, 0)
+			require.NoError(t, err)
+			assert.Nil(t, info, "an unchanged state is not recorded again")
+
+			info, err = statebackend.SaveSnapshot(backend, historyDir, "state import", second, 0)
+			require.NoError(t, err)
+			assert.Equal(t, 2, info.Version)
+
+			snapshots, err = statebackend.ListSnapshots(backend, historyDir)
+			require.NoError(t, err)
+			require.Len(t, snapshots, 2)
+			assert.Equal(t, []int{1, 2}, []int{snapshots[0].Version, snapshots[1].Version})
+			assert.Equal(t, "state import", snapshots[1].Operation)
+
+			info, files, err := statebackend.ReadSnapshot(backend, historyDir, 1)
+			require.NoError(t, err)
+			assert.Equal(t, 1, info.Version)
+			assert.Equal(t, first, files)
+
+			_, _, err = statebackend.ReadSnapshot(backend, historyDir, 3)
+			assert.EqualError(t, err, "state version 3 not found")
+		})
+	}
+}
+
+func TestSnapshotsRetention(t *testing.T) {
+	backend := statebackend.NewFilesystemBackend()
+	historyDir := filepath.ToSlash(filepath.Join(t.TempDir(), ".history"))
+
+	for _, content := range []string{"a", "b", "c", "d"} {
+		_, err := statebackend.SaveSnapshot(backend, historyDir, "metric apply", map[string][]byte{".state/metric/a.yaml": []byte(content)}, 2)
+		require.NoError(t, err)
+	}
+
+	snapshots, err := statebackend.ListSnapshots(backend, historyDir)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, []int{3, 4}, []int{snapshots[0].Version, snapshots[1].Version})
+
+	paths, err := backend.List(historyDir)
+	require.NoError(t, err)
+	assert.Len(t, paths, 4, "the files of the deleted versions are deleted too")
+
+	_, _, err = statebackend.ReadSnapshot(backend, historyDir, 1)
+	assert.EqualError(t, err, "state version 1 not found")
+}
@@ -0,0 +1,135 @@
+package statebackend
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"time"
+)
+
+const (
+	lockMinRetryInterval = 100 * time.Millisecond
+	lockMaxRetryInterval = 2 * time.Second
+)
+
+// LockInfo describes the holder of a state lock.
+type LockInfo struct {
+	ID        string    `json:"id"`
+	Operation string    `json:"operation"`
+	Who       string    `json:"who"`
+	Created   time.Time `json:"created"`
+}
+
+// LockError is returned when the state is locked by someone else.
+type LockError struct {
+	Info *LockInfo
+}
+
+func (e *LockError) Error() string {
+	return fmt.Sprintf(
+		"state is locked by %s since %s (operation: %s, lock ID: %s)",
+		e.Info.Who, e.Info.Created.Format(time.RFC3339), e.Info.Operation, e.Info.ID,
+	)
+}
+
+// Lock acquires the advisory lock stored at lockPath, retrying until timeout elapses.
+// A zero timeout makes a single attempt.
+func Lock(backend Backend, lockPath, operation string, timeout time.Duration) (*LockInfo, error) {
+	info, infoErr := newLockInfo(operation)
+	if infoErr != nil {
+		return nil, infoErr
+	}
+
+	data, encodeErr := json.Marshal(info)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+
+	deadline := time.Now().Add(timeout)
+	retryInterval := lockMinRetryInterval
+	for {
+		writeErr := backend.WriteIfNotExists(lockPath, data)
+		if writeErr == nil {
+			return info, nil
+		}
+		if !isExist(writeErr) {
+			return nil, fmt.Errorf("failed to acquire state lock: %w", writeErr)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			holder, readErr := ReadLock(backend, lockPath)
+			if readErr != nil || holder == nil {
+				return nil, fmt.Errorf("failed to acquire state lock: %w", writeErr)
+			}
+			return nil, &LockError{Info: holder}
+		}
+
+		time.Sleep(min(retryInterval, remaining))
+		retryInterval = min(retryInterval*2, lockMaxRetryInterval)
+	}
+}
+
+// Unlock releases the lock stored at lockPath if it is held with the given ID.
+func Unlock(backend Backend, lockPath, id string) error {
+	holder, readErr := ReadLock(backend, lockPath)
+	if readErr != nil {
+		return readErr
+	}
+	if holder == nil {
+		return fmt.Errorf("state is not locked")
+	}
+	if holder.ID != id {
+		return fmt.Errorf("lock ID %q does not match the lock held with ID %q", id, holder.ID)
+	}
+
+	return backend.Delete(lockPath)
+}
+
+// ReadLock returns the holder of the lock stored at lockPath, or nil when the state is not locked.
+func ReadLock(backend Backend, lockPath string) (*LockInfo, error) {
+	data, readErr := backend.Read(lockPath)
+	if readErr != nil {
+		if isNotExist(readErr) {
+			return nil, nil
+		}
+		return nil, readErr
+	}
+
+	var info LockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("failed to decode state lock %s: %w", lockPath, err)
+	}
+
+	return &info, nil
+}
+
+func newLockInfo(operation string) (*LockInfo, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	return &LockInfo{
+		ID:        hex.EncodeToString(id),
+		Operation: operation,
+		Who:       currentWho(),
+		Created:   time.Now().UTC(),
+	}, nil
+}
+
+// currentWho identifies the user running the command as user@host.
+func currentWho() string {
+	who := "unknown"
+	if currentUser, err := user.Current(); err == nil {
+		who = currentUser.Username
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		who = fmt.Sprintf("%s@%s", who, hostname)
+	}
+
+	return who
+}
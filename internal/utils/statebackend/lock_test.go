@@ -0,0 +1,80 @@
+package statebackend_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/statebackend/s3test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backends := map[string]statebackend.Backend{
+		"filesystem": statebackend.NewFilesystemBackend(),
+		"s3":         newS3Backend(t, server, ""),
+	}
+
+	for name, backend := range backends {
+		t.Run(name, func(t *testing.T) {
+			lockPath := filepath.ToSlash(filepath.Join(t.TempDir(), ".lock"))
+
+			lock, err := statebackend.Lock(backend, lockPath, "component apply", 0)
+			require.NoError(t, err)
+			assert.Equal(t, "component apply", lock.Operation)
+
+			holder, err := statebackend.ReadLock(backend, lockPath)
+			require.NoError(t, err)
+			assert.Equal(t, lock.ID, holder.ID)
+
+			_, err = statebackend.Lock(backend, lockPath, "metric apply", 0)
+			var lockErr *statebackend.LockError
+			require.ErrorAs(t, err, &lockErr)
+			assert.Equal(t, lock.ID, lockErr.Info.ID)
+			assert.Contains(t, err.Error(), lock.ID)
+
+			assert.Error(t, statebackend.Unlock(backend, lockPath, "another-id"))
+			require.NoError(t, statebackend.Unlock(backend, lockPath, lock.ID))
+			assert.Error(t, statebackend.Unlock(backend, lockPath, lock.ID), "the state is not locked anymore")
+
+			holder, err = statebackend.ReadLock(backend, lockPath)
+			require.NoError(t, err)
+			assert.Nil(t, holder)
+		})
+	}
+}
+
+func TestLock_WaitsForRelease(t *testing.T) {
+	backend := statebackend.NewFilesystemBackend()
+	lockPath := filepath.ToSlash(filepath.Join(t.TempDir(), ".lock"))
+
+	lock, err := statebackend.Lock(backend, lockPath, "component apply", 0)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		statebackend.Unlock(backend, lockPath, lock.ID)
+	}()
+
+	_, err = statebackend.Lock(backend, lockPath, "component bind", 50*time.Millisecond)
+	assert.Error(t, err, "the lock is still held when the timeout elapses")
+
+	next, err := statebackend.Lock(backend, lockPath, "component bind", 5*time.Second)
+	require.NoError(t, err)
+	assert.NotEqual(t, lock.ID, next.ID)
+}
+
+func TestLock_InvalidLockFile(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), ".lock")
+	require.NoError(t, os.WriteFile(lockPath, []byte("not json"), 0644))
+
+	_, err := statebackend.ReadLock(statebackend.NewFilesystemBackend(), filepath.ToSlash(lockPath))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,230 @@
+package statebackend
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sigv4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// S3Options configures the S3 backend. Endpoint is only needed for S3-compatible stores such as MinIO;
+// when empty the regional AWS endpoint is used.
+type S3Options struct {
+	Endpoint string
+	Bucket   string
+	Prefix   string
+	Region   string
+}
+
+// S3Backend stores state files as objects of an S3-compatible bucket, using path-style requests
+// signed with AWS Signature Version 4.
+type S3Backend struct {
+	client      *http.Client
+	credentials aws.CredentialsProvider
+	signer      *sigv4.Signer
+	endpoint    string
+	bucket      string
+	prefix      string
+	region      string
+}
+
+func NewS3Backend(options S3Options, credentials aws.CredentialsProvider, client *http.Client) (*S3Backend, error) {
+	if options.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required")
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	endpoint := options.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", options.Region)
+	}
+
+	return &S3Backend{
+		client:      client,
+		credentials: credentials,
+		signer: sigv4.NewSigner(func(signer *sigv4.SignerOptions) {
+			// S3 expects the object key to be escaped only once
+			signer.DisableURIPathEscaping = true
+		}),
+		endpoint: strings.TrimRight(endpoint, "/"),
+		bucket:   options.Bucket,
+		prefix:   strings.Trim(options.Prefix, "/"),
+		region:   options.Region,
+	}, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func (b *S3Backend) List(dir string) ([]string, error) {
+	keyPrefix := b.key(dir) + "/"
+
+	paths := make([]string, 0)
+	continuationToken := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", keyPrefix)
+		query.Set("delimiter", "/")
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		body, err := b.do(http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+
+		var result listBucketResult
+		if err := xml.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode listing of %s: %w", dir, err)
+		}
+
+		for _, content := range result.Contents {
+			paths = append(paths, path.Join(dir, strings.TrimPrefix(content.Key, keyPrefix)))
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func (b *S3Backend) Read(filePath string) ([]byte, error) {
+	body, err := b.do(http.MethodGet, b.key(filePath), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	return body, nil
+}
+
+func (b *S3Backend) Write(filePath string, data []byte) error {
+	if _, err := b.do(http.MethodPut, b.key(filePath), nil, data, nil); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// WriteIfNotExists relies on S3 conditional writes, also supported by MinIO.
+func (b *S3Backend) WriteIfNotExists(filePath string, data []byte) error {
+	headers := http.Header{"If-None-Match": []string{"*"}}
+	if _, err := b.do(http.MethodPut, b.key(filePath), nil, data, headers); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (b *S3Backend) Delete(filePath string) error {
+	if _, err := b.do(http.MethodDelete, b.key(filePath), nil, nil, nil); err != nil && !isNotExist(err) {
+		return fmt.Errorf("failed to delete %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+func (b *S3Backend) key(filePath string) string {
+	return strings.TrimPrefix(path.Join(b.prefix, path.Clean(filePath)), "/")
+}
+
+func (b *S3Backend) do(method, key string, query url.Values, data []byte, headers http.Header) ([]byte, error) {
+	ctx := context.Background()
+
+	requestURL := fmt.Sprintf("%s/%s", b.endpoint, b.bucket)
+	if key != "" {
+		requestURL = fmt.Sprintf("%s/%s", requestURL, escapeKey(key))
+	}
+	if len(query) > 0 {
+		requestURL = fmt.Sprintf("%s?%s", requestURL, query.Encode())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range headers {
+		request.Header[name] = values
+	}
+
+	payloadHash := sha256.Sum256(data)
+	request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	credentials, err := b.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+	if credentials.SessionToken != "" {
+		request.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	if err := b.signer.SignHTTP(ctx, credentials, request, hex.EncodeToString(payloadHash[:]), "s3", b.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	response, err := b.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s %s: %w", method, key, fs.ErrNotExist)
+	}
+	// A conditional write racing another one on the same key is answered with 409 ConditionalRequestConflict
+	if response.StatusCode == http.StatusPreconditionFailed || (response.StatusCode == http.StatusConflict && headers.Get("If-None-Match") != "") {
+		return nil, fmt.Errorf("%s %s: %w", method, key, fs.ErrExist)
+	}
+	if response.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s: unexpected status %d: %s", method, key, response.StatusCode, body)
+	}
+
+	return body, nil
+}
+
+// escapeKey percent-encodes an object key as SigV4 expects, leaving only unreserved characters and separators.
+func escapeKey(key string) string {
+	var escaped strings.Builder
+	for _, c := range []byte(key) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			escaped.WriteByte(c)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+
+	return escaped.String()
+}
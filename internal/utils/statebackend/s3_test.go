@@ -0,0 +1,102 @@
+package statebackend_test
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/statebackend/s3test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newS3Backend(t *testing.T, server *s3test.Server, prefix string) *statebackend.S3Backend {
+	backend, err := statebackend.NewS3Backend(
+		statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Prefix: prefix, Region: "eu-west-1"},
+		credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		server.Client(),
+	)
+	require.NoError(t, err)
+
+	return backend
+}
+
+func TestS3Backend_ReadWriteDelete(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+	backend := newS3Backend(t, server, "teams/platform")
+
+	require.NoError(t, backend.Write(".state/component/service-a.yaml", []byte("kind: Component\n")))
+	assert.Equal(t, map[string]string{"state/teams/platform/.state/component/service-a.yaml": "kind: Component\n"}, server.Objects())
+
+	content, err := backend.Read(".state/component/service-a.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Component\n", string(content))
+
+	require.NoError(t, backend.Delete(".state/component/service-a.yaml"))
+	require.NoError(t, backend.Delete(".state/component/service-a.yaml"), "deleting a missing file is not an error")
+
+	_, err = backend.Read(".state/component/service-a.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestS3Backend_List(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+	server.Put("state", ".state/component/service-b.yaml", "")
+	server.Put("state", ".state/component/service-a.yaml", "")
+	server.Put("state", ".state/component/nested/service-c.yaml", "")
+	server.Put("state", ".state/metric/metric-a.yaml", "")
+	server.Put("other", ".state/component/service-d.yaml", "")
+	backend := newS3Backend(t, server, "")
+
+	paths, err := backend.List(".state/component")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{".state/component/service-a.yaml", ".state/component/service-b.yaml"}, paths)
+}
+
+func TestS3Backend_WriteIfNotExists(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		expected   error
+	}{
+		{name: "written", statusCode: http.StatusOK},
+		{name: "already exists", statusCode: http.StatusPreconditionFailed, expected: fs.ErrExist},
+		{name: "racing another conditional write", statusCode: http.StatusConflict, expected: fs.ErrExist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "*", r.Header.Get("If-None-Match"))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+			backend, err := statebackend.NewS3Backend(
+				statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Region: "eu-west-1"},
+				credentials.NewStaticCredentialsProvider("access", "secret", ""),
+				server.Client(),
+			)
+			require.NoError(t, err)
+
+			err = backend.WriteIfNotExists(".state/.lock", []byte("lock"))
+
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestNewS3Backend_RequiresBucket(t *testing.T) {
+	_, err := statebackend.NewS3Backend(statebackend.S3Options{}, credentials.NewStaticCredentialsProvider("access", "secret", ""), nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+// Package s3test provides an in-memory stand-in for an S3-compatible object store, such as MinIO,
+// implementing the subset of the API used by the S3 state backend.
+package s3test
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type Server struct {
+	*httptest.Server
+
+	mutex   sync.Mutex
+	objects map[string][]byte
+}
+
+// NewServer starts a stand-in server. Objects are addressed path-style: /<bucket>/<key>.
+func NewServer() *Server {
+	server := &Server{objects: make(map[string][]byte)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// Objects returns a copy of the stored objects keyed by "<bucket>/<key>".
+func (s *Server) Objects() map[string]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	objects := make(map[string]string, len(s.objects))
+	for key, value := range s.objects {
+		objects[key] = string(value)
+	}
+
+	return objects
+}
+
+// Put stores an object directly, bypassing the HTTP API.
+func (s *Server) Put(bucket, key, content string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.objects[bucket+"/"+key] = []byte(content)
+}
+
+type listBucketResult struct {
+	XMLName     xml.Name `xml:"ListBucketResult"`
+	Name        string   `xml:"Name"`
+	Prefix      string   `xml:"Prefix"`
+	IsTruncated bool     `xml:"IsTruncated"`
+	Contents    []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		http.Error(w, "missing signature", http.StatusForbidden)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	objectKey := bucket + "/" + key
+
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		s.list(w, bucket, r.URL.Query().Get("prefix"), r.URL.Query().Get("delimiter"))
+	case r.Method == http.MethodGet:
+		content, exists := s.objects[objectKey]
+		if !exists {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		w.Write(content)
+	case r.Method == http.MethodPut:
+		content, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, exists := s.objects[objectKey]; exists && r.Header.Get("If-None-Match") == "*" {
+			http.Error(w, "PreconditionFailed", http.StatusPreconditionFailed)
+			return
+		}
+		s.objects[objectKey] = content
+	case r.Method == http.MethodDelete:
+		delete(s.objects, objectKey)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unsupported operation", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, bucket, prefix, delimiter string) {
+	result := listBucketResult{Name: bucket, Prefix: prefix}
+
+	keys := make([]string, 0)
+	for objectKey := range s.objects {
+		key, found := strings.CutPrefix(objectKey, bucket+"/")
+		if !found || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" && strings.Contains(strings.TrimPrefix(key, prefix), delimiter) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		result.Contents = append(result.Contents, struct {
+			Key string `xml:"Key"`
+		}{Key: key})
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,102 @@
+package statebackend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+const (
+	FilesystemBackendType = "local"
+	S3BackendType         = "s3"
+
+	// DefaultHistoryLimit is the number of state versions kept when STATE_HISTORY_LIMIT is not set
+	DefaultHistoryLimit = 100
+)
+
+// Backend stores state files. Paths are slash separated and relative to the state root,
+// e.g. ".state/component/my-service.yaml".
+type Backend interface {
+	// List returns the paths of the files stored directly in dir, sorted by name.
+	List(dir string) ([]string, error)
+	// Read returns the content of the file at path. It returns an error wrapping fs.ErrNotExist
+	// when the file does not exist.
+	Read(path string) ([]byte, error)
+	Write(path string, data []byte) error
+	// WriteIfNotExists writes the file only if it does not exist yet, otherwise it returns an error
+	// wrapping fs.ErrExist. It is used to acquire the state lock.
+	WriteIfNotExists(path string, data []byte) error
+	// Delete removes the file at path. Deleting a missing file is not an error.
+	Delete(path string) error
+}
+
+// Config is the subset of the config service used to select the backend.
+type Config interface {
+	Get(envVar string) string
+	GetAWSRegion() string
+}
+
+// NewStateBackend returns the backend configured through the STATE_BACKEND environment variable,
+// defaulting to the local filesystem.
+func NewStateBackend(cfg Config) (Backend, error) {
+	switch backendType := cfg.Get("STATE_BACKEND"); backendType {
+	case "", FilesystemBackendType:
+		return NewFilesystemBackend(), nil
+	case S3BackendType:
+		return newS3BackendFromConfig(cfg)
+	default:
+		return nil, fmt.Errorf("unknown state backend %q, expected %s or %s", backendType, FilesystemBackendType, S3BackendType)
+	}
+}
+
+// HistoryLimit returns the number of state versions to keep, configured through the STATE_HISTORY_LIMIT
+// environment variable. 0 keeps all of them.
+func HistoryLimit(cfg Config) (int, error) {
+	value := cfg.Get("STATE_HISTORY_LIMIT")
+	if value == "" {
+		return DefaultHistoryLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("invalid STATE_HISTORY_LIMIT %q, expected a number of versions", value)
+	}
+
+	return limit, nil
+}
+
+func newS3BackendFromConfig(cfg Config) (Backend, error) {
+	bucket := cfg.Get("STATE_S3_BUCKET")
+	if bucket == "" {
+		return nil, fmt.Errorf("STATE_S3_BUCKET is required for the %s state backend", S3BackendType)
+	}
+
+	region := cfg.Get("STATE_S3_REGION")
+	if region == "" {
+		region = cfg.GetAWSRegion()
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	return NewS3Backend(S3Options{
+		Endpoint: cfg.Get("STATE_S3_ENDPOINT"),
+		Bucket:   bucket,
+		Prefix:   cfg.Get("STATE_S3_PREFIX"),
+		Region:   region,
+	}, awsCfg.Credentials, nil)
+}
+
+func isNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+func isExist(err error) bool {
+	return errors.Is(err, fs.ErrExist)
+}
@@ -0,0 +1,125 @@
+package statebackend_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	configservice "github.com/motain/of-catalog/internal/services/configservice/mocks"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStateBackend(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expectedType  interface{}
+		expectedError string
+	}{
+		{
+			name:         "defaults to the filesystem",
+			env:          map[string]string{},
+			expectedType: &statebackend.FilesystemBackend{},
+		},
+		{
+			name:         "filesystem",
+			env:          map[string]string{"STATE_BACKEND": "local"},
+			expectedType: &statebackend.FilesystemBackend{},
+		},
+		{
+			name:          "s3 without bucket",
+			env:           map[string]string{"STATE_BACKEND": "s3"},
+			expectedError: "STATE_S3_BUCKET is required",
+		},
+		{
+			name:          "unknown backend",
+			env:           map[string]string{"STATE_BACKEND": "consul"},
+			expectedError: `unknown state backend "consul"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().Get(gomock.Any()).DoAndReturn(func(envVar string) string { return tt.env[envVar] }).AnyTimes()
+			mockConfig.EXPECT().GetAWSRegion().Return("eu-west-1").AnyTimes()
+
+			backend, err := statebackend.NewStateBackend(mockConfig)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expectedType, backend)
+		})
+	}
+}
+
+func TestFilesystemBackend(t *testing.T) {
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer os.Chdir(workingDir)
+	backend := statebackend.NewFilesystemBackend()
+
+	paths, err := backend.List(".state/component")
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+
+	require.NoError(t, backend.Write(".state/component/service-b.yaml", []byte("b")))
+	require.NoError(t, backend.Write(".state/component/service-a.yaml", []byte("a")))
+
+	paths, err = backend.List(".state/component")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".state/component/service-a.yaml", ".state/component/service-b.yaml"}, paths)
+
+	content, err := backend.Read(".state/component/service-a.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "a", string(content))
+
+	require.NoError(t, backend.Delete(".state/component/service-a.yaml"))
+	require.NoError(t, backend.Delete(".state/component/service-a.yaml"))
+	paths, err = backend.List(".state/component")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".state/component/service-b.yaml"}, paths)
+}
+
+func TestHistoryLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      int
+		expectedError string
+	}{
+		{name: "default", value: "", expected: statebackend.DefaultHistoryLimit},
+		{name: "configured", value: "20", expected: 20},
+		{name: "unlimited", value: "0", expected: 0},
+		{name: "invalid", value: "all", expectedError: `invalid STATE_HISTORY_LIMIT "all"`},
+		{name: "negative", value: "-1", expectedError: `invalid STATE_HISTORY_LIMIT "-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockConfig := configservice.NewMockConfigServiceInterface(ctrl)
+			mockConfig.EXPECT().Get("STATE_HISTORY_LIMIT").Return(tt.value)
+
+			limit, err := statebackend.HistoryLimit(mockConfig)
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
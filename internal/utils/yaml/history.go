@@ -0,0 +1,147 @@
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+)
+
+// stateDirectories lists the locations holding state files, the top level one holding legacy state files
+var stateDirectories = []string{StateLocation, MetricStateLocation, ScorecardStateLocation, ComponentStateLocation}
+
+// stateHistoryLimit is the number of state versions kept, the oldest ones being deleted
+var stateHistoryLimit = statebackend.DefaultHistoryLimit
+
+// SetStateHistoryLimit sets the number of state versions kept, 0 keeping all of them
+func SetStateHistoryLimit(limit int) {
+	stateHistoryLimit = limit
+}
+
+// SnapshotState records the current state files as a new state version. It returns nil when the state
+// did not change since the latest version.
+func SnapshotState(operation string) (*statebackend.SnapshotInfo, error) {
+	files, readErr := readStateFiles()
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	return statebackend.SaveSnapshot(stateBackend, HistoryLocation, operation, files, stateHistoryLimit)
+}
+
+// snapshotChangedState records the current state files as a new state version when they differ from before
+func snapshotChangedState(operation string, before map[string][]byte) error {
+	files, readErr := readStateFiles()
+	if readErr != nil {
+		return readErr
+	}
+	if maps.EqualFunc(before, files, bytes.Equal) {
+		return nil
+	}
+
+	_, saveErr := statebackend.SaveSnapshot(stateBackend, HistoryLocation, operation, files, stateHistoryLimit)
+	return saveErr
+}
+
+// GetStateHistory returns the recorded state versions, oldest first
+func GetStateHistory() ([]statebackend.SnapshotInfo, error) {
+	return statebackend.ListSnapshots(stateBackend, HistoryLocation)
+}
+
+// ReadStateVersion returns the metadata and the state files, keyed by their path, of a recorded state version
+func ReadStateVersion(version int) (*statebackend.SnapshotInfo, map[string][]byte, error) {
+	return statebackend.ReadSnapshot(stateBackend, HistoryLocation, version)
+}
+
+// RestoreStateVersion replaces the state files with the ones recorded in the given state version
+func RestoreStateVersion(version int) error {
+	_, files, readErr := ReadStateVersion(version)
+	if readErr != nil {
+		return readErr
+	}
+
+	current, currentErr := readStateFiles()
+	if currentErr != nil {
+		return currentErr
+	}
+
+	for filePath, data := range files {
+		if err := stateBackend.Write(filePath, data); err != nil {
+			return fmt.Errorf("failed to write state file %s: %w", filePath, err)
+		}
+	}
+
+	for filePath := range current {
+		if _, restored := files[filePath]; restored {
+			continue
+		}
+		if err := stateBackend.Delete(filePath); err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// ParseStateFiles decodes the definitions of kind T found in state files, such as the ones of a state version
+func ParseStateFiles[T any](files map[string][]byte, getKey KeyExtractor[T]) (map[string]*T, error) {
+	tKind, kindErr := GetKindFromGeneric(fmt.Sprintf("%T", new(T)))
+	if kindErr != nil {
+		return nil, kindErr
+	}
+
+	definitions := make(map[string]*T)
+	for filePath, data := range files {
+		if !isKindStateFile(tKind, filePath) {
+			continue
+		}
+
+		decoded, decodeErr := decodeStateBytes[T](tKind, data)
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to parse state file %s: %w", filePath, decodeErr)
+		}
+		for _, definition := range decoded {
+			definitions[getKey(definition)] = definition
+		}
+	}
+
+	return definitions, nil
+}
+
+// isKindStateFile reports whether filePath holds state of kind tKind, either in the kind directory or in a legacy state file
+func isKindStateFile(tKind, filePath string) bool {
+	dir := path.Dir(filePath)
+	if dir == path.Join(StateLocation, tKind) {
+		return true
+	}
+
+	matched, _ := path.Match(getKindFileName(fmt.Sprintf("%s*", tKind)), path.Base(filePath))
+	return dir == StateLocation && matched
+}
+
+func readStateFiles() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, dir := range stateDirectories {
+		filePaths, listErr := stateBackend.List(dir)
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		for _, filePath := range filePaths {
+			if !strings.HasSuffix(filePath, ".yaml") {
+				continue
+			}
+
+			data, readErr := stateBackend.Read(filePath)
+			if readErr != nil {
+				return nil, readErr
+			}
+			files[filePath] = data
+		}
+	}
+
+	return files, nil
+}
@@ -0,0 +1,271 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentStateVersion is the version of the state schema written to state files
+const CurrentStateVersion = 1
+
+const (
+	stateVersionKey = "stateVersion"
+	resourceKey     = "resource"
+)
+
+// stateEnvelope wraps every resource written to a state file with the version of the state schema
+type stateEnvelope struct {
+	StateVersion int       `yaml:"stateVersion"`
+	Resource     yaml.Node `yaml:"resource"`
+}
+
+// StateMigration upgrades the resource of a state document from state version From to From+1. The resource
+// is migrated as a YAML node, before being decoded, so that it can still be read after the DTOs changed.
+type StateMigration struct {
+	From        int
+	Description string
+	Migrate     func(kind string, resource *yaml.Node) error
+}
+
+// stateMigrations holds the registered migrations by the state version they upgrade from
+var stateMigrations = map[int]StateMigration{}
+
+func init() {
+	RegisterStateMigration(StateMigration{
+		From:        0,
+		Description: "wrap resources in a versioned envelope",
+		Migrate:     func(kind string, resource *yaml.Node) error { return nil },
+	})
+}
+
+// RegisterStateMigration registers the migration upgrading state documents from migration.From.
+// Every state version below CurrentStateVersion needs a migration.
+func RegisterStateMigration(migration StateMigration) {
+	if _, exists := stateMigrations[migration.From]; exists {
+		panic(fmt.Sprintf("state migration from version %d registered twice", migration.From))
+	}
+	stateMigrations[migration.From] = migration
+}
+
+// GetStateMigrations returns the registered migrations needed to upgrade from state version from, in order
+func GetStateMigrations(from int) []StateMigration {
+	var migrations []StateMigration
+	for version := from; version < CurrentStateVersion; version++ {
+		if migration, exists := stateMigrations[version]; exists {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations
+}
+
+// OutdatedStateFile is a state file needing `state migrate` to be rewritten in the current format
+type OutdatedStateFile struct {
+	Path string
+	// Version is the oldest state version of the documents of the file
+	Version int
+	// Legacy is set for the single file per kind state, replaced by a directory per kind
+	Legacy bool
+}
+
+// GetOutdatedStateFiles returns the state files written with an older state version or in the legacy layout.
+// They are still read, migrating their documents on load, until `state migrate` rewrites them.
+func GetOutdatedStateFiles() ([]OutdatedStateFile, error) {
+	var outdated []OutdatedStateFile
+	for _, dir := range stateDirectories {
+		filePaths, listErr := stateBackend.List(dir)
+		if listErr != nil {
+			return nil, listErr
+		}
+
+		for _, filePath := range filePaths {
+			legacy := dir == StateLocation
+			if !strings.HasSuffix(filePath, ".yaml") || (legacy && GetLegacyStateKind(filePath) == "") {
+				continue
+			}
+
+			data, readErr := stateBackend.Read(filePath)
+			if readErr != nil {
+				return nil, readErr
+			}
+
+			version, versionErr := getOldestStateVersion(data)
+			if versionErr != nil {
+				return nil, fmt.Errorf("failed to parse state file %s: %w", filePath, versionErr)
+			}
+
+			if legacy || version < CurrentStateVersion {
+				outdated = append(outdated, OutdatedStateFile{Path: filePath, Version: version, Legacy: legacy})
+			}
+		}
+	}
+
+	return outdated, nil
+}
+
+// GetLegacyStateKind returns the kind held by a legacy state file, such as .state/component.yaml,
+// or an empty string for other files
+func GetLegacyStateKind(filePath string) string {
+	if path.Dir(filePath) != StateLocation {
+		return ""
+	}
+
+	for _, dir := range stateDirectories[1:] {
+		kind := path.Base(dir)
+		if matched, _ := path.Match(getKindFileName(fmt.Sprintf("%s*", kind)), path.Base(filePath)); matched {
+			return kind
+		}
+	}
+
+	return ""
+}
+
+func encodeStateData[T any](data []*T) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	for _, item := range data {
+		envelope := stateEnvelope{StateVersion: CurrentStateVersion}
+		if encodeErr := envelope.Resource.Encode(item); encodeErr != nil {
+			return nil, encodeErr
+		}
+		if encodeErr := encoder.Encode(envelope); encodeErr != nil {
+			return nil, encodeErr
+		}
+	}
+	if closeErr := encoder.Close(); closeErr != nil {
+		return nil, closeErr
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// decodeStateBytes decodes the resources of kind tKind of a state file, migrating them to the current state version
+func decodeStateBytes[T any](tKind string, data []byte) ([]*T, error) {
+	var results []*T
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		decodeErr := decoder.Decode(&document)
+		if decodeErr != nil {
+			if errors.Is(decodeErr, io.EOF) {
+				break
+			}
+			return nil, decodeErr
+		}
+
+		resource, version, unwrapErr := unwrapStateDocument(&document)
+		if unwrapErr != nil {
+			return nil, unwrapErr
+		}
+		if resource == nil {
+			continue
+		}
+
+		if err := migrateStateResource(tKind, resource, version); err != nil {
+			return nil, err
+		}
+
+		var result T
+		if err := resource.Decode(&result); err != nil {
+			return nil, err
+		}
+
+		kindField := reflect.ValueOf(result).FieldByName(Kind)
+		if kindField.IsValid() && strings.EqualFold(kindField.String(), tKind) {
+			results = append(results, &result)
+		}
+	}
+
+	return results, nil
+}
+
+// unwrapStateDocument returns the resource of a state document with its state version. Documents written
+// before state versions were introduced hold the resource itself and are of version 0.
+func unwrapStateDocument(document *yaml.Node) (*yaml.Node, int, error) {
+	root := document
+	if root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			return nil, 0, nil
+		}
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return root, 0, nil
+	}
+
+	var versionNode, resourceNode *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case stateVersionKey:
+			versionNode = root.Content[i+1]
+		case resourceKey:
+			resourceNode = root.Content[i+1]
+		}
+	}
+	if versionNode == nil {
+		return root, 0, nil
+	}
+
+	var version int
+	if err := versionNode.Decode(&version); err != nil {
+		return nil, 0, fmt.Errorf("invalid state version: %w", err)
+	}
+	if resourceNode == nil {
+		return nil, 0, fmt.Errorf("state document of version %d has no %s", version, resourceKey)
+	}
+
+	return resourceNode, version, nil
+}
+
+func migrateStateResource(kind string, resource *yaml.Node, version int) error {
+	if version > CurrentStateVersion {
+		return fmt.Errorf(
+			"state version %d is newer than the state version %d supported by this version of of-catalog",
+			version, CurrentStateVersion,
+		)
+	}
+
+	for ; version < CurrentStateVersion; version++ {
+		migration, exists := stateMigrations[version]
+		if !exists {
+			return fmt.Errorf("no migration registered from state version %d", version)
+		}
+		if err := migration.Migrate(kind, resource); err != nil {
+			return fmt.Errorf("failed to migrate %s from state version %d: %w", kind, version, err)
+		}
+	}
+
+	return nil
+}
+
+func getOldestStateVersion(data []byte) (int, error) {
+	oldest := CurrentStateVersion
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		decodeErr := decoder.Decode(&document)
+		if decodeErr != nil {
+			if errors.Is(decodeErr, io.EOF) {
+				break
+			}
+			return 0, decodeErr
+		}
+
+		resource, version, unwrapErr := unwrapStateDocument(&document)
+		if unwrapErr != nil {
+			return 0, unwrapErr
+		}
+		if resource != nil {
+			oldest = min(oldest, version)
+		}
+	}
+
+	return oldest, nil
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/bmatcuk/doublestar/v4"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,11 +23,63 @@ const (
 	MetricStateLocation    = ".state/metric"
 	ScorecardStateLocation = ".state/scorecard"
 	ComponentStateLocation = ".state/component"
+	LockLocation           = ".state/.lock"
+	HistoryLocation        = ".state/.history"
 	Kind                   = "Kind"
 	DTO                    = "DTO"
 	FilePermission         = 0644
 )
 
+// stateBackend stores the state files, the local filesystem unless configured otherwise
+var stateBackend statebackend.Backend = statebackend.NewFilesystemBackend()
+
+// SetStateBackend replaces the backend used to read and write state files
+func SetStateBackend(backend statebackend.Backend) {
+	stateBackend = backend
+}
+
+// GetStateBackend returns the backend used to read and write state files
+func GetStateBackend() statebackend.Backend {
+	return stateBackend
+}
+
+// WithStateLock runs fn while holding the state lock, waiting up to timeout for the lock to be released
+// by other runs. The lock is released whatever fn returns, and the state fn changed, even when it fails,
+// is recorded as a new state version.
+func WithStateLock(operation string, timeout time.Duration, fn func() error) (err error) {
+	lock, lockErr := statebackend.Lock(stateBackend, LockLocation, operation, timeout)
+	if lockErr != nil {
+		return lockErr
+	}
+	defer func() {
+		if unlockErr := statebackend.Unlock(stateBackend, LockLocation, lock.ID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release state lock %s: %w", lock.ID, unlockErr))
+		}
+	}()
+
+	before, readErr := readStateFiles()
+	if readErr != nil {
+		return readErr
+	}
+
+	fnErr := fn()
+	if snapshotErr := snapshotChangedState(operation, before); snapshotErr != nil {
+		return errors.Join(fnErr, fmt.Errorf("failed to record state version: %w", snapshotErr))
+	}
+
+	return fnErr
+}
+
+// GetStateLock returns the holder of the state lock, or nil when the state is not locked
+func GetStateLock() (*statebackend.LockInfo, error) {
+	return statebackend.ReadLock(stateBackend, LockLocation)
+}
+
+// ForceUnlockState removes the state lock held with the given ID
+func ForceUnlockState(id string) error {
+	return statebackend.Unlock(stateBackend, LockLocation, id)
+}
+
 type ParseInput struct {
 	RootLocation string
 	Recursive    bool
@@ -149,17 +204,9 @@ func WriteState[T any](data []*T) error {
 		return kindErr
 	}
 
-	stateFileLocation := filepath.Join(StateLocation, getKindFileName(tKind))
-	dir := filepath.Dir(stateFileLocation)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return err
-	}
-
+	stateFileLocation := path.Join(StateLocation, getKindFileName(tKind))
 	if len(data) == 0 {
-		if err := os.Remove(stateFileLocation); err != nil && !os.IsNotExist(err) {
-			return err
-		}
-		return nil
+		return stateBackend.Delete(stateFileLocation)
 	}
 
 	buffer, encodeErr := encodeData(data)
@@ -167,7 +214,7 @@ func WriteState[T any](data []*T) error {
 		return encodeErr
 	}
 
-	return os.WriteFile(stateFileLocation, buffer, FilePermission)
+	return stateBackend.Write(stateFileLocation, buffer)
 }
 
 // WriteMetricStates writes each metric to its own file in the state/metric/ directory
@@ -187,58 +234,36 @@ func WriteComponentStates[T any](data []*T, getName KeyExtractor[T]) error {
 
 // writeEntityStates is a generic function to write entities to their own files
 func writeEntityStates[T any](data []*T, getName KeyExtractor[T], baseDir string) error {
-	// Create the directory if it doesn't exist
-	if err := os.MkdirAll(baseDir, os.ModePerm); err != nil {
-		return err
-	}
-
-	// Clean up existing files first (removes orphaned state files)
-	if err := cleanupStateDirectory(baseDir); err != nil {
-		return err
-	}
-
-	if len(data) == 0 {
-		return nil
+	existingFiles, listErr := stateBackend.List(baseDir)
+	if listErr != nil {
+		return listErr
 	}
 
 	// Write each entity to its own file
+	writtenFiles := make(map[string]bool, len(data))
 	for _, item := range data {
 		name := getName(item)
-		fileName := fmt.Sprintf("%s.yaml", name)
-		filePath := filepath.Join(baseDir, fileName)
+		filePath := path.Join(baseDir, fmt.Sprintf("%s.yaml", name))
 
 		// Encode the single item
-		buffer, err := encodeData([]*T{item})
+		buffer, err := encodeStateData([]*T{item})
 		if err != nil {
 			return fmt.Errorf("failed to encode entity %s: %w", name, err)
 		}
 
-		// Write to individual file
-		if err := os.WriteFile(filePath, buffer, FilePermission); err != nil {
+		if err := stateBackend.Write(filePath, buffer); err != nil {
 			return fmt.Errorf("failed to write entity file %s: %w", filePath, err)
 		}
+		writtenFiles[filePath] = true
 	}
 
-	return nil
-}
-
-// cleanupStateDirectory removes all yaml files from the state directory
-// This ensures that deleted entities don't leave orphaned state files
-func cleanupStateDirectory(dir string) error {
-	entries, err := os.ReadDir(dir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil // Directory doesn't exist, nothing to clean
+	// Remove the files of entities that are no longer part of the state
+	for _, filePath := range existingFiles {
+		if writtenFiles[filePath] || !strings.HasSuffix(filePath, ".yaml") {
+			continue
 		}
-		return err
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".yaml") {
-			filePath := filepath.Join(dir, entry.Name())
-			if err := os.Remove(filePath); err != nil {
-				return fmt.Errorf("failed to remove file %s: %w", filePath, err)
-			}
+		if err := stateBackend.Delete(filePath); err != nil {
+			return fmt.Errorf("failed to remove file %s: %w", filePath, err)
 		}
 	}
 
@@ -251,6 +276,14 @@ func getDefinitions[T any](parseInput ParseInput) ([]*T, error) {
 		return nil, kindErr
 	}
 
+	if isStateLocation(parseInput.RootLocation) {
+		defintions, parseErr := parseState[T](tKind, parseInput.RootLocation)
+		if parseErr != nil {
+			return nil, errors.Join(fmt.Errorf("failed to parse state at %s", parseInput.RootLocation), parseErr)
+		}
+		return defintions, nil
+	}
+
 	filePath, pathErr := getFilePath[T](tKind, parseInput)
 	if pathErr != nil {
 		return nil, pathErr
@@ -274,20 +307,58 @@ func getFilePath[T any](tKind string, parseInput ParseInput) (string, error) {
 		directory = fmt.Sprintf("%s/**", directory)
 	}
 
-	if isSplitStateDirectory(parseInput.RootLocation) {
-		return filepath.Join(directory, "*.yaml"), nil
-	}
-
 	fileString := fmt.Sprintf("%s*", tKind)
 	return filepath.Join(directory, getKindFileName(fileString)), nil
 }
 
+// parseState decodes the state files stored in the state backend under rootLocation
+func parseState[T any](tKind, rootLocation string) ([]*T, error) {
+	pattern := "*.yaml"
+	if !isSplitStateDirectory(rootLocation) {
+		pattern = getKindFileName(fmt.Sprintf("%s*", tKind))
+	}
+
+	filePaths, listErr := stateBackend.List(rootLocation)
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	var results []*T
+	for _, filePath := range filePaths {
+		if matched, _ := path.Match(pattern, path.Base(filePath)); !matched {
+			continue
+		}
+
+		data, readErr := stateBackend.Read(filePath)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		decodedResults, decodeErr := decodeStateBytes[T](tKind, data)
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to parse state file %s: %w", filePath, decodeErr)
+		}
+		results = append(results, decodedResults...)
+	}
+
+	return results, nil
+}
+
+func isStateLocation(rootLocation string) bool {
+	return strings.TrimRight(rootLocation, "/") == StateLocation || isSplitStateDirectory(rootLocation)
+}
+
 func isSplitStateDirectory(rootLocation string) bool {
 	return rootLocation == MetricStateLocation ||
 		rootLocation == ScorecardStateLocation ||
 		rootLocation == ComponentStateLocation
 }
 
+// Encode returns the YAML documents of data, as written to state files without their state envelope
+func Encode[T any](data []*T) ([]byte, error) {
+	return encodeData(data)
+}
+
 func encodeData[T any](data []*T) ([]byte, error) {
 	var buffer bytes.Buffer
 	encoder := yaml.NewEncoder(&buffer)
@@ -309,6 +380,10 @@ func decodeData[T any](tKind, fileName string) ([]*T, error) {
 		return nil, readErr
 	}
 
+	return decodeBytes[T](tKind, data)
+}
+
+func decodeBytes[T any](tKind string, data []byte) ([]*T, error) {
 	var results []*T
 	decoder := yaml.NewDecoder(bytes.NewReader(data))
 	for {
@@ -2,16 +2,20 @@ package yaml_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/statebackend/s3test"
 	thisyaml "github.com/motain/of-catalog/internal/utils/yaml"
 )
 
@@ -360,3 +364,181 @@ func TestSortResults(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteComponentStates_RemoteBackend(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backend, err := statebackend.NewS3Backend(
+		statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Region: "eu-west-1"},
+		credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		server.Client(),
+	)
+	require.NoError(t, err)
+
+	previousBackend := thisyaml.GetStateBackend()
+	thisyaml.SetStateBackend(backend)
+	defer thisyaml.SetStateBackend(previousBackend)
+
+	getKey := func(def *TestDTO) string { return def.Spec.Name }
+
+	require.NoError(t, thisyaml.WriteComponentStates([]*TestDTO{getTestDTO("John", 30), getTestDTO("Jane", 25)}, getKey))
+	assert.Len(t, server.Objects(), 2)
+	assert.Contains(t, server.Objects(), "state/.state/component/John.yaml")
+
+	// Entities missing from the new state are removed from the backend
+	require.NoError(t, thisyaml.WriteComponentStates([]*TestDTO{getTestDTO("John", 31)}, getKey))
+	assert.Len(t, server.Objects(), 1)
+
+	result, err := thisyaml.Parse(thisyaml.GetComponentStateInput(), getKey)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*TestDTO{"John": getTestDTO("John", 31)}, result)
+
+	_, statErr := os.Stat(thisyaml.ComponentStateLocation)
+	assert.True(t, os.IsNotExist(statErr), "nothing is written to the local filesystem")
+}
+
+func TestWithStateLock_RecordsStateVersions(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backend, err := statebackend.NewS3Backend(
+		statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Region: "eu-west-1"},
+		credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		server.Client(),
+	)
+	require.NoError(t, err)
+
+	previousBackend := thisyaml.GetStateBackend()
+	thisyaml.SetStateBackend(backend)
+	defer thisyaml.SetStateBackend(previousBackend)
+
+	getKey := func(def *TestDTO) string { return def.Spec.Name }
+	write := func(dtos ...*TestDTO) {
+		require.NoError(t, thisyaml.WithStateLock("component apply", 0, func() error {
+			return thisyaml.WriteComponentStates(dtos, getKey)
+		}))
+	}
+
+	write(getTestDTO("John", 30), getTestDTO("Jane", 25))
+	write(getTestDTO("John", 30), getTestDTO("Jane", 25))
+	write(getTestDTO("John", 31))
+	require.NoError(t, thisyaml.WithStateLock("state list", 0, func() error { return nil }))
+
+	history, err := thisyaml.GetStateHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 2, "unchanged states and reads are not recorded")
+	assert.Equal(t, "component apply", history[1].Operation)
+
+	_, files, err := thisyaml.ReadStateVersion(1)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Contains(t, files, ".state/component/Jane.yaml")
+	recorded := map[string]*TestDTO{"John": getTestDTO("John", 30), "Jane": getTestDTO("Jane", 25)}
+
+	require.NoError(t, thisyaml.WithStateLock("state rollback", 0, func() error {
+		return thisyaml.RestoreStateVersion(1)
+	}))
+
+	result, err := thisyaml.Parse(thisyaml.GetComponentStateInput(), getKey)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, result)
+
+	history, err = thisyaml.GetStateHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 3, "restoring a version records a new one")
+	assert.Equal(t, "state rollback", history[2].Operation)
+}
+
+func TestWithStateLock_FailureReleasesLock(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backend, err := statebackend.NewS3Backend(
+		statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Region: "eu-west-1"},
+		credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		server.Client(),
+	)
+	require.NoError(t, err)
+
+	previousBackend := thisyaml.GetStateBackend()
+	thisyaml.SetStateBackend(backend)
+	defer thisyaml.SetStateBackend(previousBackend)
+
+	applyErr := errors.New("apply failed")
+	err = thisyaml.WithStateLock("component apply", 0, func() error {
+		require.NoError(t, thisyaml.WriteComponentStates([]*TestDTO{getTestDTO("John", 30)}, func(def *TestDTO) string { return def.Spec.Name }))
+		return applyErr
+	})
+
+	assert.ErrorIs(t, err, applyErr)
+	lock, lockErr := thisyaml.GetStateLock()
+	require.NoError(t, lockErr)
+	assert.Nil(t, lock, "the lock is released")
+	history, historyErr := thisyaml.GetStateHistory()
+	require.NoError(t, historyErr)
+	assert.Len(t, history, 1, "the state written before the failure is recorded")
+}
+
+func TestParseStateFiles(t *testing.T) {
+	getKey := func(def *TestDTO) string { return def.Spec.Name }
+	john, err := thisyaml.Encode([]*TestDTO{getTestDTO("John", 30)})
+	require.NoError(t, err)
+	legacy, err := thisyaml.Encode([]*TestDTO{getTestDTO("Jane", 25)})
+	require.NoError(t, err)
+
+	files := map[string][]byte{
+		".state/test/John.yaml":       john,
+		".state/test.yaml":            legacy,
+		".state/component/Other.yaml": []byte("kind: Component\nspec:\n  name: [not, a, test]\n"),
+	}
+
+	result, err := thisyaml.ParseStateFiles(files, getKey)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*TestDTO{"John": getTestDTO("John", 30), "Jane": getTestDTO("Jane", 25)}, result)
+}
+
+func TestStateVersions(t *testing.T) {
+	server := s3test.NewServer()
+	defer server.Close()
+
+	backend, err := statebackend.NewS3Backend(
+		statebackend.S3Options{Endpoint: server.URL, Bucket: "state", Region: "eu-west-1"},
+		credentials.NewStaticCredentialsProvider("access", "secret", ""),
+		server.Client(),
+	)
+	require.NoError(t, err)
+
+	previousBackend := thisyaml.GetStateBackend()
+	thisyaml.SetStateBackend(backend)
+	defer thisyaml.SetStateBackend(previousBackend)
+
+	getKey := func(def *TestDTO) string { return def.Spec.Name }
+	unversioned, err := thisyaml.Encode([]*TestDTO{getTestDTO("Jane", 25)})
+	require.NoError(t, err)
+
+	require.NoError(t, thisyaml.WriteComponentStates([]*TestDTO{getTestDTO("John", 30)}, getKey))
+	require.NoError(t, backend.Write(".state/component/Jane.yaml", unversioned))
+	require.NoError(t, backend.Write(".state/component.yaml", unversioned))
+
+	written, err := backend.Read(".state/component/John.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "stateVersion: 1\nresource:\n    apiVersion: v1\n    kind: test\n    spec:\n        name: John\n        age: 30\n", string(written))
+
+	result, err := thisyaml.Parse(thisyaml.GetComponentStateInput(), getKey)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*TestDTO{"John": getTestDTO("John", 30), "Jane": getTestDTO("Jane", 25)}, result, "unversioned state is migrated on load")
+
+	outdated, err := thisyaml.GetOutdatedStateFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []thisyaml.OutdatedStateFile{
+		{Path: ".state/component.yaml", Version: 0, Legacy: true},
+		{Path: ".state/component/Jane.yaml", Version: 0},
+	}, outdated)
+	assert.Equal(t, "component", thisyaml.GetLegacyStateKind(".state/component.yaml"))
+	assert.Len(t, thisyaml.GetStateMigrations(0), thisyaml.CurrentStateVersion)
+
+	require.NoError(t, backend.Write(".state/component/Jane.yaml", []byte("stateVersion: 99\nresource:\n    kind: test\n")))
+	_, err = thisyaml.Parse(thisyaml.GetComponentStateInput(), getKey)
+	assert.ErrorContains(t, err, "state version 99 is newer than the state version 1 supported")
+}
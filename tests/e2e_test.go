@@ -0,0 +1,324 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	component "github.com/motain/of-catalog/internal/modules/component/cmd"
+	history "github.com/motain/of-catalog/internal/modules/history/cmd"
+	metric "github.com/motain/of-catalog/internal/modules/metric/cmd"
+	metricdtos "github.com/motain/of-catalog/internal/modules/metric/dtos"
+	scorecard "github.com/motain/of-catalog/internal/modules/scorecard/cmd"
+	scorecarddtos "github.com/motain/of-catalog/internal/modules/scorecard/dtos"
+	state "github.com/motain/of-catalog/internal/modules/state/cmd"
+	"github.com/motain/of-catalog/internal/services/compassservice/compasstest"
+	"github.com/motain/of-catalog/internal/services/githubservice/githubtest"
+	"github.com/motain/of-catalog/internal/services/historyservice"
+	"github.com/motain/of-catalog/internal/utils/cassette"
+	"github.com/motain/of-catalog/internal/utils/statebackend"
+	"github.com/motain/of-catalog/internal/utils/yaml"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setup starts the Compass and GitHub stand-ins, points the configuration at them and runs the test
+// in an empty working directory holding the state.
+func setup(t *testing.T) (*compasstest.Server, *githubtest.Server, string) {
+	configRoot, err := filepath.Abs("testdata/config")
+	require.NoError(t, err)
+
+	compass := compasstest.NewServer()
+	t.Cleanup(compass.Close)
+
+	github := githubtest.NewServer()
+	t.Cleanup(github.Close)
+	github.AddRepository("bookmarks", "Bookmarks of the users")
+	github.AddFile("bookmarks", "app.toml", "[envs]\nOTEL_SERVICE_NAME = \"bookmarks\"\n")
+	github.AddFile("bookmarks", "openapi.yaml", "openapi: 3.0.0\n")
+	github.AddFile("bookmarks", ".github/workflows/ci.yaml", "uses: motain/onefootball-actions/security@v1\n")
+
+	t.Setenv("COMPASS_HOST", compass.URL)
+	t.Setenv("COMPASS_TOKEN", "token")
+	t.Setenv("COMPASS_CLOUD_ID", compasstest.CloudID)
+	t.Setenv("GITHUB_TOKEN", "token")
+	t.Setenv("GITHUB_API_URL", github.URL)
+	t.Setenv("CATALOG_BACKEND", "compass")
+	t.Setenv("COMPASS_RATE_LIMIT", "0")
+	t.Setenv("COMPASS_RETRY_BASE_DELAY", "1ms")
+	// The Prometheus client is built by compute but not used by the metrics under testdata: with a role
+	// to assume, it does not look the caller identity up when it is built.
+	t.Setenv("PROMETHEUS_URL", "http://prometheus.invalid")
+	t.Setenv("AWS_ROLE", "arn:aws:iam::000000000000:role/e2e")
+
+	workingDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Setenv("PUSH_QUEUE_PATH", filepath.Join(t.TempDir(), "push-queue"))
+	t.Setenv("METRIC_HISTORY_PATH", filepath.Join(t.TempDir(), "metric-history.jsonl"))
+	t.Cleanup(func() { os.Chdir(workingDir) })
+	yaml.SetStateBackend(statebackend.NewFilesystemBackend())
+
+	return compass, github, configRoot
+}
+
+func run(t *testing.T, command *cobra.Command, args ...string) {
+	command.SetArgs(args)
+	require.NoError(t, command.Execute())
+}
+
+func applyAll(t *testing.T, configRoot string) {
+	run(t, metric.Init(), "apply", "-l", filepath.Join(configRoot, "metrics"))
+	run(t, scorecard.Init(), "apply", "-l", filepath.Join(configRoot, "scorecards"))
+	run(t, component.Init(), "apply", "-l", filepath.Join(configRoot, "components"))
+	run(t, component.Init(), "bind")
+}
+
+func TestApplyBindCompute(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+
+	metrics := compass.Metrics()
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "instrumentation-check", metrics[0].Name)
+	assert.Equal(t, "Instrumentation Check", metrics[0].Unit)
+	assert.Equal(t, "security-as-pipeline", metrics[1].Name)
+
+	scorecards := compass.Scorecards()
+	require.Len(t, scorecards, 1)
+	assert.Equal(t, "observability", scorecards[0].Name)
+	require.Len(t, scorecards[0].Criteria, 1)
+	assert.Equal(t, metrics[0].ID, scorecards[0].Criteria[0].MetricDefinitionID)
+	assert.Equal(t, 100, scorecards[0].Criteria[0].Weight)
+
+	components := compass.Components()
+	require.Len(t, components, 1)
+	assert.Equal(t, "svc-bookmarks", components[0].Slug)
+	assert.Equal(t, "Bookmarks of the users", components[0].Description)
+	assert.Equal(t, []string{"engagement"}, components[0].Labels)
+
+	apiSpecifications := compass.APISpecifications()
+	require.Contains(t, apiSpecifications, components[0].ID)
+	assert.Equal(t, "openapi.yaml", apiSpecifications[components[0].ID].FileName)
+
+	metricSources := compass.MetricSources()
+	require.Len(t, metricSources, 2)
+	for _, metricSource := range metricSources {
+		assert.Equal(t, components[0].ID, metricSource.ComponentID)
+	}
+
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "instrumentation-check")
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "security-as-pipeline")
+
+	values := compass.MetricValues()
+	require.Len(t, values, 2)
+	valuesByMetric := make(map[string]float64, len(values))
+	for _, value := range values {
+		for _, metricSource := range metricSources {
+			if metricSource.ID == value.MetricSourceID {
+				valuesByMetric[metricSource.MetricDefinitionID] = value.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]float64{metrics[0].ID: 1, metrics[1].ID: 1}, valuesByMetric)
+}
+
+func TestApplyIsIdempotent(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+	metrics, scorecards, components, metricSources := compass.Metrics(), compass.Scorecards(), compass.Components(), compass.MetricSources()
+	applied := len(compass.Operations())
+
+	applyAll(t, configRoot)
+
+	assert.Equal(t, metrics, compass.Metrics())
+	assert.Equal(t, scorecards, compass.Scorecards())
+	assert.Equal(t, components, compass.Components())
+	assert.Equal(t, metricSources, compass.MetricSources())
+	for _, operation := range compass.Operations()[applied:] {
+		assert.NotContains(t, []string{"createMetricDefinition", "createScorecard", "createComponent", "createMetricSource"}, operation)
+	}
+}
+
+func TestComputeRecordAndReplay(t *testing.T) {
+	compass, github, configRoot := setup(t)
+	t.Cleanup(func() { cassette.Use(cassette.Off, "") })
+	cassetteDir := t.TempDir()
+
+	applyAll(t, configRoot)
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "instrumentation-check", "--record", cassetteDir)
+	require.Len(t, compass.MetricValues(), 1)
+
+	recorded, err := cassette.Load(cassetteDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, recorded.Interactions)
+	assert.Contains(t, recorded.Interactions[0].Request.URL, "/repos/motain/bookmarks/contents/app.toml")
+
+	github.Close()
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "instrumentation-check", "--replay", cassetteDir)
+
+	assert.Len(t, compass.MetricValues(), 1, "replayed values are not pushed")
+	records, err := historyservice.NewHistoryServiceAt(os.Getenv("METRIC_HISTORY_PATH")).Records(historyservice.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "replayed values are not recorded")
+}
+
+func TestComputeReplaysGraphQLFacts(t *testing.T) {
+	compass, _, testdataConfig := setup(t)
+	t.Cleanup(func() { cassette.Use(cassette.Off, "") })
+	cassetteDir := t.TempDir()
+
+	configRoot := t.TempDir()
+	require.NoError(t, os.CopyFS(configRoot, os.DirFS(testdataConfig)))
+	require.NoError(t, os.WriteFile(filepath.Join(configRoot, "metrics", "metric-labels.yaml"), []byte(`---
+apiVersion: of-catalog/v1alpha1
+kind: Metric
+metadata:
+  name: labels-count
+  componentType:
+    - service
+  facts:
+    - id: read-labels
+      name: Read the labels of the component in Compass
+      type: extract
+      source: graphql
+      query: "query component($cloudId: ID!, $slug: String!) { compass { componentByReference(reference: {slug: {slug: $slug, cloudId: $cloudId}}) { ... on CompassComponent { labels { name } } } } }"
+      variables:
+        slug: svc-${Metadata.Name}
+      jsonPath: ".compass.componentByReference.labels[].name"
+      rule: jsonpath
+    - id: labels-count
+      name: Count the labels of the component
+      type: aggregate
+      dependsOn:
+        - read-labels
+      method: count
+spec:
+  name: labels-count
+  description: "Counts the labels of the component"
+  format:
+    unit: "Labels"
+`), 0o644))
+
+	applyAll(t, configRoot)
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "labels-count", "--record", cassetteDir)
+	require.Len(t, compass.MetricValues(), 1)
+
+	recorded, err := cassette.Load(cassetteDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, recorded.Interactions)
+	assert.Contains(t, recorded.Interactions[0].Request.Body, "componentByReference")
+
+	compass.Close()
+	run(t, component.Init(), "compute", "-c", "bookmarks", "-m", "labels-count", "--replay", cassetteDir)
+
+	assert.Len(t, compass.MetricValues(), 1, "replayed values are not pushed")
+}
+
+func TestMetricTest(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	// metric test exits with a non-zero status when a case fails
+	run(t, metric.Init(), "test", "-l", filepath.Join(configRoot, "metrics"))
+
+	assert.Empty(t, compass.Operations(), "test cases run against mocked sources only")
+}
+
+func TestComputeSpoolsValuesWhileCompassIsDown(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+	compass.SetUnavailable(true)
+	run(t, component.Init(), "compute", "-c", "bookmarks", "--all")
+	require.Empty(t, compass.MetricValues())
+
+	compass.SetUnavailable(false)
+	run(t, component.Init(), "push-pending")
+
+	values := compass.MetricValues()
+	require.Len(t, values, 2)
+	for _, value := range values {
+		assert.NotEmpty(t, value.Timestamp, "values are pushed with the time they were computed at")
+	}
+
+	run(t, component.Init(), "push-pending")
+	assert.Len(t, compass.MetricValues(), 2, "pushed values are removed from the queue")
+}
+
+func TestComputeRecordsMetricHistory(t *testing.T) {
+	_, github, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+	run(t, component.Init(), "compute", "-c", "bookmarks", "--all")
+	github.AddFile("bookmarks", "app.toml", "[envs]\nOTEL_SERVICE_NAME = \"favourites\"\n")
+	run(t, component.Init(), "compute", "-c", "bookmarks", "--all")
+
+	records, err := historyservice.NewHistoryServiceAt(os.Getenv("METRIC_HISTORY_PATH")).Records(historyservice.Filter{})
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	changes := historyservice.Diff(records, records[0].RecordedAt)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "bookmarks", changes[0].Component)
+	assert.Equal(t, "instrumentation-check", changes[0].Metric)
+	assert.True(t, changes[0].Decreased())
+
+	run(t, component.Init(), "compute", "-c", "bookmarks", "--all", "--no-push")
+	records, err = historyservice.NewHistoryServiceAt(os.Getenv("METRIC_HISTORY_PATH")).Records(historyservice.Filter{})
+	require.NoError(t, err)
+	assert.Len(t, records, 6, "values computed without being pushed are recorded")
+
+	run(t, history.Init(), "show", "-c", "bookmarks")
+	run(t, history.Init(), "diff", "--since", "1h")
+}
+
+func TestStateRefreshReadsEveryPage(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+	compass.SetPageSize(1)
+	run(t, state.Init(), "refresh", "--update-state")
+
+	metrics, err := yaml.Parse(yaml.GetMetricStateInput(), metricdtos.GetMetricUniqueKey)
+	require.NoError(t, err)
+	assert.Len(t, metrics, 2, "metrics past the first page are not reported missing")
+	scorecards, err := yaml.Parse(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey)
+	require.NoError(t, err)
+	assert.Len(t, scorecards, 1)
+}
+
+func TestStateMvRenamesCompassObjectOnApply(t *testing.T) {
+	compass, _, configRoot := setup(t)
+
+	applyAll(t, configRoot)
+	scorecards := compass.Scorecards()
+	require.Len(t, scorecards, 1)
+
+	config, err := os.ReadFile(filepath.Join(configRoot, "scorecards", "scorecard-observability.yaml"))
+	require.NoError(t, err)
+	renamedConfig := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(renamedConfig, "scorecard-observability.yaml"),
+		[]byte(strings.ReplaceAll(string(config), "name: observability\n", "name: observability-v2\n")),
+		0o644,
+	))
+
+	run(t, state.Init(), "mv", "scorecard", "observability", "observability-v2")
+	applied := len(compass.Operations())
+	run(t, scorecard.Init(), "apply", "-l", renamedConfig)
+
+	renamed := compass.Scorecards()
+	require.Len(t, renamed, 1)
+	assert.Equal(t, scorecards[0].ID, renamed[0].ID)
+	assert.Equal(t, "observability-v2", renamed[0].Name)
+	assert.Equal(t, []string{"updateScorecard"}, compass.Operations()[applied:])
+
+	stateScorecards, err := yaml.Parse(yaml.GetScorecardStateInput(), scorecarddtos.GetScorecardUniqueKey)
+	require.NoError(t, err)
+	require.Contains(t, stateScorecards, "observability-v2")
+	assert.Empty(t, stateScorecards["observability-v2"].Metadata.MovedFrom, "the rename is recorded once applied")
+}